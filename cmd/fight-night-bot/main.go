@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,6 +14,7 @@ import (
 	cfgpkg "github.com/zodakzach/fight-night-discord-bot/internal/config"
 	discpkg "github.com/zodakzach/fight-night-discord-bot/internal/discord"
 	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/metrics"
 	"github.com/zodakzach/fight-night-discord-bot/internal/migrate"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sentryx"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
@@ -21,6 +24,9 @@ import (
 func main() {
 	logx.Init("fight-night-bot")
 	cfg := cfgpkg.Load()
+	if err := cfg.Validate(); err != nil {
+		logx.Fatal("invalid config", "err", err)
+	}
 
 	// Initialize Sentry (no-op if SENTRY_DSN is not set)
 	if err := sentryx.InitFromEnv("fight-night-bot"); err != nil {
@@ -35,16 +41,24 @@ func main() {
 	}
 
 	st := state.Load(cfg.StatePath)
+	st.SetDefaultAnnounce(cfg.DefaultDelivery == cfgpkg.DeliveryAnnouncement)
+	if cfg.BackupDir != "" {
+		logx.Info("periodic db backups enabled", "dir", cfg.BackupDir, "interval", cfg.BackupInterval.String(), "keep", cfg.BackupKeep)
+		st.StartBackups(cfg.BackupDir, cfg.BackupInterval, cfg.BackupKeep)
+	}
 
 	dg, err := discordgo.New("Bot " + cfg.Token)
 	if err != nil {
 		logx.Fatal("discord session init failed", "err", err)
 	}
-	dg.Identify.Intents = discordgo.IntentsGuilds
+	// Reactions power the /status quick toggles.
+	dg.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessageReactions
 
 	// Bind handlers BEFORE opening so we don't miss the initial Ready event.
 	mgr := sources.NewDefaultManager(http.DefaultClient, cfg.UserAgent)
-	discpkg.BindHandlers(dg, st, cfg, mgr)
+	// Shared so /dev-test reload-config can update RUN_AT/TZ for handlers and the notifier.
+	live := cfgpkg.NewHolder(cfg)
+	discpkg.BindHandlers(dg, st, live, mgr)
 
 	logx.Info("opening discord gateway")
 	if err := dg.Open(); err != nil {
@@ -53,7 +67,23 @@ func main() {
 	defer dg.Close()
 	logx.Info("discord gateway opened")
 
-	discpkg.StartNotifier(dg, st, cfg, mgr)
+	discpkg.StartNotifier(dg, st, live, mgr)
+
+	var metricsSrv *http.Server
+	if cfg.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		if cfg.OwnerToken != "" {
+			mux.Handle("/export/post-history", discpkg.HistoryExportHandler(st, cfg.OwnerToken))
+		}
+		metricsSrv = &http.Server{Addr: cfg.MetricsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			logx.Info("metrics server listening", "addr", cfg.MetricsAddr)
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logx.Error("metrics server failed", "err", err)
+			}
+		}()
+	}
 
 	// Graceful shutdown on SIGINT/SIGTERM so Discord session closes cleanly.
 	logx.Info("bot running; waiting for shutdown signal")
@@ -61,6 +91,14 @@ func main() {
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 	<-sigs
 	logx.Info("shutdown signal received; closing session")
+	if cfg.ClearCommandsOnExit {
+		discpkg.UnregisterAllCommands(dg, cfg.DevGuilds)
+	}
+	if metricsSrv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		_ = metricsSrv.Shutdown(ctx)
+		cancel()
+	}
 	// Ensure any buffered Sentry events are sent before exit
 	sentryx.Flush(2 * time.Second)
 }
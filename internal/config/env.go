@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
@@ -15,6 +17,26 @@ const (
 	DefaultRunAt = "16:00" // HH:MM process-local time for daily check
 	// Default SQLite DB file path for persistent state
 	DefaultDBFile = "state.db"
+	// Defaults for optional periodic DB backups (enabled via BACKUP_DIR)
+	DefaultBackupInterval = 24 * time.Hour
+	DefaultBackupKeep     = 7
+	// Consecutive scheduled event failures before a guild's events are turned off
+	DefaultEventFailureLimit = 3
+	// Minimum time left before a short-notice event's start for a same-day scheduled event
+	DefaultSameDayEventGrace = 15 * time.Minute
+)
+
+// Presence modes accepted by PRESENCE_MODE.
+const (
+	PresenceOff       = "off"        // leave the bot's activity unset
+	PresenceStatic    = "static"     // "Watching /help"
+	PresenceNextEvent = "next-event" // "Watching UFC 300 · Sat 10:00 PM EDT"
+)
+
+// Delivery modes accepted by DEFAULT_DELIVERY.
+const (
+	DeliveryMessage      = "message"
+	DeliveryAnnouncement = "announcement"
 )
 
 type Config struct {
@@ -23,14 +45,64 @@ type Config struct {
 	RunAt     string
 	StatePath string
 	TZ        string
-	DevGuild  string
+	// DevGuilds are the dev guild IDs from GUILD_ID (comma-separated). When
+	// set, commands are registered per guild instead of globally.
+	DevGuilds []string
 	UserAgent string
+	// OwnerID is the Discord user ID of the bot operator; it gates
+	// operator-only commands such as /dev-test reload-config.
+	OwnerID string
+	// OwnerToken is the bearer token (OWNER_TOKEN) for operator HTTP
+	// endpoints on the metrics server; empty disables them.
+	OwnerToken string
+
+	// Optional periodic SQLite backups; disabled when BackupDir is empty.
+	BackupDir      string
+	BackupInterval time.Duration
+	BackupKeep     int
+
+	// DefaultDelivery is the delivery mode ("message" or "announcement") for
+	// guilds that haven't chosen one via /settings delivery.
+	DefaultDelivery string
+
+	// AllowedOrgs restricts which orgs a guild may select, keyed by guild ID
+	// (from ALLOWED_ORGS). Guilds without an entry may select any org.
+	AllowedOrgs map[string][]string
+
+	// PresenceMode controls the bot's activity status (PRESENCE_MODE); see the
+	// Presence* constants.
+	PresenceMode string
+
+	// EventFailureLimit is how many consecutive scheduled event creation
+	// failures (EVENT_FAILURE_LIMIT) turn off a guild's scheduled events.
+	EventFailureLimit int
+
+	// SameDayEventGrace is how much time must remain before an event's start
+	// for the notifier to still create its scheduled event on the event day
+	// (SAME_DAY_EVENT_GRACE), e.g. when the event was announced that morning.
+	SameDayEventGrace time.Duration
+
+	// Maintenance pauses all notifier posting (MAINTENANCE) while ticks keep
+	// running; per-guild settings are left untouched.
+	Maintenance bool
+
+	// SkipInitialTick skips the notifier's immediate run at startup and waits
+	// for the first scheduled hourly tick instead.
+	SkipInitialTick bool
+
+	// MetricsAddr is the listen address (METRICS_ADDR, e.g. ":9091") for the
+	// Prometheus /metrics endpoint; empty disables it.
+	MetricsAddr string
+
+	// ClearCommandsOnExit unregisters the bot's global and dev guild slash
+	// commands on shutdown (CLEAR_COMMANDS_ON_EXIT), for development.
+	ClearCommandsOnExit bool
 }
 
 func Load() Config {
 	// Load environment variables from a .env file if present.
 	// Non-fatal: proceed if the file is missing so production env vars still work.
-	if err := godotenv.Load(); err != nil {
+	if err := loadDotEnv(); err != nil {
 		// Informational only when missing; production often omits .env.
 		logx.Debug("godotenv load", "err", err)
 	}
@@ -38,13 +110,77 @@ func Load() Config {
 	// Use DB_FILE, defaulting to a local SQLite file.
 	dbPath := getEnv("DB_FILE", DefaultDBFile)
 	return Config{
-		Token:     mustEnv("DISCORD_TOKEN"),
-		RunAt:     getEnv("RUN_AT", DefaultRunAt),
-		StatePath: dbPath,
-		TZ:        getEnv("TZ", DefaultTZ),
-		DevGuild:  os.Getenv("GUILD_ID"),
-		UserAgent: getEnv("USER_AGENT", "ufc-fight-night-notifier/1.0 (contact: zach@codeezy.dev)"),
+		Token:      mustEnv("DISCORD_TOKEN"),
+		RunAt:      getEnv("RUN_AT", DefaultRunAt),
+		StatePath:  dbPath,
+		TZ:         getEnv("TZ", DefaultTZ),
+		DevGuilds:  splitList(os.Getenv("GUILD_ID")),
+		OwnerID:    strings.TrimSpace(os.Getenv("OWNER_ID")),
+		OwnerToken: strings.TrimSpace(os.Getenv("OWNER_TOKEN")),
+		UserAgent:  getEnv("USER_AGENT", "ufc-fight-night-notifier/1.0 (contact: zach@codeezy.dev)"),
+
+		BackupDir:      strings.TrimSpace(os.Getenv("BACKUP_DIR")),
+		BackupInterval: getDurationEnv("BACKUP_INTERVAL", DefaultBackupInterval),
+		BackupKeep:     getIntEnv("BACKUP_KEEP", DefaultBackupKeep),
+
+		DefaultDelivery: strings.ToLower(strings.TrimSpace(getEnv("DEFAULT_DELIVERY", DeliveryMessage))),
+		AllowedOrgs:     parseAllowedOrgs(os.Getenv("ALLOWED_ORGS")),
+
+		PresenceMode:      strings.ToLower(strings.TrimSpace(getEnv("PRESENCE_MODE", PresenceOff))),
+		EventFailureLimit: getIntEnv("EVENT_FAILURE_LIMIT", DefaultEventFailureLimit),
+		SameDayEventGrace: getDurationEnv("SAME_DAY_EVENT_GRACE", DefaultSameDayEventGrace),
+		Maintenance:       getBoolEnv("MAINTENANCE"),
+		SkipInitialTick:   getBoolEnv("SKIP_INITIAL_TICK"),
+		MetricsAddr:       strings.TrimSpace(os.Getenv("METRICS_ADDR")),
+
+		ClearCommandsOnExit: getBoolEnv("CLEAR_COMMANDS_ON_EXIT"),
+	}
+}
+
+// OrgAllowed reports whether the guild may select org. Guilds without an
+// ALLOWED_ORGS entry may select any org.
+func (c Config) OrgAllowed(guildID, org string) bool {
+	allowed, ok := c.AllowedOrgs[guildID]
+	if !ok {
+		return true
+	}
+	org = strings.ToLower(strings.TrimSpace(org))
+	for _, a := range allowed {
+		if a == org {
+			return true
+		}
 	}
+	return false
+}
+
+// parseAllowedOrgs parses ALLOWED_ORGS entries of the form
+// "<guild_id>=<org>[|<org>...]" separated by ";" (e.g., "111=ufc;222=ufc|pfl").
+// Org keys are lowercased. Malformed entries are skipped with a warning.
+func parseAllowedOrgs(v string) map[string][]string {
+	var out map[string][]string
+	for _, entry := range strings.Split(v, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		gid, orgs, ok := strings.Cut(entry, "=")
+		gid = strings.TrimSpace(gid)
+		var list []string
+		for _, o := range strings.Split(orgs, "|") {
+			if o = strings.ToLower(strings.TrimSpace(o)); o != "" {
+				list = append(list, o)
+			}
+		}
+		if !ok || gid == "" || len(list) == 0 {
+			logx.Warn("invalid ALLOWED_ORGS entry; skipping", "entry", entry)
+			continue
+		}
+		if out == nil {
+			out = map[string][]string{}
+		}
+		out[gid] = append(out[gid], list...)
+	}
+	return out
 }
 
 func getEnv(k, def string) string {
@@ -55,6 +191,61 @@ func getEnv(k, def string) string {
 	return v
 }
 
+// splitList parses a comma-separated list, trimming whitespace and dropping
+// empty and duplicate entries. Returns nil when nothing remains.
+func splitList(v string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, p := range strings.Split(v, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// getBoolEnv reports whether k is set to an affirmative value (1/true/yes,
+// case-insensitive).
+func getBoolEnv(k string) bool {
+	switch strings.TrimSpace(strings.ToLower(os.Getenv(k))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// getDurationEnv parses a Go duration (e.g., "6h"), falling back to def when
+// unset or invalid/non-positive.
+func getDurationEnv(k string, def time.Duration) time.Duration {
+	v := strings.TrimSpace(os.Getenv(k))
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		logx.Warn("invalid duration env; using default", "key", k, "value", v, "default", def.String())
+		return def
+	}
+	return d
+}
+
+// getIntEnv parses a positive integer, falling back to def when unset or invalid.
+func getIntEnv(k string, def int) int {
+	v := strings.TrimSpace(os.Getenv(k))
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		logx.Warn("invalid integer env; using default", "key", k, "value", v, "default", def)
+		return def
+	}
+	return n
+}
+
 func mustEnv(k string) string {
 	v := os.Getenv(k)
 	if strings.TrimSpace(v) == "" {
@@ -79,6 +270,33 @@ func LiveESPNEnabled() bool {
 	return false
 }
 
+var (
+	dotenvMu   sync.Mutex
+	dotenvKeys = map[string]bool{} // keys whose current value came from .env
+)
+
+// loadDotEnv reads .env into the environment without overriding variables the
+// process was started with. Keys it set earlier are refreshed, so a reload
+// picks up edits to .env while the real environment keeps precedence.
+func loadDotEnv() error {
+	vals, err := godotenv.Read()
+	if err != nil {
+		return err
+	}
+	dotenvMu.Lock()
+	defer dotenvMu.Unlock()
+	for k, v := range vals {
+		if _, set := os.LookupEnv(k); set && !dotenvKeys[k] {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return err
+		}
+		dotenvKeys[k] = true
+	}
+	return nil
+}
+
 var dotenvOnce sync.Once
 
 // loadDotEnvUpward attempts to load a .env starting from the current working
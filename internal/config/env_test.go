@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"sync"
@@ -85,8 +86,8 @@ func Test_Load_UsesDefaultsAndEnv(t *testing.T) {
 	if cfg.StatePath != DefaultDBFile {
 		t.Fatalf("StatePath default mismatch: %q", cfg.StatePath)
 	}
-	if cfg.DevGuild != "" {
-		t.Fatalf("DevGuild expected empty, got %q", cfg.DevGuild)
+	if len(cfg.DevGuilds) != 0 {
+		t.Fatalf("DevGuilds expected empty, got %q", cfg.DevGuilds)
 	}
 	if !strings.Contains(cfg.UserAgent, "ufc-fight-night-notifier") {
 		t.Fatalf("UserAgent default mismatch: %q", cfg.UserAgent)
@@ -102,11 +103,50 @@ func Test_Load_WithEnvOverrides(t *testing.T) {
 	t.Setenv("USER_AGENT", "custom-agent/1.0")
 
 	cfg := Load()
-	if cfg.Token != "xyz" || cfg.RunAt != "10:30" || cfg.TZ != "Europe/London" || cfg.StatePath != "/tmp/test.db" || cfg.DevGuild != "123" || cfg.UserAgent != "custom-agent/1.0" {
+	if cfg.Token != "xyz" || cfg.RunAt != "10:30" || cfg.TZ != "Europe/London" || cfg.StatePath != "/tmp/test.db" || !reflect.DeepEqual(cfg.DevGuilds, []string{"123"}) || cfg.UserAgent != "custom-agent/1.0" {
 		t.Fatalf("unexpected cfg: %+v", cfg)
 	}
 }
 
+func Test_Load_MultipleDevGuilds(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	t.Setenv("GUILD_ID", "111, 222,,111")
+
+	cfg := Load()
+	if !reflect.DeepEqual(cfg.DevGuilds, []string{"111", "222"}) {
+		t.Fatalf("expected two dev guilds, got %q", cfg.DevGuilds)
+	}
+}
+
+func Test_Load_DefaultDelivery(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	t.Setenv("DEFAULT_DELIVERY", "")
+	if got := Load().DefaultDelivery; got != DeliveryMessage {
+		t.Fatalf("expected message by default, got %q", got)
+	}
+	t.Setenv("DEFAULT_DELIVERY", " Announcement ")
+	if got := Load().DefaultDelivery; got != DeliveryAnnouncement {
+		t.Fatalf("expected announcement, got %q", got)
+	}
+}
+
+func Test_Load_AllowedOrgs(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	t.Setenv("ALLOWED_ORGS", "111=UFC; 222=ufc|pfl; bogus; 333=")
+
+	cfg := Load()
+	want := map[string][]string{"111": {"ufc"}, "222": {"ufc", "pfl"}}
+	if !reflect.DeepEqual(cfg.AllowedOrgs, want) {
+		t.Fatalf("allowed orgs: got %v want %v", cfg.AllowedOrgs, want)
+	}
+	if !cfg.OrgAllowed("111", "ufc") || cfg.OrgAllowed("111", "pfl") {
+		t.Fatalf("expected guild 111 limited to ufc")
+	}
+	if !cfg.OrgAllowed("999", "pfl") {
+		t.Fatalf("expected unlisted guild to allow any org")
+	}
+}
+
 func Test_LiveESPNEnabled_DefaultFalse(t *testing.T) {
 	// Reset once to allow executing the loader and avoid picking up repo root .env
 	oldWD, _ := os.Getwd()
@@ -181,3 +221,50 @@ func Test_loadDotEnvUpward_LoadsFromParent(t *testing.T) {
 	}
 	_ = time.Now() // keep time import used
 }
+
+func Test_Load_BackupSettings(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	t.Setenv("BACKUP_DIR", "")
+	t.Setenv("BACKUP_INTERVAL", "")
+	t.Setenv("BACKUP_KEEP", "")
+	cfg := Load()
+	if cfg.BackupDir != "" || cfg.BackupInterval != DefaultBackupInterval || cfg.BackupKeep != DefaultBackupKeep {
+		t.Fatalf("unexpected backup defaults: %+v", cfg)
+	}
+
+	t.Setenv("BACKUP_DIR", "/data/backups")
+	t.Setenv("BACKUP_INTERVAL", "6h")
+	t.Setenv("BACKUP_KEEP", "3")
+	cfg = Load()
+	if cfg.BackupDir != "/data/backups" || cfg.BackupInterval != 6*time.Hour || cfg.BackupKeep != 3 {
+		t.Fatalf("unexpected backup overrides: %+v", cfg)
+	}
+
+	// Invalid values fall back to defaults
+	t.Setenv("BACKUP_INTERVAL", "soon")
+	t.Setenv("BACKUP_KEEP", "-1")
+	cfg = Load()
+	if cfg.BackupInterval != DefaultBackupInterval || cfg.BackupKeep != DefaultBackupKeep {
+		t.Fatalf("expected defaults for invalid values: %+v", cfg)
+	}
+}
+
+func Test_Load_SkipInitialTick(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	for in, want := range map[string]bool{"": false, "0": false, "1": true, "TRUE": true, "yes": true, "nope": false} {
+		t.Setenv("SKIP_INITIAL_TICK", in)
+		if got := Load().SkipInitialTick; got != want {
+			t.Fatalf("SKIP_INITIAL_TICK=%q: got %v want %v", in, got, want)
+		}
+	}
+}
+
+func Test_Load_ClearCommandsOnExit(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "xyz")
+	for in, want := range map[string]bool{"": false, "0": false, "1": true, "true": true} {
+		t.Setenv("CLEAR_COMMANDS_ON_EXIT", in)
+		if got := Load().ClearCommandsOnExit; got != want {
+			t.Fatalf("CLEAR_COMMANDS_ON_EXIT=%q: got %v want %v", in, got, want)
+		}
+	}
+}
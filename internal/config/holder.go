@@ -0,0 +1,56 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+)
+
+// Holder shares the running Config between the notifier and command handlers
+// so that hot-reloadable fields can be changed without a restart. Readers call
+// Get for a consistent snapshot; Reload swaps in a new one.
+//
+// Only RunAt, TZ, and Maintenance are hot-reloadable. Everything else (token, DB path, dev
+// guild, user agent, backups, initial tick) is bound at startup and keeps its
+// current value across reloads.
+type Holder struct {
+	mu sync.Mutex // serializes Reload
+	v  atomic.Pointer[Config]
+}
+
+// NewHolder returns a Holder seeded with cfg.
+func NewHolder(cfg Config) *Holder {
+	h := &Holder{}
+	h.v.Store(&cfg)
+	return h
+}
+
+// Get returns a snapshot of the current config.
+func (h *Holder) Get() Config {
+	return *h.v.Load()
+}
+
+// Reload re-reads the environment (and .env, if present, for keys the real
+// environment does not set) and applies the hot-reloadable fields. It returns
+// the previous and new config; if the new values fail Validate, nothing is
+// applied and the error is returned.
+func (h *Holder) Reload() (prev, next Config, err error) {
+	if err := loadDotEnv(); err != nil {
+		logx.Debug("godotenv load", "err", err)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prev = h.Get()
+	next = prev
+	next.RunAt = getEnv("RUN_AT", DefaultRunAt)
+	next.TZ = getEnv("TZ", DefaultTZ)
+	next.Maintenance = getBoolEnv("MAINTENANCE")
+	if err := next.Validate(); err != nil {
+		logx.Warn("config reload rejected", "err", err)
+		return prev, prev, err
+	}
+	h.v.Store(&next)
+	logx.Info("config reloaded", "run_at", next.RunAt, "tz", next.TZ, "prev_run_at", prev.RunAt, "prev_tz", prev.TZ, "maintenance", next.Maintenance)
+	return prev, next, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHolder_ReloadAppliesRunAtOnly(t *testing.T) {
+	h := NewHolder(Config{Token: "tok", RunAt: "16:00", TZ: "UTC", StatePath: "state.db", UserAgent: "agent/1.0"})
+
+	t.Setenv("RUN_AT", "18:00")
+	t.Setenv("TZ", "UTC")
+	t.Setenv("DISCORD_TOKEN", "other")
+	t.Setenv("DB_FILE", "other.db")
+
+	prev, next, err := h.Reload()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if prev.RunAt != "16:00" || next.RunAt != "18:00" {
+		t.Fatalf("expected RUN_AT 16:00 -> 18:00, got %q -> %q", prev.RunAt, next.RunAt)
+	}
+	got := h.Get()
+	if got.RunAt != "18:00" {
+		t.Fatalf("expected holder to serve reloaded RUN_AT, got %q", got.RunAt)
+	}
+	if got.Token != "tok" || got.StatePath != "state.db" {
+		t.Fatalf("expected startup-only fields unchanged, got token=%q db=%q", got.Token, got.StatePath)
+	}
+}
+
+func TestHolder_ReloadRejectsInvalid(t *testing.T) {
+	h := NewHolder(Config{RunAt: "16:00", TZ: "UTC", UserAgent: "agent/1.0"})
+	t.Setenv("RUN_AT", "25:00")
+	t.Setenv("TZ", "UTC")
+
+	if _, _, err := h.Reload(); err == nil {
+		t.Fatalf("expected invalid RUN_AT to be rejected")
+	}
+	if got := h.Get().RunAt; got != "16:00" {
+		t.Fatalf("expected previous RUN_AT kept, got %q", got)
+	}
+}
+
+func TestHolder_ReloadKeepsRealEnvOverDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("RUN_AT=09:00\nTZ=UTC\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv("RUN_AT", "18:00")
+	t.Setenv("TZ", "")
+	os.Unsetenv("TZ")
+	t.Cleanup(func() { delete(dotenvKeys, "TZ") })
+
+	h := NewHolder(Config{RunAt: "16:00", TZ: "America/New_York", UserAgent: "agent/1.0"})
+	_, next, err := h.Reload()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if next.RunAt != "18:00" {
+		t.Fatalf("expected the real RUN_AT to win over .env, got %q", next.RunAt)
+	}
+	if next.TZ != "UTC" {
+		t.Fatalf("expected TZ from .env when unset, got %q", next.TZ)
+	}
+
+	// Edits to .env are picked up for keys it supplied.
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("RUN_AT=09:00\nTZ=Europe/London\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, next, err = h.Reload(); err != nil || next.TZ != "Europe/London" || next.RunAt != "18:00" {
+		t.Fatalf("expected edited .env TZ with real RUN_AT, got tz=%q run_at=%q err=%v", next.TZ, next.RunAt, err)
+	}
+}
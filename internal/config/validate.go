@@ -0,0 +1,50 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate checks fields that would otherwise only fail (or silently fall back)
+// at runtime. It reports every problem found, not just the first.
+func (c Config) Validate() error {
+	var errs []error
+	if err := validateHHMM(c.RunAt); err != nil {
+		errs = append(errs, fmt.Errorf("RUN_AT %q: %w", c.RunAt, err))
+	}
+	if _, err := time.LoadLocation(c.TZ); err != nil || strings.TrimSpace(c.TZ) == "" {
+		errs = append(errs, fmt.Errorf("TZ %q: not a valid IANA timezone", c.TZ))
+	}
+	switch c.DefaultDelivery {
+	case "", DeliveryMessage, DeliveryAnnouncement:
+	default:
+		errs = append(errs, fmt.Errorf("DEFAULT_DELIVERY %q: expected message or announcement", c.DefaultDelivery))
+	}
+	switch c.PresenceMode {
+	case "", PresenceOff, PresenceStatic, PresenceNextEvent:
+	default:
+		errs = append(errs, fmt.Errorf("PRESENCE_MODE %q: expected off, static, or next-event", c.PresenceMode))
+	}
+	if strings.TrimSpace(c.UserAgent) == "" {
+		errs = append(errs, errors.New("USER_AGENT: must not be empty"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateHHMM checks a 24-hour HH:MM time such as "16:00".
+func validateHHMM(s string) error {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok {
+		return errors.New("expected HH:MM")
+	}
+	if h, err := strconv.Atoi(hh); err != nil || h < 0 || h > 23 {
+		return errors.New("invalid hour")
+	}
+	if m, err := strconv.Atoi(mm); err != nil || m < 0 || m > 59 {
+		return errors.New("invalid minute")
+	}
+	return nil
+}
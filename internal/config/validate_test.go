@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func validConfig() Config {
+	return Config{RunAt: "16:00", TZ: "America/New_York", UserAgent: "agent/1.0"}
+}
+
+func TestValidate_OK(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidate_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		want   string
+	}{
+		{"run_at format", func(c *Config) { c.RunAt = "4pm" }, "RUN_AT"},
+		{"run_at hour", func(c *Config) { c.RunAt = "24:00" }, "RUN_AT"},
+		{"run_at minute", func(c *Config) { c.RunAt = "16:60" }, "RUN_AT"},
+		{"tz", func(c *Config) { c.TZ = "Mars/Olympus" }, "TZ"},
+		{"user agent", func(c *Config) { c.UserAgent = "  " }, "USER_AGENT"},
+		{"default delivery", func(c *Config) { c.DefaultDelivery = "pigeon" }, "DEFAULT_DELIVERY"},
+		{"presence mode", func(c *Config) { c.PresenceMode = "loud" }, "PRESENCE_MODE"},
+	}
+	for _, tc := range tests {
+		c := validConfig()
+		tc.mutate(&c)
+		err := c.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error mentioning %s, got %v", tc.name, tc.want, err)
+		}
+	}
+}
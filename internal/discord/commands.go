@@ -1,9 +1,14 @@
 package discord
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 
@@ -13,6 +18,42 @@ import (
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
+// maxCustomMessageLen bounds guild-written message text and templates, well
+// under Discord's 2000-character message limit.
+const maxCustomMessageLen = 500
+
+// customMessageTooLong reports whether text exceeds maxCustomMessageLen.
+// Characters are counted as Discord does, not bytes, so emoji and accented
+// names aren't cut short.
+func customMessageTooLong(text string) bool {
+	return utf8.RuneCountInString(text) > maxCustomMessageLen
+}
+
+// maxEventNamePrefixLen bounds the scheduled event name prefix, leaving most of
+// Discord's 100-character name limit for the event name.
+const maxEventNamePrefixLen = 40
+
+// maxAnnounceDaysLimit caps the /settings max-announce-days window.
+const maxAnnounceDaysLimit = 365
+
+// maxMainCardSize caps /settings embed main-card-size.
+const maxMainCardSize = 10
+
+// maxFightWeekDays caps the /settings extra-posts fight-week lead.
+const maxFightWeekDays = 14
+
+// maxLiveSoonMinutes caps the /settings extra-posts live-soon-reminder lead.
+const maxLiveSoonMinutes = 120
+
+// maxReminderOffsets and maxReminderOffsetHours bound /settings extra-posts reminders.
+const (
+	maxReminderOffsets     = 5
+	maxReminderOffsetHours = 168
+)
+
+// maxScheduledEventLeadDays caps the /settings scheduled-event-lead window.
+const maxScheduledEventLeadDays = 60
+
 func handleInteraction(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 	if ic.Type != discordgo.InteractionApplicationCommand {
 		return
@@ -24,10 +65,7 @@ func handleInteraction(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 	}
 
 	// Trace which command was invoked and by whom
-	userID := ""
-	if ic.Member != nil && ic.Member.User != nil {
-		userID = ic.Member.User.ID
-	}
+	userID, _ := interactionUserID(ic)
 	logx.Debug("slash command invoked", "name", data.Name, "guild_id", ic.GuildID, "channel_id", ic.ChannelID, "user_id", userID)
 
 	// Measure how long the command execution takes
@@ -47,24 +85,20 @@ func handleOrgSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 	}
 
 	// Permission check similar to set-channel
-	ok, err := hasManageOrAdmin(s, ic.Member.User.ID, ic.ChannelID)
-	if err != nil {
-		replyEphemeral(s, ic, "Could not check permissions.")
-		return
-	}
-	if !ok {
-		replyEphemeral(s, ic, "You need Manage Channels permission to change org settings.")
+	if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change org settings.") {
 		return
 	}
 
 	group := data.Options[0]
 	if group.Name == "ufc" {
 		if len(group.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /org-settings ufc contender-ignore|contender-include")
+			replyEphemeral(s, ic, "Usage: /org-settings ufc contender-ignore|contender-include|events")
 			return
 		}
 		sub := group.Options[0]
 		switch sub.Name {
+		case "events":
+			handleOrgEventsSetting(s, ic, st, group.Name, sub)
 		case "contender-ignore":
 			st.UpdateGuildUFCIgnoreContender(ic.GuildID, true)
 			replyEphemeral(s, ic, "UFC Contender Series will be ignored.")
@@ -80,7 +114,33 @@ func handleOrgSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 	replyEphemeral(s, ic, "Unknown org. Currently supported: ufc")
 }
 
-// handleCreateEvent: dev-only helper to create a scheduled event for the next org event.
+// handleOrgEventsSetting excludes or re-includes an org from scheduled event creation.
+// The guild-wide /settings events toggle still has to be on for included orgs.
+func handleOrgEventsSetting(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, org string, sub *discordgo.ApplicationCommandInteractionDataOption) {
+	display := sources.DisplayOrg(org)
+	if len(sub.Options) == 0 {
+		replyEphemeral(s, ic, display+" scheduled events are currently "+onOff(!st.GetGuildOrgEventsExcluded(ic.GuildID, org))+".")
+		return
+	}
+	switch sub.Options[0].StringValue() {
+	case "on":
+		st.UpdateGuildOrgEventsExcluded(ic.GuildID, org, false)
+		msg := display + " scheduled events enabled."
+		if !st.GetGuildEventsEnabled(ic.GuildID) {
+			msg += " Scheduled events are off server-wide; turn them on with /settings events."
+		}
+		replyEphemeral(s, ic, msg)
+	case "off":
+		st.UpdateGuildOrgEventsExcluded(ic.GuildID, org, true)
+		replyEphemeral(s, ic, display+" scheduled events disabled.")
+	default:
+		replyEphemeral(s, ic, "Invalid state. Use on or off.")
+	}
+}
+
+// handleCreateEvent creates a scheduled event for the next org event on demand
+// (/create-event, or /dev-test create-event). It ignores the events toggles,
+// which only control the automatic day-before creation.
 func handleCreateEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 	// Basic checks
 	if ic.GuildID == "" {
@@ -91,9 +151,9 @@ func handleCreateEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 		replyEphemeral(s, ic, "Set an organization first with /settings org")
 		return
 	}
-	// Permission: require Manage Events for invoker to reduce abuse during testing
+	// Permission: require Manage Events, the same right needed to create one by hand
 	if ic.Member == nil || (ic.Member.Permissions&discordgo.PermissionManageEvents) == 0 {
-		replyEphemeral(s, ic, "You need Manage Events to use this (dev).")
+		replyEphemeral(s, ic, "You need Manage Events to create events.")
 		return
 	}
 
@@ -110,7 +170,8 @@ func handleCreateEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 	// Use provider to select next/ongoing event in guild TZ
 	evt, ok, err := pickNextEvent(ctx, provider)
 	if err != nil {
-		replyEphemeral(s, ic, "Error fetching events: "+err.Error())
+		logx.Warn("create-event: fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		replyEphemeral(s, ic, fetchErrorText(err))
 		return
 	}
 	if !ok {
@@ -118,6 +179,10 @@ func handleCreateEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 		return
 	}
 
+	if strings.TrimSpace(evt.Start) == "" {
+		replyEphemeral(s, ic, "The next event's date is TBA; nothing to schedule yet.")
+		return
+	}
 	// Prevent duplicates: check by the event's local date
 	stUTC, err := parseAPITime(evt.Start)
 	if err != nil {
@@ -126,29 +191,31 @@ func handleCreateEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st
 	}
 	pickAt := stUTC.In(loc)
 	evDateKey := pickAt.In(loc).Format("2006-01-02")
-	if st.HasScheduledEvent(ic.GuildID, org, evDateKey) {
-		replyEphemeral(s, ic, "An event already exists for "+evDateKey+".")
-		return
+	// A tracked event an admin since deleted can be created again on request.
+	if eventID, _, ok := st.ScheduledEvent(ic.GuildID, org, evDateKey); ok {
+		if _, err := fetchGuildScheduledEvent(s, ic.GuildID, eventID); !scheduledEventGone(err) {
+			replyEphemeral(s, ic, "An event already exists for "+evDateKey+".")
+			return
+		}
 	}
 
-	startAt := pickAt
-	endAt := startAt.Add(3 * time.Hour)
-	params := &discordgo.GuildScheduledEventParams{
-		Name:               strings.ToUpper(org) + ": " + evt.Name,
-		Description:        "Created by dev command",
-		ScheduledStartTime: &startAt,
-		ScheduledEndTime:   &endAt,
-		PrivacyLevel:       discordgo.GuildScheduledEventPrivacyLevelGuildOnly,
-		EntityType:         discordgo.GuildScheduledEventEntityTypeExternal,
-		EntityMetadata:     &discordgo.GuildScheduledEventEntityMetadata{Location: "TBD"},
+	footer := "Created with /create-event"
+	if ic.ApplicationCommandData().Name == "dev-test" {
+		footer = "Created by dev command"
 	}
-	ev, err := s.GuildScheduledEventCreate(ic.GuildID, params)
+	params := scheduledEventParams(org, st.GetGuildEventNamePrefix(ic.GuildID), evt, pickAt, footer)
+	if err := validateScheduledEventParams(params, time.Now()); err != nil {
+		replyEphemeral(s, ic, "Cannot create event: "+err.Error())
+		return
+	}
+	ev, err := createGuildScheduledEvent(s, ic.GuildID, params)
 	if err != nil {
 		replyEphemeral(s, ic, "Create failed: "+err.Error())
 		return
 	}
 	// Track by local date key to avoid duplicate creates
 	st.MarkScheduledEvent(ic.GuildID, org, evDateKey, ev.ID)
+	announceScheduledEvent(s, st, ic.GuildID, evt, ev.ID)
 	replyEphemeral(s, ic, "Scheduled event created: "+ev.Name)
 }
 
@@ -176,7 +243,7 @@ func handleCreateAnnouncement(s *discordgo.Session, ic *discordgo.InteractionCre
 	}
 
 	// Use the notifier code path with force=true to ensure it posts even when not event day.
-	posted, reason := notifyGuildCore(s, st, ic.GuildID, mgr, cfg, true, chID)
+	posted, reason := notifyGuildCore(s, st, ic.GuildID, mgr, cfg, time.Now(), true, chID)
 	if posted {
 		replyEphemeral(s, ic, "Announcement posted to <#"+chID+">")
 		return
@@ -185,7 +252,17 @@ func handleCreateAnnouncement(s *discordgo.Session, ic *discordgo.InteractionCre
 }
 
 func handleStatus(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
-	ch, tz, _ := st.GetGuildSettings(ic.GuildID)
+	text := statusText(st, cfg, ic.GuildID)
+	if commandBoolOption(ic, "reactions") {
+		postStatusWithReactions(s, ic, text)
+		return
+	}
+	replyEphemeral(s, ic, text)
+}
+
+// statusText summarizes the guild's current settings for /status.
+func statusText(st *state.Store, cfg config.Config, guildID string) string {
+	ch, tz, _ := st.GetGuildSettings(guildID)
 	if ch == "" {
 		ch = "(not set)"
 	}
@@ -193,50 +270,237 @@ func handleStatus(s *discordgo.Session, ic *discordgo.InteractionCreate, st *sta
 		tz = cfg.TZ
 	}
 	orgDisplay := "(not set)"
-	if st.HasGuildOrg(ic.GuildID) {
-		orgDisplay = strings.ToUpper(st.GetGuildOrg(ic.GuildID))
-	}
-	notify := "off"
-	if st.GetGuildNotifyEnabled(ic.GuildID) {
-		notify = "on"
-	}
-	events := "off"
-	if st.GetGuildEventsEnabled(ic.GuildID) {
-		events = "on"
+	if st.HasGuildOrg(guildID) {
+		orgDisplay = sources.DisplayOrg(st.GetGuildOrg(guildID))
 	}
+	notify := onOff(st.GetGuildNotifyEnabled(guildID))
+	events := onOff(st.GetGuildEventsEnabled(guildID))
 	delivery := "message"
-	if st.GetGuildAnnounceEnabled(ic.GuildID) {
+	if st.GetGuildAnnounceEnabled(guildID) {
 		delivery = "announcement"
 	}
 	runAt := cfg.RunAt
-	if h := st.GetGuildRunHour(ic.GuildID); h >= 0 {
+	if h := st.GetGuildRunHour(guildID); h >= 0 {
 		runAt = fmt.Sprintf("%02d:00", h)
 	}
+	if st.GetGuildRunTimeReference(guildID) == runTimeUTC {
+		runAt += " UTC"
+	}
 	msg := fmt.Sprintf(
 		"Channel: %s\nTimezone: %s\nOrg: %s\nNotifications: %s\nEvents: %s\nDelivery: %s\nRun time: %s",
 		ch, tz, orgDisplay, notify, events, delivery, runAt,
 	)
+	fallback := st.GetGuildFallbackTZ(guildID)
+	if fallback != "" {
+		msg += "\nFallback timezone: " + fallback
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		using := "server local time"
+		if _, fbErr := time.LoadLocation(fallback); fallback != "" && fbErr == nil {
+			using = fallback
+		}
+		msg += fmt.Sprintf("\nWarning: timezone %s is no longer recognized, so times use %s. Re-set it with /settings timezone.", tz, using)
+	}
 	// Append UFC-specific status when applicable
-	if strings.EqualFold(orgDisplay, "UFC") || st.GetGuildOrg(ic.GuildID) == "ufc" {
-		if st.GetGuildUFCIgnoreContender(ic.GuildID) {
+	if st.GetGuildOrg(guildID) == "ufc" {
+		if st.GetGuildUFCIgnoreContender(guildID) {
 			msg += "\nUFC Contender Series: ignored"
 		} else {
 			msg += "\nUFC Contender Series: included"
 		}
+		if st.GetGuildOrgEventsExcluded(guildID, "ufc") {
+			msg += "\nUFC scheduled events: off"
+		}
+	}
+	if alert := st.GetGuildAlertChannel(guildID); alert != "" {
+		msg += "\nAlert channel: " + alert
+	}
+	if evCh := st.GetGuildEventChannel(guildID); evCh != "" {
+		msg += "\nEvent link channel: " + evCh
+	}
+	if roleID := st.GetGuildSubscriberRole(guildID); roleID != "" {
+		msg += "\nSubscriber role: <@&" + roleID + ">"
+	}
+	return msg
+}
+
+// alertSectionsText summarizes which parts of a fight-night alert are sent.
+func alertSectionsText(a state.AlertSections) string {
+	return fmt.Sprintf("Fight-night alerts include: header %s, trailer %s, embed %s (event lines are always sent).", onOff(a.Header), onOff(a.Trailer), onOff(a.Embed))
+}
+
+// handleSubscribeRole toggles the guild's subscriber role on the caller, so
+// members can opt in to (or out of) fight-night pings. The bot needs Manage
+// Roles, and its own role must sit above the subscriber role.
+func handleSubscribeRole(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store) {
+	if ic.GuildID == "" {
+		replyEphemeral(s, ic, "Use /subscribe-role in a server.")
+		return
+	}
+	userID, ok := interactionUserID(ic)
+	if !ok {
+		replyEphemeral(s, ic, unidentifiedUserMsg)
+		return
+	}
+	roleID := st.GetGuildSubscriberRole(ic.GuildID)
+	if roleID == "" {
+		replyEphemeral(s, ic, "This server has no subscriber role yet. An admin can set one with /settings subscriber-role.")
+		return
+	}
+	if slices.Contains(ic.Member.Roles, roleID) {
+		if err := removeMemberRole(s, ic.GuildID, userID, roleID); err != nil {
+			logx.Warn("subscriber role remove failed", "guild_id", ic.GuildID, "user_id", userID, "role_id", roleID, "err", err)
+			replyEphemeral(s, ic, "Could not update your roles. The bot needs Manage Roles, and its role must be above the subscriber role.")
+			return
+		}
+		replyEphemeral(s, ic, "Unsubscribed; you won't be pinged for fight nights.")
+		return
+	}
+	if err := addMemberRole(s, ic.GuildID, userID, roleID); err != nil {
+		logx.Warn("subscriber role add failed", "guild_id", ic.GuildID, "user_id", userID, "role_id", roleID, "err", err)
+		replyEphemeral(s, ic, "Could not update your roles. The bot needs Manage Roles, and its role must be above the subscriber role.")
+		return
+	}
+	replyEphemeral(s, ic, "Subscribed! You'll be pinged when fight-night alerts are posted. Run /subscribe-role again to opt out.")
+}
+
+// handlePing replies with gateway and database latency as a quick liveness check.
+func handlePing(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store) {
+	gw := heartbeatLatency(s).Round(time.Millisecond)
+	dbStatus := "error"
+	if d, err := st.Ping(); err == nil {
+		dbStatus = d.Round(time.Microsecond).String()
+	} else {
+		logx.Warn("ping: db check failed", "guild_id", ic.GuildID, "err", err)
+	}
+	replyEphemeral(s, ic, fmt.Sprintf("Pong!\nGateway latency: %s\nDB latency: %s", gw, dbStatus))
+}
+
+// handleNextCheck reports when the notifier will next evaluate this guild, based on
+// its run hour and timezone.
+func handleNextCheck(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
+	loc, tz := guildLocation(st, cfg, ic.GuildID)
+	runLoc, runTZ := runLocation(st, cfg, ic.GuildID)
+	now := time.Now()
+	hour := guildRunHour(st, cfg, ic.GuildID)
+	next := nextRunAt(now, runLoc, hour)
+	style := dateStyle(st.GetGuildDateFormat(ic.GuildID))
+	msg := fmt.Sprintf("Next check: %s (%s) — <t:%d:R>\n%s", next.In(loc).Format(style.Full), tz, next.Unix(), runHourText(hour, runLoc, runTZ, now))
+	if !st.GetGuildNotifyEnabled(ic.GuildID) {
+		msg += "\nNotifications are off, so nothing will be posted. Enable them with /settings notifications."
 	}
 	replyEphemeral(s, ic, msg)
 }
 
-func handleHelp(s *discordgo.Session, ic *discordgo.InteractionCreate) {
-	replyEphemeral(s, ic, buildHelp())
+// historyShown caps how many dates /history lists per kind of post.
+const historyShown = 10
+
+// handleHistory lists the most recent dates the bot posted for the guild, per
+// org and post kind (fight-night alerts, weigh-in reminders).
+func handleHistory(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store) {
+	records, err := st.ListPostHistory(ic.GuildID, 0)
+	if err != nil {
+		logx.Warn("history: list failed", "guild_id", ic.GuildID, "err", err)
+		replyEphemeral(s, ic, "Could not load post history.")
+		return
+	}
+	if len(records) == 0 {
+		replyEphemeral(s, ic, "Nothing has been posted in this server yet.")
+		return
+	}
+	// Records arrive newest first; group them by key, keeping that order.
+	byKey := map[string][]string{}
+	for _, r := range records {
+		if len(byKey[r.Org]) < historyShown {
+			byKey[r.Org] = append(byKey[r.Org], r.Date)
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString("Recent posts (newest first):")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n%s: %s", historyLabel(k), strings.Join(byKey[k], ", "))
+	}
+	replyEphemeral(s, ic, b.String())
+}
+
+// historyLabel renders a last_posted key such as "ufc" or "ufc:weighin".
+func historyLabel(key string) string {
+	org, kind, _ := strings.Cut(key, ":")
+	switch kind {
+	case "":
+		return sources.DisplayOrg(org) + " fight nights"
+	case "weighin":
+		return sources.DisplayOrg(org) + " weigh-in reminders"
+	case "fightweek":
+		return sources.DisplayOrg(org) + " fight-week promos"
+	default:
+		return sources.DisplayOrg(org) + " " + kind
+	}
+}
+
+// handleTZConvert shows a UTC time in a chosen timezone and the guild's, to help
+// admins reason about run hours and event times across zones.
+func handleTZConvert(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
+	raw, tzName := commandStringOption(ic, "time"), commandStringOption(ic, "tz")
+	if raw == "" || tzName == "" {
+		replyEphemeral(s, ic, "Usage: /tz-convert time:<RFC3339 or HH:MM UTC> tz:<IANA timezone>")
+		return
+	}
+	t, err := parseConvertTime(raw, time.Now())
+	if err != nil {
+		replyEphemeral(s, ic, "Invalid time. Use RFC3339 (e.g. 2024-04-13T22:00:00Z) or HH:MM in UTC (e.g. 22:00).")
+		return
+	}
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		replyEphemeral(s, ic, "Invalid timezone. Use an IANA name like America/New_York; see /settings timezone-help.")
+		return
+	}
+	guildLoc, guildTZ := guildLocation(st, cfg, ic.GuildID)
+	replyEphemeral(s, ic, tzConvertText(t, loc, tzName, guildLoc, guildTZ))
+}
+
+// /settings replies help-visibility values; unset means ephemeral.
+const (
+	helpEphemeral = "ephemeral"
+	helpPublic    = "public"
+)
+
+// helpVisibility returns the guild's /help visibility, defaulting to ephemeral.
+func helpVisibility(st *state.Store, guildID string) string {
+	if st.GetGuildHelpVisibility(guildID) == helpPublic {
+		return helpPublic
+	}
+	return helpEphemeral
+}
+
+func handleHelp(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store) {
+	data := &discordgo.InteractionResponseData{Content: buildHelp()}
+	if helpVisibility(st, ic.GuildID) == helpEphemeral {
+		data.Flags = discordgo.MessageFlagsEphemeral
+	}
+	_ = sendInteractionData(s, ic, data)
 }
 
 func handleNextEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 	// Acknowledge quickly to avoid the 3s interaction timeout.
 	_ = deferInteractionResponse(s, ic)
 
-	// Timezone selection for display
+	// Timezone selection for display; an optional tz option overrides it for this reply only.
 	loc, tzName := guildLocation(st, cfg, ic.GuildID)
+	tzNote := ""
+	if tz := commandStringOption(ic, "tz"); tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc, tzName = l, tz
+		} else {
+			tzNote = "\n(Unknown timezone " + tz + "; showing the server timezone.)"
+		}
+	}
 
 	// Resolve org+provider (default to UFC if unset) and build context
 	org, provider, ctx, ok := providerForGuild(st, mgr, ic.GuildID, true)
@@ -246,11 +510,24 @@ func handleNextEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *
 	}
 	ev, ok, err := pickNextEvent(ctx, provider)
 	if err != nil {
-		_ = editInteractionResponse(s, ic, "Error fetching events. Please try again later.")
+		logx.Warn("next-event: fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		_ = editInteractionResponse(s, ic, fetchErrorText(err))
 		return
 	}
 	if !ok {
-		_ = editInteractionResponse(s, ic, "No upcoming "+strings.ToUpper(org)+" events found in the next 30 days.")
+		msg := st.GetGuildNoEventMessage(ic.GuildID)
+		if msg == "" {
+			msg = "No upcoming " + sources.DisplayOrg(org) + " events found in the next 30 days."
+		}
+		_ = editInteractionResponse(s, ic, msg)
+		return
+	}
+	// A provider may know about an event before its start time is set.
+	if strings.TrimSpace(ev.Start) == "" {
+		_ = editInteractionResponse(s, ic, fmt.Sprintf("Next %s event: %s\nWhen: Date TBA", sources.DisplayOrg(org), ev.Name)+eventIDNote(st, ic, ev))
+		if emb := buildEventEmbed(sources.DisplayOrg(org), tzName, loc, ev, embedOptionsForGuild(st, ic.GuildID)); emb != nil {
+			_ = editInteractionEmbeds(s, ic, []*discordgo.MessageEmbed{emb})
+		}
 		return
 	}
 	// Parse event start for display
@@ -259,7 +536,13 @@ func handleNextEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *
 		_ = editInteractionResponse(s, ic, "Error parsing event time.")
 		return
 	}
+	// Hide events beyond the guild's display window (selection is unaffected).
+	if days := st.GetGuildMaxAnnounceDays(ic.GuildID); days > 0 && startUTC.After(time.Now().AddDate(0, 0, days)) {
+		_ = editInteractionResponse(s, ic, fmt.Sprintf("No %s events in the next %d days.", sources.DisplayOrg(org), days))
+		return
+	}
 	localTime := startUTC.In(loc)
+	style := dateStyle(st.GetGuildDateFormat(ic.GuildID))
 	until := time.Until(startUTC).Truncate(time.Minute)
 	msg := ""
 	if until >= 0 {
@@ -274,7 +557,7 @@ func handleNextEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *
 		} else {
 			rel = fmt.Sprintf("%dm", m)
 		}
-		msg = fmt.Sprintf("Next %s event: %s\nWhen: %s (%s) — in %s", strings.ToUpper(org), ev.Name, localTime.Format("Mon Jan 2, 3:04 PM MST"), tzName, rel)
+		msg = fmt.Sprintf("Next %s event: %s\nWhen: %s (%s) — in %s", sources.DisplayOrg(org), ev.Name, localTime.Format(style.Full), tzName, rel)
 	} else {
 		ago := -until
 		h := int(ago.Hours())
@@ -285,43 +568,250 @@ func handleNextEvent(s *discordgo.Session, ic *discordgo.InteractionCreate, st *
 		} else {
 			rel = fmt.Sprintf("%dm ago", m)
 		}
-		msg = fmt.Sprintf("Today’s %s event: %s\nStarted: %s (%s) — %s", strings.ToUpper(org), ev.Name, localTime.Format("3:04 PM"), tzName, rel)
+		msg = fmt.Sprintf("Today’s %s event: %s\nStarted: %s (%s) — %s", sources.DisplayOrg(org), ev.Name, localTime.Format(style.Time), tzName, rel)
 	}
-	_ = editInteractionResponse(s, ic, msg)
+	if st.GetGuildNextEventTeaserEnabled(ic.GuildID) {
+		msg += nextEventTeaser(ctx, provider, ev, loc, style.Date)
+	}
+	_ = editInteractionResponse(s, ic, msg+tzNote+eventIDNote(st, ic, ev))
 
 	// Attempt to add a rich embed with card details (best-effort; ignore errors)
-	if emb := buildEventEmbed(strings.ToUpper(org), tzName, loc, ev); emb != nil {
+	if emb := buildEventEmbed(sources.DisplayOrg(org), tzName, loc, ev, embedOptionsForGuild(st, ic.GuildID)); emb != nil {
+		_ = editInteractionEmbeds(s, ic, []*discordgo.MessageEmbed{emb})
+	}
+}
+
+// handleResults shows the outcomes of the org's most recently completed event.
+// The card embed lists each bout's winner in place of its start time.
+func handleResults(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+	_ = deferInteractionResponse(s, ic)
+
+	loc, tzName := guildLocation(st, cfg, ic.GuildID)
+	org, provider, ctx, ok := providerForGuild(st, mgr, ic.GuildID, true)
+	if !ok {
+		_ = editInteractionResponse(s, ic, "Unsupported organization for results. Try /settings org to a supported one.")
+		return
+	}
+	if !mgr.Capabilities(org).HasResults {
+		_ = editInteractionResponse(s, ic, "Results aren't available for "+sources.DisplayOrg(org)+".")
+		return
+	}
+	ev, ok, err := provider.LastEvent(ctx)
+	if err != nil {
+		logx.Warn("results: fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		_ = editInteractionResponse(s, ic, fetchErrorText(err))
+		return
+	}
+	if !ok {
+		_ = editInteractionResponse(s, ic, "No completed "+sources.DisplayOrg(org)+" events found.")
+		return
+	}
+	msg := fmt.Sprintf("Latest %s results: %s", sources.DisplayOrg(org), ev.Name)
+	if t, err := parseAPITime(ev.Start); err == nil {
+		msg += fmt.Sprintf("\nHeld: %s (%s)", t.In(loc).Format(dateStyle(st.GetGuildDateFormat(ic.GuildID)).Date), tzName)
+	}
+	decided := false
+	for _, b := range ev.Bouts {
+		if strings.TrimSpace(b.Winner) != "" {
+			decided = true
+			break
+		}
+	}
+	if !decided {
+		msg += "\nResults haven't been posted yet; check back later."
+	}
+	emb := buildEventEmbed(sources.DisplayOrg(org), tzName, loc, ev, embedOptionsForGuild(st, ic.GuildID))
+	if decided {
+		if channelID, ok := postResultsWithReactions(s, st, mgr, ic.GuildID, org, loc, ev, msg, emb); ok {
+			msg += "\nPosted in <#" + channelID + "> for members to react."
+		}
+	}
+	_ = editInteractionResponse(s, ic, msg+eventIDNote(st, ic, ev))
+	if emb != nil {
+		_ = editInteractionEmbeds(s, ic, []*discordgo.MessageEmbed{emb})
+	}
+}
+
+// handleCard replies with the full fight card of the next or ongoing event.
+// Unlike /next-event it ignores the guild's max-announce-days window, so the
+// card can be checked however far out the event is.
+func handleCard(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+	_ = deferInteractionResponse(s, ic)
+
+	loc, tzName := guildLocation(st, cfg, ic.GuildID)
+	org, provider, ctx, ok := providerForGuild(st, mgr, ic.GuildID, true)
+	if !ok {
+		_ = editInteractionResponse(s, ic, "Unsupported organization for card. Try /settings org to a supported one.")
+		return
+	}
+	ev, ok, err := pickNextEvent(ctx, provider)
+	if err != nil {
+		logx.Warn("card: fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		_ = editInteractionResponse(s, ic, fetchErrorText(err))
+		return
+	}
+	if !ok {
+		_ = editInteractionResponse(s, ic, "No upcoming "+sources.DisplayOrg(org)+" events found, so there's no card to show.")
+		return
+	}
+	when := "Date TBA"
+	if t, err := parseAPITime(ev.Start); err == nil {
+		when = fmt.Sprintf("%s (%s)", t.In(loc).Format(dateStyle(st.GetGuildDateFormat(ic.GuildID)).Full), tzName)
+	}
+	msg := fmt.Sprintf("%s fight card: %s\nWhen: %s", sources.DisplayOrg(org), ev.Name, when)
+	if len(ev.Bouts) == 0 {
+		if mgr.Capabilities(org).HasCards {
+			msg += "\nThe card hasn't been announced yet; check back closer to the event."
+		} else {
+			msg += "\n" + sources.DisplayOrg(org) + " data doesn't include fight cards."
+		}
+	}
+	_ = editInteractionResponse(s, ic, msg+eventIDNote(st, ic, ev))
+	if emb := buildEventEmbed(sources.DisplayOrg(org), tzName, loc, ev, embedOptionsForGuild(st, ic.GuildID)); emb != nil {
 		_ = editInteractionEmbeds(s, ic, []*discordgo.MessageEmbed{emb})
 	}
 }
 
+// handleH2H shows whether the next event's main-event fighters have met
+// before, for providers with the head-to-head capability.
+func handleH2H(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, mgr *sources.Manager) {
+	_ = deferInteractionResponse(s, ic)
+
+	org, provider, ctx, ok := providerForGuild(st, mgr, ic.GuildID, true)
+	if !ok {
+		_ = editInteractionResponse(s, ic, "Unsupported organization for h2h. Try /settings org to a supported one.")
+		return
+	}
+	h2h, ok := provider.(sources.HeadToHeadProvider)
+	if !ok || !mgr.Capabilities(org).HasHeadToHead {
+		_ = editInteractionResponse(s, ic, sources.DisplayOrg(org)+" data doesn't include fighters' previous meetings.")
+		return
+	}
+	ev, ok, err := pickNextEvent(ctx, provider)
+	if err != nil {
+		logx.Warn("h2h: fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		_ = editInteractionResponse(s, ic, fetchErrorText(err))
+		return
+	}
+	if !ok {
+		_ = editInteractionResponse(s, ic, "No upcoming "+sources.DisplayOrg(org)+" events found.")
+		return
+	}
+	main, ok := mainEventBout(ev)
+	if !ok || safe(main.RedName) == "" || safe(main.BlueName) == "" {
+		_ = editInteractionResponse(s, ic, "The main event for "+ev.Name+" hasn't been announced yet.")
+		return
+	}
+	header := fmt.Sprintf("%s main event: %s vs %s", ev.Name, safe(main.RedName), safe(main.BlueName))
+	meetings, err := h2h.PreviousMeetings(ctx, main)
+	if errors.Is(err, sources.ErrNoFighterIDs) {
+		_ = editInteractionResponse(s, ic, header+"\nPrevious meetings aren't available for this bout.")
+		return
+	}
+	if err != nil {
+		logx.Warn("h2h: meetings fetch failed", "guild_id", ic.GuildID, "org", org, "err", err)
+		_ = editInteractionResponse(s, ic, header+"\n"+fetchErrorText(err))
+		return
+	}
+	if len(meetings) == 0 {
+		_ = editInteractionResponse(s, ic, header+"\nThey haven't fought each other before.")
+		return
+	}
+	var b strings.Builder
+	b.WriteString(header)
+	for _, m := range meetings {
+		b.WriteString("\nPreviously: " + meetingText(main, m))
+	}
+	_ = editInteractionResponse(s, ic, b.String())
+}
+
+// meetingText renders a previous meeting as "A def. B (2021)", or a no-winner
+// line for draws and no contests.
+func meetingText(b sources.Bout, m sources.Meeting) string {
+	line := fmt.Sprintf("%s vs %s ended without a winner", safe(b.RedName), safe(b.BlueName))
+	if m.Winner != "" {
+		line = fmt.Sprintf("%s def. %s", safe(m.Winner), safe(m.Loser))
+	}
+	if t, err := parseAPITime(m.Date); err == nil {
+		line += fmt.Sprintf(" (%d)", t.Year())
+	}
+	return line
+}
+
+// eventIDNote returns a line with the provider's event ID for support requests
+// when the guild turned on /settings replies debug-ids and the invoker has Manage
+// Channels or Administrator; "" otherwise. /next-event, /results and /card
+// replies are ephemeral, so only the invoker sees it.
+func eventIDNote(st *state.Store, ic *discordgo.InteractionCreate, ev *sources.Event) string {
+	if ev == nil || strings.TrimSpace(ev.ID) == "" || !st.GetGuildDebugIDsEnabled(ic.GuildID) {
+		return ""
+	}
+	if ic.Member == nil || ic.Member.Permissions&(discordgo.PermissionManageChannels|discordgo.PermissionAdministrator) == 0 {
+		return ""
+	}
+	return "\nESPN event ID: " + ev.ID
+}
+
 // handleSettings routes subcommands under /settings to the existing handlers/logic.
 func handleSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 	data := ic.ApplicationCommandData()
 	if len(data.Options) == 0 {
-		replyEphemeral(s, ic, "Usage: /settings <org|channel|delivery|hour|timezone|notifications|events> — see /help")
+		replyEphemeral(s, ic, "Usage: /settings <org|channel|alert-channel|event-channel|delivery|hour|run-time-reference|timezone|timezone-help|notifications|skip-until-ppv|events|pin|replies|card-update-mode|alert|subscriber-role|ping-role|extra-posts|no-event-message|next-event-teaser|max-announce-days|scheduled-event-lead|event-name-prefix|embed> — see /help")
 		return
 	}
 	sub := data.Options[0]
+	// The extra-posts, alert and replies groups only namespace their subcommands; they
+	// are handled with the top-level ones below.
+	if sub.Name == "extra-posts" {
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings extra-posts <weigh-in-reminder|weigh-in-message|fight-week|fight-week-message|reminders|notify-webhook|postpone-notice|broadcast-opt-out> — see /help")
+			return
+		}
+		sub = sub.Options[0]
+	}
+	if sub.Name == "alert" {
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings alert <content|message-template> — see /help")
+			return
+		}
+		sub = sub.Options[0]
+	}
+	if sub.Name == "replies" {
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings replies <debug-ids|help-visibility> — see /help")
+			return
+		}
+		sub = sub.Options[0]
+	}
 	switch sub.Name {
 	case "org":
 		// Expect: option org:string
+		orgs := []string{"ufc"}
+		if mgr != nil {
+			if o := mgr.Orgs(); len(o) > 0 {
+				orgs = o
+			}
+		}
 		if len(sub.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /settings org org:<ufc>")
+			replyEphemeral(s, ic, "Usage: /settings org org:<"+strings.Join(orgs, "|")+">")
+			return
+		}
+		org := sources.NormalizeOrg(sub.Options[0].StringValue())
+		// Operator restriction via ALLOWED_ORGS (all orgs allowed by default)
+		if !cfg.OrgAllowed(ic.GuildID, org) {
+			replyEphemeral(s, ic, fmt.Sprintf("%s is not available for this server.", sources.DisplayOrg(org)))
 			return
 		}
 		// Permission check similar to set-org
 		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to set the organization.") {
 			return
 		}
-		org := sub.Options[0].StringValue()
-		switch org {
-		case "ufc":
-			st.UpdateGuildOrg(ic.GuildID, org)
-			replyEphemeral(s, ic, "Organization set to UFC.")
-		default:
-			replyEphemeral(s, ic, "Unsupported org. Currently only 'ufc' is available.")
+		if !slices.Contains(orgs, org) {
+			replyEphemeral(s, ic, "Unsupported org. Available: "+strings.Join(orgs, ", ")+".")
+			return
 		}
+		st.UpdateGuildOrg(ic.GuildID, org)
+		replyEphemeral(s, ic, "Organization set to "+sources.DisplayOrg(org)+".")
 	case "channel":
 		// Expect optional channel option; default to current channel
 		channelID := ic.ChannelID
@@ -333,9 +823,39 @@ func handleSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *s
 		}
 		st.UpdateGuildChannel(ic.GuildID, channelID)
 		replyEphemeral(s, ic, "Notification channel updated.")
+	case "alert-channel", "event-channel":
+		// Omitting channel clears the override so the main channel is used
+		channelID := ""
+		if len(sub.Options) > 0 {
+			channelID = sub.Options[0].ChannelValue(s).ID
+		}
+		permChannel := channelID
+		if permChannel == "" {
+			permChannel = ic.ChannelID
+		}
+		if !requireManageOrAdmin(s, ic, permChannel, "You need Manage Channels permission to change where the bot posts.") {
+			return
+		}
+		what := "Fight-night alerts, weigh-in reminders and fight-week promos"
+		if sub.Name == "event-channel" {
+			what = "Scheduled event links"
+			st.UpdateGuildEventChannel(ic.GuildID, channelID)
+		} else {
+			st.UpdateGuildAlertChannel(ic.GuildID, channelID)
+		}
+		if channelID == "" {
+			replyEphemeral(s, ic, what+" will go to the main notification channel.")
+			return
+		}
+		replyEphemeral(s, ic, what+" will go to <#"+channelID+">.")
 	case "delivery":
+		// No option: report the current mode instead of changing it
 		if len(sub.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /settings delivery mode:<message|announcement>")
+			mode := "message"
+			if st.GetGuildAnnounceEnabled(ic.GuildID) {
+				mode = "announcement"
+			}
+			replyEphemeral(s, ic, "Delivery mode is currently "+mode+".")
 			return
 		}
 		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change delivery mode.") {
@@ -366,22 +886,84 @@ func handleSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *s
 			return
 		}
 		st.UpdateGuildRunHour(ic.GuildID, hour)
-		replyEphemeral(s, ic, fmt.Sprintf("Daily run hour updated to %02d:00 (guild timezone)", hour))
-	case "timezone":
+		loc, tz := runLocation(st, cfg, ic.GuildID)
+		replyEphemeral(s, ic, "Daily run hour updated. "+runHourText(hour, loc, tz, time.Now()))
+	case "run-time-reference":
 		if len(sub.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /settings timezone tz:<IANA timezone>")
+			ref := runTimeLocal
+			if st.GetGuildRunTimeReference(ic.GuildID) == runTimeUTC {
+				ref = runTimeUTC
+			}
+			loc, tz := runLocation(st, cfg, ic.GuildID)
+			replyEphemeral(s, ic, "Run hour reference: "+ref+". "+runHourText(guildRunHour(st, cfg, ic.GuildID), loc, tz, time.Now()))
+			return
+		}
+		ref := sub.Options[0].StringValue()
+		if ref != runTimeLocal && ref != runTimeUTC {
+			replyEphemeral(s, ic, "Invalid reference. Use local or utc.")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the run time reference.") {
+			return
+		}
+		st.UpdateGuildRunTimeReference(ic.GuildID, ref)
+		loc, tz := runLocation(st, cfg, ic.GuildID)
+		replyEphemeral(s, ic, "Run hour reference set to "+ref+". "+runHourText(guildRunHour(st, cfg, ic.GuildID), loc, tz, time.Now()))
+	case "timezone":
+		var tz, fallback string
+		for _, o := range sub.Options {
+			switch o.Name {
+			case "tz":
+				tz = strings.TrimSpace(o.StringValue())
+			case "fallback":
+				fallback = strings.TrimSpace(o.StringValue())
+			}
+		}
+		if tz == "" {
+			replyEphemeral(s, ic, "Usage: /settings timezone tz:<IANA timezone> [fallback:<IANA timezone|none>]")
 			return
 		}
-		tz := sub.Options[0].StringValue()
 		if _, err := time.LoadLocation(tz); err != nil {
 			replyEphemeral(s, ic, "Invalid timezone. Example: America/Los_Angeles")
 			return
 		}
+		clearFallback := strings.EqualFold(fallback, "none")
+		if fallback != "" && !clearFallback {
+			if _, err := time.LoadLocation(fallback); err != nil {
+				replyEphemeral(s, ic, "Invalid fallback timezone. Example: America/New_York, or none to clear it.")
+				return
+			}
+		}
 		st.UpdateGuildTZ(ic.GuildID, tz)
-		replyEphemeral(s, ic, "Timezone updated to "+tz)
+		msg := "Timezone updated to " + tz
+		switch {
+		case clearFallback:
+			st.UpdateGuildFallbackTZ(ic.GuildID, "")
+			msg += "; fallback timezone cleared"
+		case fallback != "":
+			st.UpdateGuildFallbackTZ(ic.GuildID, fallback)
+			msg += "; " + fallback + " will be used if it ever stops being recognized"
+		}
+		replyEphemeral(s, ic, msg)
+	case "timezone-help":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings timezone-help region:<text>, e.g. America or Europe/L")
+			return
+		}
+		region := sub.Options[0].StringValue()
+		zones, total := matchZones(ianaZones, region)
+		if total == 0 {
+			replyEphemeral(s, ic, fmt.Sprintf("No timezones match %q. Try a continent such as America, Europe, or Asia.", region))
+			return
+		}
+		msg := "Matching timezones:\n" + strings.Join(zones, "\n")
+		if total > len(zones) {
+			msg += fmt.Sprintf("\n…and %d more; narrow the region to see them.", total-len(zones))
+		}
+		replyEphemeral(s, ic, msg+"\nSet one with /settings timezone tz:<name>.")
 	case "notifications":
 		if len(sub.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /settings notifications state:<on|off>")
+			replyEphemeral(s, ic, "Notifications are currently "+onOff(st.GetGuildNotifyEnabled(ic.GuildID))+".")
 			return
 		}
 		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change notifications.") {
@@ -402,39 +984,833 @@ func handleSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *s
 		default:
 			replyEphemeral(s, ic, "Invalid state. Use on or off.")
 		}
-	case "events":
+	case "skip-until-ppv":
 		if len(sub.Options) == 0 {
-			replyEphemeral(s, ic, "Usage: /settings events state:<on|off>")
+			if st.GetGuildSkipUntilPPV(ic.GuildID) {
+				replyEphemeral(s, ic, "Skipping non-PPV events until the next UFC PPV posts.")
+			} else {
+				replyEphemeral(s, ic, "Skip-until-PPV is off.")
+			}
 			return
 		}
-		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change scheduled events.") {
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change notifications.") {
 			return
 		}
-		stateVal := sub.Options[0].StringValue()
-		switch stateVal {
+		switch sub.Options[0].StringValue() {
 		case "on":
-			if !st.HasGuildOrg(ic.GuildID) {
-				replyEphemeral(s, ic, "Please set an organization first with /settings org before enabling scheduled events.")
+			if !st.HasGuildOrg(ic.GuildID) || sources.NormalizeOrg(st.GetGuildOrg(ic.GuildID)) != "ufc" {
+				replyEphemeral(s, ic, "Skip-until-PPV needs the UFC organization; other orgs have no PPV events.")
 				return
 			}
-			st.UpdateGuildEventsEnabled(ic.GuildID, true)
-			replyEphemeral(s, ic, "Scheduled events enabled (will create day-before).")
+			st.UpdateGuildSkipUntilPPV(ic.GuildID, true)
+			replyEphemeral(s, ic, "Fight Nights will be skipped (alerts, reminders, promos and scheduled events) until the next UFC PPV posts; then this turns itself off.")
 		case "off":
-			st.UpdateGuildEventsEnabled(ic.GuildID, false)
-			replyEphemeral(s, ic, "Scheduled events disabled.")
+			st.UpdateGuildSkipUntilPPV(ic.GuildID, false)
+			replyEphemeral(s, ic, "Skip-until-PPV is off; every event will be posted again.")
 		default:
 			replyEphemeral(s, ic, "Invalid state. Use on or off.")
 		}
-	default:
+	case "events":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Scheduled events are currently "+onOff(st.GetGuildEventsEnabled(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change scheduled events.") {
+			return
+		}
+		stateVal := sub.Options[0].StringValue()
+		switch stateVal {
+		case "on":
+			if !st.HasGuildOrg(ic.GuildID) {
+				replyEphemeral(s, ic, "Please set an organization first with /settings org before enabling scheduled events.")
+				return
+			}
+			st.UpdateGuildEventsEnabled(ic.GuildID, true)
+			st.ResetGuildEventFailures(ic.GuildID)
+			replyEphemeral(s, ic, "Scheduled events enabled (will create day-before).")
+		case "off":
+			st.UpdateGuildEventsEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Scheduled events disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "pin":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Pinning fight-night alerts is currently "+onOff(st.GetGuildPinEnabled(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change pinning.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildPinEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Fight-night alerts will be pinned (the previous alert is unpinned). The bot needs Manage Messages in the channel.")
+		case "off":
+			st.UpdateGuildPinEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Fight-night alerts will not be pinned.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "next-event-teaser":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "The /next-event teaser is currently "+onOff(st.GetGuildNextEventTeaserEnabled(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change /next-event settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildNextEventTeaserEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "/next-event will also name the event that follows, e.g. \"Then: UFC 301 on Sat May 4\".")
+		case "off":
+			st.UpdateGuildNextEventTeaserEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "/next-event will show only the next event.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "debug-ids":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Showing event IDs in /next-event is currently "+onOff(st.GetGuildDebugIDsEnabled(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change debug settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildDebugIDsEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "/next-event will show the ESPN event ID to members with Manage Channels. Include it when reporting a data issue.")
+		case "off":
+			st.UpdateGuildDebugIDsEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "/next-event will no longer show event IDs.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "help-visibility":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "/help replies are currently "+helpVisibility(st, ic.GuildID)+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change help visibility.") {
+			return
+		}
+		switch v := sub.Options[0].StringValue(); v {
+		case helpEphemeral:
+			st.UpdateGuildHelpVisibility(ic.GuildID, v)
+			replyEphemeral(s, ic, "/help will reply only to the member who runs it.")
+		case helpPublic:
+			st.UpdateGuildHelpVisibility(ic.GuildID, v)
+			replyEphemeral(s, ic, "/help will reply in the channel, so it can be shared or pinned.")
+		default:
+			replyEphemeral(s, ic, "Invalid visibility. Use public or ephemeral.")
+		}
+	case "card-update-mode":
+		if len(sub.Options) == 0 {
+			mode := st.GetGuildCardUpdateMode(ic.GuildID)
+			if mode == "" {
+				mode = cardUpdateEdit
+			}
+			replyEphemeral(s, ic, "Card update mode is currently "+mode+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the card update mode.") {
+			return
+		}
+		note := ""
+		if org := st.GetGuildOrg(ic.GuildID); !mgr.Capabilities(org).HasCards {
+			note = " Note: " + sources.DisplayOrg(org) + " data doesn't include fight cards, so alerts won't be updated."
+		}
+		switch mode := sub.Options[0].StringValue(); mode {
+		case cardUpdateEdit:
+			st.UpdateGuildCardUpdateMode(ic.GuildID, mode)
+			replyEphemeral(s, ic, "When the card fills in after an alert, the alert will be edited quietly."+note)
+		case cardUpdateNew:
+			st.UpdateGuildCardUpdateMode(ic.GuildID, mode)
+			replyEphemeral(s, ic, "When the card fills in after an alert, a \"Card update\" message will be posted."+note)
+		default:
+			replyEphemeral(s, ic, "Invalid mode. Use edit or new.")
+		}
+	case "content":
+		sections := st.GetGuildAlertSections(ic.GuildID)
+		// No options: report the current sections instead of changing them
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, alertSectionsText(sections))
+			return
+		}
+		for _, o := range sub.Options {
+			v := o.StringValue()
+			if v != "on" && v != "off" {
+				replyEphemeral(s, ic, "Invalid state for "+o.Name+". Use on or off.")
+				return
+			}
+			switch o.Name {
+			case "header":
+				sections.Header = v == "on"
+			case "trailer":
+				sections.Trailer = v == "on"
+			case "embed":
+				sections.Embed = v == "on"
+			}
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change alert content.") {
+			return
+		}
+		st.UpdateGuildAlertSections(ic.GuildID, sections)
+		replyEphemeral(s, ic, "Alert content updated. "+alertSectionsText(sections))
+	case "message-template":
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the alert message.") {
+			return
+		}
+		// Omitting template resets to the built-in alert format
+		text := ""
+		if len(sub.Options) > 0 {
+			text = strings.TrimSpace(sub.Options[0].StringValue())
+		}
+		if customMessageTooLong(text) {
+			replyEphemeral(s, ic, fmt.Sprintf("Template too long. Keep it under %d characters.", maxCustomMessageLen))
+			return
+		}
+		st.UpdateGuildMessageTemplate(ic.GuildID, text)
+		if text == "" {
+			replyEphemeral(s, ic, "Alert message reset to the default format.")
+			return
+		}
+		replyEphemeral(s, ic, "Alert message template updated. It replaces the header, event lines and trailer; the embed still follows /settings alert content.")
+	case "subscriber-role":
+		// Omitting role clears it
+		roleID := ""
+		if len(sub.Options) > 0 {
+			roleID, _ = sub.Options[0].Value.(string)
+		}
+		if roleID != "" && roleID == ic.GuildID {
+			replyEphemeral(s, ic, "@everyone can't be the subscriber role. Pick a dedicated role.")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the subscriber role.") {
+			return
+		}
+		st.UpdateGuildSubscriberRole(ic.GuildID, roleID)
+		if roleID == "" {
+			replyEphemeral(s, ic, "Subscriber role cleared; fight-night alerts won't ping anyone.")
+			return
+		}
+		replyEphemeral(s, ic, "Subscriber role set to <@&"+roleID+">. Members can opt in with /subscribe-role, and fight-night alerts will ping it.")
+	case "ping-role":
+		// Omitting role clears it
+		roleID := ""
+		if len(sub.Options) > 0 {
+			roleID, _ = sub.Options[0].Value.(string)
+		}
+		if roleID != "" && roleID == ic.GuildID {
+			replyEphemeral(s, ic, "@everyone can't be the ping role. Pick a dedicated role.")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the ping role.") {
+			return
+		}
+		st.UpdateGuildPingRole(ic.GuildID, roleID)
+		if roleID == "" {
+			replyEphemeral(s, ic, "Ping role cleared.")
+			return
+		}
+		msg := "Fight-night alerts will ping <@&" + roleID + ">."
+		if st.GetGuildSubscriberRole(ic.GuildID) != "" {
+			msg += " It replaces the subscriber role in alerts while set."
+		}
+		replyEphemeral(s, ic, msg)
+	case "weigh-in-reminder":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Weigh-in reminders are currently "+onOff(st.GetGuildWeighInEnabled(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change weigh-in reminders.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildWeighInEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Weigh-in reminders enabled (posted the day before each event at the run hour).")
+		case "off":
+			st.UpdateGuildWeighInEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Weigh-in reminders disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "weigh-in-message":
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the weigh-in message.") {
+			return
+		}
+		// Omitting text resets to the default reminder
+		text := ""
+		if len(sub.Options) > 0 {
+			text = strings.TrimSpace(sub.Options[0].StringValue())
+		}
+		if customMessageTooLong(text) {
+			replyEphemeral(s, ic, fmt.Sprintf("Message too long. Keep it under %d characters.", maxCustomMessageLen))
+			return
+		}
+		st.UpdateGuildWeighInMessage(ic.GuildID, text)
+		if text == "" {
+			replyEphemeral(s, ic, "Weigh-in message reset to the default.")
+			return
+		}
+		replyEphemeral(s, ic, "Weigh-in message updated.")
+	case "fight-week":
+		if len(sub.Options) == 0 {
+			if days := st.GetGuildFightWeekDays(ic.GuildID); days > 0 {
+				replyEphemeral(s, ic, fmt.Sprintf("The fight-week promo is posted %s before each event.", leadDaysText(days)))
+			} else {
+				replyEphemeral(s, ic, "The fight-week promo is off.")
+			}
+			return
+		}
+		days := int(sub.Options[0].IntValue())
+		if days < 0 || days > maxFightWeekDays {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid days. Use 1-%d, or 0 to turn it off.", maxFightWeekDays))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the fight-week promo.") {
+			return
+		}
+		st.UpdateGuildFightWeekDays(ic.GuildID, days)
+		if days == 0 {
+			replyEphemeral(s, ic, "Fight-week promo disabled.")
+			return
+		}
+		replyEphemeral(s, ic, fmt.Sprintf("Fight-week promo enabled (posted %s before each event at the run hour).", leadDaysText(days)))
+	case "reminders":
+		if len(sub.Options) == 0 {
+			if hours := st.GetGuildReminderOffsets(ic.GuildID); len(hours) > 0 {
+				replyEphemeral(s, ic, "Reminders are posted "+reminderOffsetsText(hours)+" before each event.")
+			} else {
+				replyEphemeral(s, ic, "Event reminders are off.")
+			}
+			return
+		}
+		hours, ok := parseReminderOffsets(sub.Options[0].StringValue())
+		if !ok {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid offsets. Use up to %d comma-separated hours between 1 and %d, e.g. 24,1, or off.", maxReminderOffsets, maxReminderOffsetHours))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change reminders.") {
+			return
+		}
+		st.UpdateGuildReminderOffsets(ic.GuildID, hours)
+		if len(hours) == 0 {
+			replyEphemeral(s, ic, "Event reminders disabled.")
+			return
+		}
+		replyEphemeral(s, ic, "Reminders will be posted "+reminderOffsetsText(hours)+" before each event (while notifications are on).")
+	case "live-soon-reminder":
+		if len(sub.Options) == 0 {
+			if mins := st.GetGuildLiveSoonMinutes(ic.GuildID); mins > 0 {
+				replyEphemeral(s, ic, fmt.Sprintf("The live-soon reminder is posted %d minutes before each event.", mins))
+			} else {
+				replyEphemeral(s, ic, "The live-soon reminder is off.")
+			}
+			return
+		}
+		mins := int(sub.Options[0].IntValue())
+		if mins < 0 || mins > maxLiveSoonMinutes {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid minutes. Use 1-%d, or 0 to turn it off.", maxLiveSoonMinutes))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the live-soon reminder.") {
+			return
+		}
+		st.UpdateGuildLiveSoonMinutes(ic.GuildID, mins)
+		if mins == 0 {
+			replyEphemeral(s, ic, "Live-soon reminder disabled.")
+			return
+		}
+		replyEphemeral(s, ic, fmt.Sprintf("A reminder will be posted once %d minutes before each event starts, linking the server calendar event when there is one (while notifications are on).", mins))
+	case "fight-week-message":
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the fight-week message.") {
+			return
+		}
+		// Omitting text resets to the default promo
+		text := ""
+		if len(sub.Options) > 0 {
+			text = strings.TrimSpace(sub.Options[0].StringValue())
+		}
+		if customMessageTooLong(text) {
+			replyEphemeral(s, ic, fmt.Sprintf("Message too long. Keep it under %d characters.", maxCustomMessageLen))
+			return
+		}
+		st.UpdateGuildFightWeekMessage(ic.GuildID, text)
+		if text == "" {
+			replyEphemeral(s, ic, "Fight-week message reset to the default.")
+			return
+		}
+		replyEphemeral(s, ic, "Fight-week message updated.")
+	case "notify-webhook":
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the notify webhook.") {
+			return
+		}
+		// Omitting url clears the webhook
+		webhookURL := ""
+		if len(sub.Options) > 0 {
+			webhookURL = strings.TrimSpace(sub.Options[0].StringValue())
+		}
+		if webhookURL != "" && !validWebhookURL(webhookURL) {
+			replyEphemeral(s, ic, "Invalid webhook URL. Use a public https:// address.")
+			return
+		}
+		st.UpdateGuildNotifyWebhookURL(ic.GuildID, webhookURL)
+		if webhookURL == "" {
+			replyEphemeral(s, ic, "Notify webhook cleared.")
+			return
+		}
+		replyEphemeral(s, ic, "After each fight-night alert, the event will be POSTed as JSON to that URL (best effort, 5 second timeout).")
+	case "postpone-notice":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Postponement notices are currently "+onOff(st.GetGuildPostponeNotice(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change postponement notices.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildPostponeNotice(ic.GuildID, true)
+			replyEphemeral(s, ic, "Postponement notices enabled (posted when an event the bot put on the server calendar is no longer listed for its date).")
+		case "off":
+			st.UpdateGuildPostponeNotice(ic.GuildID, false)
+			replyEphemeral(s, ic, "Postponement notices disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "broadcast-opt-out":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Broadcast opt-out is currently "+onOff(st.GetGuildBroadcastOptOut(ic.GuildID))+".")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change broadcast opt-out.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildBroadcastOptOut(ic.GuildID, true)
+			replyEphemeral(s, ic, "Opted out of bot operator notices. Critical notices (e.g., breaking changes) still post.")
+		case "off":
+			st.UpdateGuildBroadcastOptOut(ic.GuildID, false)
+			replyEphemeral(s, ic, "Bot operator notices will post in the alert channel.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "no-event-message":
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the no-event message.") {
+			return
+		}
+		// Omitting text resets to the default reply
+		text := ""
+		if len(sub.Options) > 0 {
+			text = strings.TrimSpace(sub.Options[0].StringValue())
+		}
+		if customMessageTooLong(text) {
+			replyEphemeral(s, ic, fmt.Sprintf("Message too long. Keep it under %d characters.", maxCustomMessageLen))
+			return
+		}
+		st.UpdateGuildNoEventMessage(ic.GuildID, text)
+		if text == "" {
+			replyEphemeral(s, ic, "No-event message reset to the default.")
+			return
+		}
+		replyEphemeral(s, ic, "No-event message updated.")
+	case "event-name-prefix":
+		// Omitting text shows the current prefix
+		if len(sub.Options) == 0 {
+			example := scheduledEventName(st.GetGuildOrg(ic.GuildID), st.GetGuildEventNamePrefix(ic.GuildID), "<event name>")
+			replyEphemeral(s, ic, "Scheduled events are named like: "+example)
+			return
+		}
+		text := strings.TrimSpace(sub.Options[0].StringValue())
+		if utf8.RuneCountInString(text) > maxEventNamePrefixLen {
+			replyEphemeral(s, ic, fmt.Sprintf("Prefix too long. Keep it under %d characters.", maxEventNamePrefixLen))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the event name prefix.") {
+			return
+		}
+		if strings.EqualFold(text, "default") {
+			text = ""
+		}
+		st.UpdateGuildEventNamePrefix(ic.GuildID, text)
+		example := scheduledEventName(st.GetGuildOrg(ic.GuildID), text, "<event name>")
+		if text == "" {
+			replyEphemeral(s, ic, "Event name prefix reset to the default. Scheduled events are named like: "+example)
+			return
+		}
+		replyEphemeral(s, ic, "Event name prefix updated. Scheduled events are named like: "+example)
+	case "max-announce-days":
+		if len(sub.Options) == 0 {
+			if days := st.GetGuildMaxAnnounceDays(ic.GuildID); days > 0 {
+				replyEphemeral(s, ic, fmt.Sprintf("/next-event shows events up to %d days ahead.", days))
+			} else {
+				replyEphemeral(s, ic, "/next-event shows the next event however far ahead it is.")
+			}
+			return
+		}
+		days := int(sub.Options[0].IntValue())
+		if days < 0 || days > maxAnnounceDaysLimit {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid days. Use 1-%d, or 0 to remove the limit.", maxAnnounceDaysLimit))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the announce window.") {
+			return
+		}
+		st.UpdateGuildMaxAnnounceDays(ic.GuildID, days)
+		if days == 0 {
+			replyEphemeral(s, ic, "Announce window removed; /next-event shows the next event however far ahead it is.")
+			return
+		}
+		replyEphemeral(s, ic, fmt.Sprintf("/next-event will show events up to %d days ahead.", days))
+	case "scheduled-event-lead":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, fmt.Sprintf("Scheduled events are created up to %s before the event.", leadDaysText(st.GetGuildScheduledEventLeadDays(ic.GuildID))))
+			return
+		}
+		days := int(sub.Options[0].IntValue())
+		if days < 1 || days > maxScheduledEventLeadDays {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid days. Use 1-%d.", maxScheduledEventLeadDays))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change the scheduled event lead time.") {
+			return
+		}
+		st.UpdateGuildScheduledEventLeadDays(ic.GuildID, days)
+		replyEphemeral(s, ic, fmt.Sprintf("Scheduled events will be created up to %s before the event.", leadDaysText(days)))
+	case "embed":
+		handleEmbedSettings(s, ic, st, sub)
+	default:
 		replyEphemeral(s, ic, "Unknown settings subcommand. See /help")
 	}
 }
 
+// leadDaysText renders a scheduled event lead time, e.g. "1 day" or "14 days".
+func leadDaysText(days int) string {
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// parseReminderOffsets parses a /settings extra-posts reminders list such as
+// "24,1" into distinct hour offsets, largest first. "off" (or "0") clears the
+// list; ok is false when an entry is malformed or out of range.
+func parseReminderOffsets(s string) (hours []int, ok bool) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "off") || s == "0" {
+		return nil, true
+	}
+	for _, p := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 1 || n > maxReminderOffsetHours {
+			return nil, false
+		}
+		if !slices.Contains(hours, n) {
+			hours = append(hours, n)
+		}
+	}
+	if len(hours) > maxReminderOffsets {
+		return nil, false
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(hours)))
+	return hours, true
+}
+
+// reminderOffsetsText renders offsets for replies, e.g. "24h and 1h".
+func reminderOffsetsText(hours []int) string {
+	parts := make([]string, 0, len(hours))
+	for _, h := range hours {
+		parts = append(parts, fmt.Sprintf("%dh", h))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// handleEmbedSettings routes the /settings embed group which controls how event
+// embeds are rendered for the guild.
+func handleEmbedSettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, group *discordgo.ApplicationCommandInteractionDataOption) {
+	if len(group.Options) == 0 {
+		replyEphemeral(s, ic, "Usage: /settings embed <preview|headshots|starts-format|date-format|layout|section-order|main-card-size|link-preference|show-rankings|show-flags|show-notes|show-end|result-method|result-emojis|results-reactions> — see /help")
+		return
+	}
+	sub := group.Options[0]
+	switch sub.Name {
+	case "preview":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed preview state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildPreviewEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Event previews enabled (link and headline when available).")
+		case "off":
+			st.UpdateGuildPreviewEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Event previews disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "headshots":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed headshots state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildHeadshotsEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Main-event headshots enabled.")
+		case "off":
+			st.UpdateGuildHeadshotsEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Main-event headshots disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "starts-format":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed starts-format format:<long|short|relative>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch format := sub.Options[0].StringValue(); format {
+		case startsFormatLong, startsFormatShort, startsFormatRelative:
+			st.UpdateGuildStartsFormat(ic.GuildID, format)
+			replyEphemeral(s, ic, "Embed start time format set to "+format+".")
+		default:
+			replyEphemeral(s, ic, "Invalid format. Use long, short, or relative.")
+		}
+	case "date-format":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed date-format format:<us|intl>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch format := sub.Options[0].StringValue(); format {
+		case dateFormatUS, dateFormatIntl:
+			st.UpdateGuildDateFormat(ic.GuildID, format)
+			replyEphemeral(s, ic, "Date format set to "+format+" (e.g., "+time.Date(2006, 1, 2, 15, 4, 0, 0, time.UTC).Format(dateStyle(format).Full)+").")
+		default:
+			replyEphemeral(s, ic, "Invalid format. Use us or intl.")
+		}
+	case "layout":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed layout layout:<stacked|inline>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch layout := sub.Options[0].StringValue(); layout {
+		case embedLayoutStacked, embedLayoutInline:
+			st.UpdateGuildEmbedLayout(ic.GuildID, layout)
+			replyEphemeral(s, ic, "Embed card layout set to "+layout+".")
+		default:
+			replyEphemeral(s, ic, "Invalid layout. Use stacked or inline.")
+		}
+	case "section-order":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed section-order order:<headline|chrono>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch order := sub.Options[0].StringValue(); order {
+		case sectionOrderHeadline, sectionOrderChrono:
+			st.UpdateGuildSectionOrder(ic.GuildID, order)
+			replyEphemeral(s, ic, "Embed section order set to "+order+".")
+		default:
+			replyEphemeral(s, ic, "Invalid order. Use headline or chrono.")
+		}
+	case "main-card-size":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, fmt.Sprintf("The main card is the top %d bouts.", st.GetGuildMainCardSize(ic.GuildID)))
+			return
+		}
+		size := int(sub.Options[0].IntValue())
+		if size < 1 || size > maxMainCardSize {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid size. Use 1-%d.", maxMainCardSize))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		st.UpdateGuildMainCardSize(ic.GuildID, size)
+		replyEphemeral(s, ic, fmt.Sprintf("The main card will show the top %d bouts; the rest are prelims.", size))
+	case "link-preference":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed link-preference preference:<auto|espn|official|first>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch pref := sub.Options[0].StringValue(); pref {
+		case "auto":
+			st.UpdateGuildLinkPreference(ic.GuildID, "")
+			replyEphemeral(s, ic, "Embed title link will use the default pick.")
+		case linkPrefESPN, linkPrefOfficial, linkPrefFirst:
+			st.UpdateGuildLinkPreference(ic.GuildID, pref)
+			replyEphemeral(s, ic, "Embed title link preference set to "+pref+".")
+		default:
+			replyEphemeral(s, ic, "Invalid preference. Use auto, espn, official, or first.")
+		}
+	case "show-rankings":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed show-rankings state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildRankingsEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Fighter rankings enabled (shown when available).")
+		case "off":
+			st.UpdateGuildRankingsEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Fighter rankings disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "show-flags":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed show-flags state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildFlagsEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Fighter country flags enabled (shown when available).")
+		case "off":
+			st.UpdateGuildFlagsEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Fighter country flags disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "show-notes":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed show-notes state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildNotesEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Bout notes enabled (e.g. Rematch, shown when ESPN provides them).")
+		case "off":
+			st.UpdateGuildNotesEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Bout notes disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "show-end":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed show-end state:<on|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch sub.Options[0].StringValue() {
+		case "on":
+			st.UpdateGuildShowEndEnabled(ic.GuildID, true)
+			replyEphemeral(s, ic, "Event end time enabled (shown when known).")
+		case "off":
+			st.UpdateGuildShowEndEnabled(ic.GuildID, false)
+			replyEphemeral(s, ic, "Event end time disabled.")
+		default:
+			replyEphemeral(s, ic, "Invalid state. Use on or off.")
+		}
+	case "result-method":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Usage: /settings embed result-method mode:<emoji|text|off>")
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		switch mode := sub.Options[0].StringValue(); mode {
+		case resultMethodEmoji, resultMethodText, resultMethodOff:
+			st.UpdateGuildResultMethod(ic.GuildID, mode)
+			replyEphemeral(s, ic, "Finish method display set to "+mode+".")
+		default:
+			replyEphemeral(s, ic, "Invalid mode. Use emoji, text, or off.")
+		}
+	case "result-emojis":
+		// Omitting every option resets to the built-in set
+		set := [3]string{}
+		for _, o := range sub.Options {
+			v := strings.TrimSpace(o.StringValue())
+			if strings.Contains(v, ",") || utf8.RuneCountInString(v) > maxResultEmojiLen {
+				replyEphemeral(s, ic, fmt.Sprintf("Each emoji must be at most %d characters and contain no commas.", maxResultEmojiLen))
+				return
+			}
+			switch o.Name {
+			case "ko":
+				set[methodKO] = v
+			case "sub":
+				set[methodSub] = v
+			case "dec":
+				set[methodDec] = v
+			}
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		stored := strings.Join(set[:], ",")
+		if stored == ",," {
+			stored = ""
+		}
+		st.UpdateGuildResultEmojis(ic.GuildID, stored)
+		e := resultEmojis(stored)
+		replyEphemeral(s, ic, fmt.Sprintf("Finish method emojis: %s KO/TKO, %s submission, %s decision.", e[methodKO], e[methodSub], e[methodDec]))
+	case "results-reactions":
+		if len(sub.Options) == 0 {
+			replyEphemeral(s, ic, "Results post reactions: "+resultsReactionsText(st.GetGuildResultsReactions(ic.GuildID))+".")
+			return
+		}
+		emojis, ok := parseResultsReactions(sub.Options[0].StringValue())
+		if !ok {
+			replyEphemeral(s, ic, fmt.Sprintf("Invalid reactions. Use on, off, or up to %d comma-separated emoji, e.g. 🏆,🔥.", maxResultsReactions))
+			return
+		}
+		if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to change embed settings.") {
+			return
+		}
+		st.UpdateGuildResultsReactions(ic.GuildID, emojis)
+		replyEphemeral(s, ic, "Results post reactions: "+resultsReactionsText(emojis)+".")
+	default:
+		replyEphemeral(s, ic, "Unknown embed setting. See /help")
+	}
+}
+
 // handleDevTest groups dev-only helpers under /dev-test
 func handleDevTest(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 	data := ic.ApplicationCommandData()
 	if len(data.Options) == 0 {
-		replyEphemeral(s, ic, "Usage: /dev-test <create-event|create-announcement>")
+		replyEphemeral(s, ic, "Usage: /dev-test <create-event|create-announcement|sync-commands|reload-config|info|copy-settings|export-history|broadcast>")
 		return
 	}
 	sub := data.Options[0]
@@ -443,6 +1819,18 @@ func handleDevTest(s *discordgo.Session, ic *discordgo.InteractionCreate, st *st
 		handleCreateEvent(s, ic, st, cfg, mgr)
 	case "create-announcement":
 		handleCreateAnnouncement(s, ic, st, cfg, mgr)
+	case "sync-commands":
+		handleSyncCommands(s, ic, cfg, mgr)
+	case "reload-config":
+		handleReloadConfig(s, ic, cfg, liveConfig)
+	case "info":
+		handleDevInfo(s, ic, cfg)
+	case "copy-settings":
+		handleCopySettings(s, ic, st, cfg)
+	case "export-history":
+		handleExportHistory(s, ic, st, cfg)
+	case "broadcast":
+		handleBroadcast(s, ic, st, cfg)
 	default:
 		replyEphemeral(s, ic, "Unknown dev-test subcommand.")
 	}
@@ -2,6 +2,9 @@ package discord
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +21,10 @@ type fakeProvider struct {
 	at   time.Time
 	ok   bool
 	err  error
+	// last is the completed event returned by LastEvent; nil reports none.
+	last *sources.Event
+	// upcoming is returned by UpcomingEvents (truncated to n).
+	upcoming []sources.Event
 }
 
 func (f *fakeProvider) NextEvent(_ context.Context) (*sources.Event, bool, error) {
@@ -30,6 +37,20 @@ func (f *fakeProvider) NextEvent(_ context.Context) (*sources.Event, bool, error
 	return &sources.Event{Org: "ufc", Name: f.name, Start: f.at.UTC().Format(time.RFC3339)}, true, nil
 }
 
+func (f *fakeProvider) LastEvent(_ context.Context) (*sources.Event, bool, error) {
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	return f.last, f.last != nil, nil
+}
+
+func (f *fakeProvider) UpcomingEvents(_ context.Context, n int) ([]sources.Event, error) {
+	if len(f.upcoming) > n {
+		return f.upcoming[:n], nil
+	}
+	return f.upcoming, nil
+}
+
 func TestHandleStatus_UsesDefaultTZWhenUnset(t *testing.T) {
 	s := &discordgo.Session{}
 	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
@@ -76,30 +97,853 @@ func TestHandleStatus_UsesGuildTZWhenSet(t *testing.T) {
 	}
 }
 
-func TestHandleNextEvent_FindsUpcoming(t *testing.T) {
+func TestGuildLocation_FallbackTZ(t *testing.T) {
+	st := state.Load(":memory:")
+	// A zone name that no longer (or never did) load, as after an IANA rename.
+	st.UpdateGuildTZ("g1", "America/Atlantis")
+	cfg := config.Config{TZ: "America/New_York"}
+
+	if loc, name := guildLocation(st, cfg, "g1"); loc != time.Local || name != "America/Atlantis" {
+		t.Fatalf("without fallback: got %v %q", loc, name)
+	}
+	st.UpdateGuildFallbackTZ("g1", "Europe/London")
+	loc, name := guildLocation(st, cfg, "g1")
+	if name != "Europe/London" || loc.String() != "Europe/London" {
+		t.Fatalf("expected the fallback zone, got %v %q", loc, name)
+	}
+	// A valid primary zone wins over the fallback.
+	st.UpdateGuildTZ("g1", "Asia/Tokyo")
+	if loc, name := guildLocation(st, cfg, "g1"); name != "Asia/Tokyo" || loc.String() != "Asia/Tokyo" {
+		t.Fatalf("expected the primary zone, got %v %q", loc, name)
+	}
+}
+
+func TestHandleStatus_WarnsOnInvalidTZ(t *testing.T) {
+	st := state.Load(":memory:")
+	st.UpdateGuildTZ("g1", "America/Atlantis")
+	cfg := config.Config{TZ: "America/New_York", RunAt: "16:00"}
+
+	got := statusText(st, cfg, "g1")
+	if !strings.Contains(got, "Warning: timezone America/Atlantis is no longer recognized, so times use server local time.") {
+		t.Fatalf("expected invalid tz warning, got %q", got)
+	}
+	st.UpdateGuildFallbackTZ("g1", "Europe/London")
+	got = statusText(st, cfg, "g1")
+	if !strings.Contains(got, "Fallback timezone: Europe/London") || !strings.Contains(got, "so times use Europe/London") {
+		t.Fatalf("expected fallback in warning, got %q", got)
+	}
+	st.UpdateGuildTZ("g1", "Europe/Paris")
+	if got := statusText(st, cfg, "g1"); strings.Contains(got, "Warning") {
+		t.Fatalf("expected no warning for a valid tz, got %q", got)
+	}
+}
+
+func TestSettings_TimezoneFallback(t *testing.T) {
+	st := state.Load(":memory:")
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	set := func(tz, fallback string) {
+		opts := []*discordgo.ApplicationCommandInteractionDataOption{{Type: discordgo.ApplicationCommandOptionString, Name: "tz", Value: tz}}
+		if fallback != "" {
+			opts = append(opts, &discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionString, Name: "fallback", Value: fallback})
+		}
+		handleSettings(&discordgo.Session{}, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name:    "settings",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "timezone", Options: opts}},
+			},
+		}}, st, config.Config{}, nil)
+	}
+
+	set("Europe/London", "Not/A_Zone")
+	if !strings.HasPrefix(got, "Invalid fallback timezone") || st.GetGuildFallbackTZ("g1") != "" {
+		t.Fatalf("expected invalid fallback rejected, got %q", got)
+	}
+	set("Europe/London", "America/New_York")
+	if st.GetGuildFallbackTZ("g1") != "America/New_York" || !strings.Contains(got, "America/New_York will be used") {
+		t.Fatalf("expected fallback set, got %q", got)
+	}
+	// Updating tz alone keeps the fallback; none clears it.
+	set("Europe/Paris", "")
+	if st.GetGuildFallbackTZ("g1") != "America/New_York" {
+		t.Fatalf("expected fallback kept")
+	}
+	set("Europe/Paris", "none")
+	if st.GetGuildFallbackTZ("g1") != "" || !strings.Contains(got, "fallback timezone cleared") {
+		t.Fatalf("expected fallback cleared, got %q", got)
+	}
+}
+
+func TestHandleNextEvent_FindsUpcoming(t *testing.T) {
+	s := &discordgo.Session{}
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+	st := state.Load(":memory:")
+	st.UpdateGuildTZ("g1", "America/New_York")
+	cfg := config.Config{TZ: "America/New_York"}
+
+	// Force next-event resolver to return a known event tomorrow
+	now := time.Now().UTC()
+	tomorrow := now.Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Fight Night: Test", Start: tomorrow.UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewDefaultManager(nil, "test-agent")
+	orgKey := "ufc"
+	st.UpdateGuildOrg("g1", orgKey)
+	if _, ok := mgr.Provider(orgKey); !ok {
+		t.Fatalf("test setup: provider not registered for ufc")
+	}
+	mgr.Register("ufc", &fakeProvider{})
+	st.UpdateGuildOrg("g1", "ufc")
+	mgr.Register("ufc", &fakeProvider{})
+
+	var got string
+	old := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	// Avoid real HTTP calls for embed edit
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+		return nil
+	}
+	defer func() { editInteractionResponse = old }()
+	defer func() { deferInteractionResponse = oldDefer }()
+	defer func() { editInteractionEmbeds = oldEmb }()
+
+	handleNextEvent(s, ic, st, cfg, mgr)
+
+	if !strings.Contains(got, "Next UFC event: UFC Fight Night: Test") {
+		t.Fatalf("expected next event in reply, got: %q", got)
+	}
+}
+
+func TestHandleNextEvent_DebugIDs(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "UTC"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	start := time.Now().UTC().Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", ID: "600039", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	var got string
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+		return nil
+	}
+	defer func() {
+		getNextEventFunc = oldGet
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+		editInteractionEmbeds = oldEmb
+	}()
+
+	ic := func(perms int64) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "u1"}, Permissions: perms},
+		}}
+	}
+	const idLine = "ESPN event ID: 600039"
+
+	// Off by default, even for managers.
+	handleNextEvent(s, ic(discordgo.PermissionManageChannels), st, cfg, mgr)
+	if strings.Contains(got, idLine) {
+		t.Fatalf("expected no event ID by default, got %q", got)
+	}
+
+	st.UpdateGuildDebugIDsEnabled("g1", true)
+	handleNextEvent(s, ic(discordgo.PermissionManageChannels), st, cfg, mgr)
+	if !strings.Contains(got, "Next UFC event: UFC 300") || !strings.HasSuffix(got, "\n"+idLine) {
+		t.Fatalf("expected event ID for a manager with debug-ids on, got %q", got)
+	}
+	// Members without Manage Channels never see it.
+	handleNextEvent(s, ic(0), st, cfg, mgr)
+	if strings.Contains(got, idLine) {
+		t.Fatalf("expected no event ID for a regular member, got %q", got)
+	}
+}
+
+func TestHandleNextEvent_Teaser(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "UTC"}
+	prov := &fakeProvider{}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", prov)
+
+	start := time.Now().UTC().Add(24 * time.Hour)
+	next := sources.Event{Org: "ufc", ID: "600039", Name: "UFC 300", Start: start.Format(time.RFC3339)}
+	then := sources.Event{Org: "ufc", ID: "600040", Name: "UFC 301", Start: "2099-05-04T22:00:00Z"}
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		ev := next
+		return &ev, true, nil
+	}
+	var got string
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+		return nil
+	}
+	defer func() {
+		getNextEventFunc = oldGet
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+		editInteractionEmbeds = oldEmb
+	}()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+	const teaser = "\nThen: UFC 301 on Mon May 4"
+
+	prov.upcoming = []sources.Event{next, then}
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if strings.Contains(got, "Then:") {
+		t.Fatalf("expected no teaser by default, got %q", got)
+	}
+
+	st.UpdateGuildNextEventTeaserEnabled("g1", true)
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if !strings.HasPrefix(got, "Next UFC event: UFC 300") || !strings.HasSuffix(got, teaser) {
+		t.Fatalf("expected teaser after the next event, got %q", got)
+	}
+
+	// An event listed ahead of the next one (e.g., still ongoing) is not "then".
+	earlier := sources.Event{Org: "ufc", ID: "600038", Name: "UFC Fight Night", Start: time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)}
+	prov.upcoming = []sources.Event{earlier, next, then}
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if !strings.HasSuffix(got, teaser) {
+		t.Fatalf("expected the teaser to skip the earlier event, got %q", got)
+	}
+
+	// Nothing scheduled after the next event: no teaser line.
+	prov.upcoming = []sources.Event{earlier, next}
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if strings.Contains(got, "Then:") {
+		t.Fatalf("expected no teaser without a second event, got %q", got)
+	}
+}
+
+func TestHandleTZConvert(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildTZ("g1", "America/New_York")
+	cfg := config.Config{TZ: "UTC"}
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	ic := func(tm, tz string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "tz-convert",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Type: discordgo.ApplicationCommandOptionString, Name: "time", Value: tm},
+					{Type: discordgo.ApplicationCommandOptionString, Name: "tz", Value: tz},
+				},
+			},
+		}}
+	}
+
+	handleTZConvert(s, ic("2024-04-13T22:00:00Z", "Europe/London"), st, cfg)
+	want := "Sat Apr 13 22:00 UTC is:\n- Sat Apr 13 23:00 BST (Europe/London)\n- Sat Apr 13 18:00 EDT (America/New_York, server timezone)"
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+
+	handleTZConvert(s, ic("25:00", "Europe/London"), st, cfg)
+	if !strings.HasPrefix(got, "Invalid time.") {
+		t.Fatalf("expected invalid time reply, got %q", got)
+	}
+	handleTZConvert(s, ic("22:00", "Mars/Olympus"), st, cfg)
+	if !strings.HasPrefix(got, "Invalid timezone.") {
+		t.Fatalf("expected invalid timezone reply, got %q", got)
+	}
+}
+
+func TestParseConvertTime_HHMMUsesTodayUTC(t *testing.T) {
+	now := time.Date(2024, 4, 13, 3, 0, 0, 0, time.FixedZone("X", -5*3600)) // Apr 13 08:00 UTC
+	got, err := parseConvertTime("22:30", now)
+	if err != nil || !got.Equal(time.Date(2024, 4, 13, 22, 30, 0, 0, time.UTC)) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	got, err = parseConvertTime("2024-04-13T18:00:00-04:00", now)
+	if err != nil || !got.Equal(time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)) || got.Location() != time.UTC {
+		t.Fatalf("expected RFC3339 normalized to UTC, got %v, %v", got, err)
+	}
+}
+
+func TestSettings_ExtraPostsGroupRoutes(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildWeighInEnabled("g1", true)
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	settings := func(group *discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name:    "settings",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{group},
+			},
+		}}
+	}
+	handleSettings(s, settings(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name: "extra-posts",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+			Type: discordgo.ApplicationCommandOptionSubCommand,
+			Name: "weigh-in-reminder",
+		}},
+	}), st, config.Config{}, nil)
+	if got != "Weigh-in reminders are currently on." {
+		t.Fatalf("expected the weigh-in state, got %q", got)
+	}
+
+	handleSettings(s, settings(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name: "extra-posts",
+	}), st, config.Config{}, nil)
+	if !strings.HasPrefix(got, "Usage: /settings extra-posts") {
+		t.Fatalf("expected group usage, got %q", got)
+	}
+}
+
+func TestParseReminderOffsets(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []int
+		ok   bool
+	}{
+		{"24,1", []int{24, 1}, true},
+		{" 1, 24 ,1", []int{24, 1}, true},
+		{"off", nil, true},
+		{"0", nil, true},
+		{"24,x", nil, false},
+		{"169", nil, false},
+		{"1,2,3,4,5,6", nil, false},
+	}
+	for _, tc := range tests {
+		got, ok := parseReminderOffsets(tc.in)
+		if ok != tc.ok || !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("parseReminderOffsets(%q) = %v, %v want %v, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+// resultsProvider reports whether fakeProvider's completed events carry winners.
+type resultsProvider struct {
+	fakeProvider
+	results bool
+	asked   bool
+}
+
+func (p *resultsProvider) Capabilities() sources.Capabilities {
+	return sources.Capabilities{HasCards: true, HasResults: p.results}
+}
+
+func (p *resultsProvider) LastEvent(ctx context.Context) (*sources.Event, bool, error) {
+	p.asked = true
+	return p.fakeProvider.LastEvent(ctx)
+}
+
+func TestHandleResults(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "UTC"}
+	prov := &resultsProvider{results: true}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", prov)
+
+	var got string
+	var embeds []*discordgo.MessageEmbed
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, e []*discordgo.MessageEmbed) error {
+		embeds = e
+		return nil
+	}
+	defer func() {
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+		editInteractionEmbeds = oldEmb
+	}()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+
+	handleResults(s, ic, st, cfg, mgr)
+	if got != "No completed UFC events found." || embeds != nil {
+		t.Fatalf("expected no-event reply without embed, got %q (%d embeds)", got, len(embeds))
+	}
+
+	prov.last = &sources.Event{
+		Org: "ufc", Name: "UFC 300", Start: "2024-04-13T22:00:00Z", End: "2024-04-14T04:00:00Z",
+		Bouts: []sources.Bout{
+			{RedName: "Alex Pereira", BlueName: "Jamahal Hill", Winner: "Alex Pereira", Method: "KO/TKO", Round: 1},
+			{RedName: "Zhang Weili", BlueName: "Yan Xiaonan", Winner: "Zhang Weili", Method: "Decision - Unanimous", Round: 5},
+		},
+	}
+	handleResults(s, ic, st, cfg, mgr)
+	if got != "Latest UFC results: UFC 300\nHeld: Sat Apr 13 (UTC)" {
+		t.Fatalf("unexpected reply: %q", got)
+	}
+	if len(embeds) != 1 {
+		t.Fatalf("expected one embed, got %d", len(embeds))
+	}
+	var card strings.Builder
+	for _, f := range embeds[0].Fields {
+		card.WriteString(f.Value + "\n")
+	}
+	for _, want := range []string{"W: Alex Pereira", "W: Zhang Weili"} {
+		if !strings.Contains(card.String(), want) {
+			t.Fatalf("expected %q in card, got %q", want, card.String())
+		}
+	}
+
+	// Undecided cards say the results aren't in yet.
+	prov.last.Bouts = []sources.Bout{{RedName: "A", BlueName: "B"}}
+	handleResults(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "Results haven't been posted yet") {
+		t.Fatalf("expected pending note, got %q", got)
+	}
+}
+
+func TestHandleResults_PostsOnceWithReactions(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	st.UpdateGuildChannel("g1", "main")
+	st.UpdateGuildResultsReactions("g1", []string{"🏆", "🔥"})
+	prov := &resultsProvider{results: true, fakeProvider: fakeProvider{last: &sources.Event{
+		Org: "ufc", Name: "UFC 300", Start: "2024-04-13T22:00:00Z",
+		Bouts: []sources.Bout{{RedName: "Alex Pereira", BlueName: "Jamahal Hill", Winner: "Alex Pereira"}},
+	}}}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", prov)
+
+	var got string
+	var posted []string
+	var reactions int
+	oldEdit, oldDefer, oldEmb, oldSend, oldReact := editInteractionResponse, deferInteractionResponse, editInteractionEmbeds, sendChannelMessageComplex, addMessageReaction
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+		return nil
+	}
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	sendChannelMessageComplex = func(_ *discordgo.Session, channelID string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		posted = append(posted, channelID)
+		return &discordgo.Message{ID: "m1", ChannelID: channelID}, nil
+	}
+	addMessageReaction = func(_ *discordgo.Session, _, _, _ string) error {
+		reactions++
+		return nil
+	}
+	defer func() {
+		editInteractionResponse, deferInteractionResponse, editInteractionEmbeds, sendChannelMessageComplex, addMessageReaction = oldEdit, oldDefer, oldEmb, oldSend, oldReact
+	}()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1", ChannelID: "c1"}}
+
+	handleResults(s, ic, st, config.Config{TZ: "UTC"}, mgr)
+	if len(posted) != 1 || posted[0] != "main" || reactions != 2 {
+		t.Fatalf("expected one post to the alert channel with 2 reactions, got %q and %d", posted, reactions)
+	}
+	if !strings.HasPrefix(got, "Latest UFC results: UFC 300") || !strings.Contains(got, "Posted in <#main>") {
+		t.Fatalf("unexpected reply: %q", got)
+	}
+
+	// Asking again only replies to the member.
+	handleResults(s, ic, st, config.Config{TZ: "UTC"}, mgr)
+	if len(posted) != 1 || strings.Contains(got, "Posted in") {
+		t.Fatalf("expected no repost, got %q and reply %q", posted, got)
+	}
+}
+
+func TestHandleResults_WithoutResultsCapability(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	prov := &resultsProvider{fakeProvider: fakeProvider{last: &sources.Event{Org: "ufc", Name: "UFC 300"}}}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", prov)
+
+	var got string
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	defer func() {
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+	}()
+
+	handleResults(s, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}, st, config.Config{TZ: "UTC"}, mgr)
+	if got != "Results aren't available for UFC." {
+		t.Fatalf("unexpected reply: %q", got)
+	}
+	if prov.asked {
+		t.Fatal("LastEvent should not be called without the results capability")
+	}
+}
+
+// h2hProvider adds the head-to-head capability to fakeProvider.
+type h2hProvider struct {
+	fakeProvider
+	meetings []sources.Meeting
+	err      error
+	asked    sources.Bout
+}
+
+func (*h2hProvider) Capabilities() sources.Capabilities {
+	return sources.Capabilities{HasCards: true, HasHeadToHead: true}
+}
+
+func (p *h2hProvider) PreviousMeetings(_ context.Context, b sources.Bout) ([]sources.Meeting, error) {
+	p.asked = b
+	return p.meetings, p.err
+}
+
+func TestHandleH2H(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+
+	var got string
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 316", Bouts: []sources.Bout{
+			{RedName: "Kayla Harrison", BlueName: "Julianna Pena", Scheduled: "2025-06-08T03:00:00Z"},
+			{RedName: "Merab Dvalishvili", RedID: "1", BlueName: "Sean O'Malley", BlueID: "2", Scheduled: "2025-06-08T04:00:00Z"},
+		}}, true, nil
+	}
+	defer func() {
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+		getNextEventFunc = oldGet
+	}()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+
+	// Without the capability the command says so instead of guessing.
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+	handleH2H(s, ic, st, mgr)
+	if got != "UFC data doesn't include fighters' previous meetings." {
+		t.Fatalf("expected capability reply, got %q", got)
+	}
+
+	p := &h2hProvider{meetings: []sources.Meeting{
+		{Winner: "Merab Dvalishvili", Loser: "Sean O'Malley", Date: "2024-09-15T02:00:00Z"},
+		{Date: "2021-01-01T00:00:00Z"},
+	}}
+	mgr.Register("ufc", p)
+	handleH2H(s, ic, st, mgr)
+	want := "UFC 316 main event: Merab Dvalishvili vs Sean O'Malley\n" +
+		"Previously: Merab Dvalishvili def. Sean O'Malley (2024)\n" +
+		"Previously: Merab Dvalishvili vs Sean O'Malley ended without a winner (2021)"
+	if got != want {
+		t.Fatalf("reply:\n got %q\nwant %q", got, want)
+	}
+	if p.asked.RedID != "1" || p.asked.BlueID != "2" {
+		t.Fatalf("expected the main event bout to be looked up, got %+v", p.asked)
+	}
+
+	p.meetings = nil
+	handleH2H(s, ic, st, mgr)
+	if !strings.HasSuffix(got, "\nThey haven't fought each other before.") {
+		t.Fatalf("expected no-meetings reply, got %q", got)
+	}
+
+	// Unknown fighters aren't reported as never having met.
+	p.err = sources.ErrNoFighterIDs
+	handleH2H(s, ic, st, mgr)
+	if !strings.HasSuffix(got, "\nPrevious meetings aren't available for this bout.") {
+		t.Fatalf("expected unavailable reply, got %q", got)
+	}
+}
+
+func TestHandleCard(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	// Far beyond the display window /next-event would apply.
+	st.UpdateGuildMaxAnnounceDays("g1", 7)
+	cfg := config.Config{TZ: "UTC"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	var got string
+	var embeds []*discordgo.MessageEmbed
+	oldEdit := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, e []*discordgo.MessageEmbed) error {
+		embeds = e
+		return nil
+	}
+	var next *sources.Event
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return next, next != nil, nil
+	}
+	defer func() {
+		editInteractionResponse = oldEdit
+		deferInteractionResponse = oldDefer
+		editInteractionEmbeds = oldEmb
+		getNextEventFunc = oldGet
+	}()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+
+	handleCard(s, ic, st, cfg, mgr)
+	if got != "No upcoming UFC events found, so there's no card to show." || embeds != nil {
+		t.Fatalf("expected no-event reply without embed, got %q (%d embeds)", got, len(embeds))
+	}
+
+	start := time.Now().AddDate(0, 0, 60).UTC().Truncate(time.Hour)
+	next = &sources.Event{
+		Org: "ufc", Name: "UFC 310", Start: start.Format(time.RFC3339),
+		Bouts: []sources.Bout{
+			{WeightClass: "Flyweight", RedName: "Alexandre Pantoja", BlueName: "Kai Asakura"},
+			{WeightClass: "Welterweight", RedName: "Shavkat Rakhmonov", BlueName: "Ian Machado Garry"},
+		},
+	}
+	handleCard(s, ic, st, cfg, mgr)
+	if want := "UFC fight card: UFC 310\nWhen: " + start.Format("Mon Jan 2, 3:04 PM MST") + " (UTC)"; got != want {
+		t.Fatalf("reply: got %q want %q", got, want)
+	}
+	if len(embeds) != 1 {
+		t.Fatalf("expected one embed, got %d", len(embeds))
+	}
+	var card strings.Builder
+	for _, f := range embeds[0].Fields {
+		card.WriteString(f.Value + "\n")
+	}
+	for _, want := range []string{"Alexandre Pantoja", "Ian Machado Garry"} {
+		if !strings.Contains(card.String(), want) {
+			t.Fatalf("expected %q in card, got %q", want, card.String())
+		}
+	}
+
+	// No bouts: still an embed, with a note that depends on whether the
+	// provider supplies cards at all.
+	embeds = nil
+	next.Bouts = nil
+	handleCard(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "UFC data doesn't include fight cards.") || len(embeds) != 1 {
+		t.Fatalf("expected no-card-data note with embed, got %q (%d embeds)", got, len(embeds))
+	}
+	mgr.Register("ufc", &cardProv{})
+	handleCard(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "card hasn't been announced yet") {
+		t.Fatalf("expected pending-card note, got %q", got)
+	}
+}
+
+func TestHandleNextEvent_TZOptionOverridesDisplay(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildTZ("g1", "America/New_York")
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "America/New_York"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Minute)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+
+	var got string
+	old := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	var gotEmb []*discordgo.MessageEmbed
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, embs []*discordgo.MessageEmbed) error {
+		gotEmb = embs
+		return nil
+	}
+	defer func() { editInteractionResponse = old }()
+	defer func() { deferInteractionResponse = oldDefer }()
+	defer func() { editInteractionEmbeds = oldEmb }()
+
+	withTZ := func(tz string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "next-event",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "tz", Type: discordgo.ApplicationCommandOptionString, Value: tz},
+				},
+			},
+		}}
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	handleNextEvent(s, withTZ("Asia/Tokyo"), st, cfg, mgr)
+	want := start.In(tokyo).Format("Mon Jan 2, 3:04 PM MST") + " (Asia/Tokyo)"
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected Tokyo time %q in reply, got: %q", want, got)
+	}
+	if len(gotEmb) != 1 || !strings.Contains(gotEmb[0].Description, "(Asia/Tokyo)") {
+		t.Fatalf("expected embed to use override tz, got: %+v", gotEmb)
+	}
+	// Guild setting is untouched
+	if _, tz, _ := st.GetGuildSettings("g1"); tz != "America/New_York" {
+		t.Fatalf("guild tz changed: %q", tz)
+	}
+
+	// Invalid tz falls back to the guild setting
+	handleNextEvent(s, withTZ("Mars/Olympus"), st, cfg, mgr)
+	if !strings.Contains(got, "(America/New_York)") || !strings.Contains(got, "Unknown timezone Mars/Olympus") {
+		t.Fatalf("expected fallback to guild tz with note, got: %q", got)
+	}
+}
+
+func TestHandleNextEvent_MaxAnnounceDays(t *testing.T) {
+	s := &discordgo.Session{}
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	st.UpdateGuildMaxAnnounceDays("g1", 30)
+	cfg := config.Config{TZ: "UTC"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	var start time.Time
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Far Away", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+
+	var got string
+	old := editInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	oldDefer := deferInteractionResponse
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	oldEmb := editInteractionEmbeds
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+		return nil
+	}
+	defer func() { editInteractionResponse = old }()
+	defer func() { deferInteractionResponse = oldDefer }()
+	defer func() { editInteractionEmbeds = oldEmb }()
+
+	// Within the window: shown as usual
+	start = time.Now().UTC().AddDate(0, 0, 29)
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "Next UFC event: UFC Far Away") {
+		t.Fatalf("expected event within window, got: %q", got)
+	}
+
+	// Beyond the window: hidden
+	start = time.Now().UTC().AddDate(0, 0, 31)
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if got != "No UFC events in the next 30 days." {
+		t.Fatalf("expected window message, got: %q", got)
+	}
+
+	// Clearing the limit shows it again
+	st.UpdateGuildMaxAnnounceDays("g1", 0)
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "UFC Far Away") {
+		t.Fatalf("expected event after clearing window, got: %q", got)
+	}
+}
+
+func TestHandleNextEvent_EmptyStartShownAsTBA(t *testing.T) {
 	s := &discordgo.Session{}
 	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
 	st := state.Load(":memory:")
-	st.UpdateGuildTZ("g1", "America/New_York")
-	cfg := config.Config{TZ: "America/New_York"}
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "UTC"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
 
-	// Force next-event resolver to return a known event tomorrow
-	now := time.Now().UTC()
-	tomorrow := now.Add(24 * time.Hour)
 	oldGet := getNextEventFunc
 	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
-		return &sources.Event{Org: "ufc", Name: "UFC Fight Night: Test", Start: tomorrow.UTC().Format(time.RFC3339)}, true, nil
+		return &sources.Event{Org: "ufc", Name: "UFC Fight Night: TBA"}, true, nil
 	}
 	defer func() { getNextEventFunc = oldGet }()
-	mgr := sources.NewDefaultManager(nil, "test-agent")
-	orgKey := "ufc"
-	st.UpdateGuildOrg("g1", orgKey)
-	if _, ok := mgr.Provider(orgKey); !ok {
-		t.Fatalf("test setup: provider not registered for ufc")
-	}
-	mgr.Register("ufc", &fakeProvider{})
-	st.UpdateGuildOrg("g1", "ufc")
-	mgr.Register("ufc", &fakeProvider{})
 
 	var got string
 	old := editInteractionResponse
@@ -109,9 +953,10 @@ func TestHandleNextEvent_FindsUpcoming(t *testing.T) {
 	}
 	oldDefer := deferInteractionResponse
 	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
-	// Avoid real HTTP calls for embed edit
+	var gotEmb []*discordgo.MessageEmbed
 	oldEmb := editInteractionEmbeds
-	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ []*discordgo.MessageEmbed) error {
+	editInteractionEmbeds = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, embs []*discordgo.MessageEmbed) error {
+		gotEmb = embs
 		return nil
 	}
 	defer func() { editInteractionResponse = old }()
@@ -120,8 +965,18 @@ func TestHandleNextEvent_FindsUpcoming(t *testing.T) {
 
 	handleNextEvent(s, ic, st, cfg, mgr)
 
-	if !strings.Contains(got, "Next UFC event: UFC Fight Night: Test") {
-		t.Fatalf("expected next event in reply, got: %q", got)
+	if got != "Next UFC event: UFC Fight Night: TBA\nWhen: Date TBA" {
+		t.Fatalf("expected TBA reply, got: %q", got)
+	}
+	if len(gotEmb) != 1 || gotEmb[0].Description != "Starts: TBA" {
+		t.Fatalf("expected TBA embed, got: %+v", gotEmb)
+	}
+
+	// The notifier skips instead of erroring
+	st.UpdateGuildChannel("g1", "chan1")
+	st.UpdateGuildNotifyEnabled("g1", true)
+	if posted, reason := notifyGuildCore(s, st, "g1", mgr, cfg, time.Now(), false, ""); posted || reason != "Event date TBA" {
+		t.Fatalf("expected notifier to skip TBA event, got posted=%v reason=%q", posted, reason)
 	}
 }
 
@@ -169,22 +1024,85 @@ func TestHandleHelp_IncludesKeyLines(t *testing.T) {
 	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
 
 	var got string
-	old := sendInteractionResponse
-	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
-		got = content
+	old := sendInteractionData
+	sendInteractionData = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+		got = data.Content
 		return nil
 	}
-	defer func() { sendInteractionResponse = old }()
+	defer func() { sendInteractionData = old }()
 
-	handleHelp(s, ic)
+	handleHelp(s, ic, state.Load(":memory:"))
 
-	for _, want := range []string{"/settings org", "/settings channel", "/settings notifications", "/settings timezone", "/status", "/next-event"} {
+	for _, want := range []string{"/settings org", "/settings channel", "/settings notifications", "/settings timezone", "/status", "/next-event", "/next-check", "/history", "/ping"} {
 		if !strings.Contains(got, want) {
 			t.Fatalf("help reply missing %q in %q", want, got)
 		}
 	}
 }
 
+func TestHandleHelp_Visibility(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+
+	var flags discordgo.MessageFlags
+	old := sendInteractionData
+	sendInteractionData = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+		flags = data.Flags
+		return nil
+	}
+	defer func() { sendInteractionData = old }()
+
+	handleHelp(s, ic, st)
+	if flags&discordgo.MessageFlagsEphemeral == 0 {
+		t.Fatalf("expected an ephemeral /help by default, got flags %v", flags)
+	}
+	st.UpdateGuildHelpVisibility("g1", helpPublic)
+	handleHelp(s, ic, st)
+	if flags&discordgo.MessageFlagsEphemeral != 0 {
+		t.Fatalf("expected a public /help, got flags %v", flags)
+	}
+	st.UpdateGuildHelpVisibility("g1", helpEphemeral)
+	handleHelp(s, ic, st)
+	if flags&discordgo.MessageFlagsEphemeral == 0 {
+		t.Fatalf("expected an ephemeral /help, got flags %v", flags)
+	}
+}
+
+func TestSettings_RepliesGroupRoutes(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildHelpVisibility("g1", helpPublic)
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		GuildID: "g1",
+		Type:    discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "settings",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+				Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name: "replies",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "help-visibility",
+				}},
+			}},
+		},
+	}}
+	handleSettings(s, ic, st, config.Config{}, nil)
+	if got != "/help replies are currently public." {
+		t.Fatalf("expected the help visibility, got %q", got)
+	}
+}
+
 func TestSettings_Timezone_UsageInvalidValid(t *testing.T) {
 	s := &discordgo.Session{}
 	st := state.Load(":memory:")
@@ -251,7 +1169,7 @@ func TestSettings_Timezone_UsageInvalidValid(t *testing.T) {
 	}
 }
 
-func TestSettings_Notifications_UsageWhenMissingOption(t *testing.T) {
+func TestSettings_QueryWhenMissingOption(t *testing.T) {
 	s := &discordgo.Session{}
 	st := state.Load(":memory:")
 	cfg := config.Config{}
@@ -264,17 +1182,37 @@ func TestSettings_Notifications_UsageWhenMissingOption(t *testing.T) {
 	}
 	defer func() { sendInteractionResponse = old }()
 
-	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
-		GuildID: "g1",
-		Type:    discordgo.InteractionApplicationCommand,
-		Data: discordgo.ApplicationCommandInteractionData{
-			Name:    "settings",
-			Options: []*discordgo.ApplicationCommandInteractionDataOption{{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "notifications"}},
-		},
-	}}
-	handleSettings(s, ic, st, cfg, nil)
-	if !strings.Contains(got, "Usage: /settings notifications state:<on|off>") {
-		t.Fatalf("expected notify usage message, got %q", got)
+	query := func(sub string) string {
+		got = ""
+		ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name:    "settings",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{Type: discordgo.ApplicationCommandOptionSubCommand, Name: sub}},
+			},
+		}}
+		handleSettings(s, ic, st, cfg, nil)
+		return got
+	}
+
+	tests := []struct {
+		sub   string
+		apply func()
+		want  string
+	}{
+		{"notifications", func() {}, "Notifications are currently off."},
+		{"notifications", func() { st.UpdateGuildNotifyEnabled("g1", true) }, "Notifications are currently on."},
+		{"events", func() {}, "Scheduled events are currently off."},
+		{"events", func() { st.UpdateGuildEventsEnabled("g1", true) }, "Scheduled events are currently on."},
+		{"delivery", func() {}, "Delivery mode is currently message."},
+		{"delivery", func() { st.UpdateGuildAnnounceEnabled("g1", true) }, "Delivery mode is currently announcement."},
+	}
+	for _, tc := range tests {
+		tc.apply()
+		if reply := query(tc.sub); reply != tc.want {
+			t.Fatalf("/settings %s query: got %q want %q", tc.sub, reply, tc.want)
+		}
 	}
 }
 
@@ -305,6 +1243,52 @@ func TestSettings_Org_UsageWhenMissingOption(t *testing.T) {
 	}
 }
 
+func TestSettings_Org_AllowedOrgs(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	cfg := config.Config{AllowedOrgs: map[string][]string{"g1": {"pfl"}}}
+	st.UpdateGuildOrg("g1", "pfl")
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	orgIC := func(guildID string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: guildID,
+			Type:    discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "settings",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "org",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+						Type: discordgo.ApplicationCommandOptionString, Name: "org", Value: "ufc",
+					}},
+				}},
+			},
+		}}
+	}
+
+	handleSettings(s, orgIC("g1"), st, cfg, nil)
+	if got != "UFC is not available for this server." {
+		t.Fatalf("expected disallowed org reply, got %q", got)
+	}
+	if st.GetGuildOrg("g1") != "pfl" {
+		t.Fatalf("expected org to stay unchanged, got %q", st.GetGuildOrg("g1"))
+	}
+
+	// Unrestricted guild proceeds to the permission check.
+	handleSettings(s, orgIC("g2"), st, cfg, nil)
+	if got != unidentifiedUserMsg {
+		t.Fatalf("expected allowed org to reach permission check, got %q", got)
+	}
+}
+
 func TestHandleNextEvent_ProviderErrorAndUnsupportedOrg(t *testing.T) {
 	s := &discordgo.Session{}
 	st := state.Load(":memory:")
@@ -341,6 +1325,15 @@ func TestHandleNextEvent_ProviderErrorAndUnsupportedOrg(t *testing.T) {
 		t.Fatalf("expected provider error message, got %q", got)
 	}
 
+	// Upstream block pages get a friendly reply instead of the raw error.
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return nil, false, fmt.Errorf("%w: %w", sources.ErrUnavailable, errors.New("invalid character '<'"))
+	}
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if got != "Fight data temporarily unavailable. Please try again in a few minutes." {
+		t.Fatalf("expected friendly unavailable message, got %q", got)
+	}
+
 	// Unsupported org (no provider registered)
 	got = ""
 	st.UpdateGuildOrg("g1", "pride")
@@ -417,3 +1410,335 @@ func TestHandleInteraction_GuardCases(t *testing.T) {
 		t.Fatalf("expected unknown command reply, got %q", got)
 	}
 }
+
+func TestHandleNextEvent_CustomNoEventMessage(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	cfg := config.Config{TZ: "America/New_York"}
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return nil, false, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+
+	var got string
+	oldEdit := editInteractionResponse
+	oldDefer := deferInteractionResponse
+	editInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	deferInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate) error { return nil }
+	defer func() { editInteractionResponse = oldEdit }()
+	defer func() { deferInteractionResponse = oldDefer }()
+
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+	st.UpdateGuildNoEventMessage("g1", "No fights scheduled — touch grass 🌱")
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if got != "No fights scheduled — touch grass 🌱" {
+		t.Fatalf("expected custom no-event message, got %q", got)
+	}
+
+	// Clearing restores the default text
+	st.UpdateGuildNoEventMessage("g1", "")
+	handleNextEvent(s, ic, st, cfg, mgr)
+	if !strings.Contains(got, "No upcoming UFC events") {
+		t.Fatalf("expected default no-event message after reset, got %q", got)
+	}
+}
+
+func TestProviderForGuild_ResolvesAnyOrgCasing(t *testing.T) {
+	mgr := sources.NewManager()
+	p := &fakeProvider{}
+	mgr.Register("ufc", p)
+
+	for _, stored := range []string{"UFC", "Ufc", "ufc"} {
+		st := state.Load(":memory:")
+		st.UpdateGuildOrg("g1", stored)
+		org, got, _, ok := providerForGuild(st, mgr, "g1", false)
+		if !ok || got != p || org != "ufc" {
+			t.Fatalf("stored %q: ok=%v org=%q provider=%p", stored, ok, org, got)
+		}
+	}
+}
+
+func TestHandlePing_ReportsLatencies(t *testing.T) {
+	s := &discordgo.Session{}
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+	st := state.Load(":memory:")
+
+	oldLat := heartbeatLatency
+	heartbeatLatency = func(_ *discordgo.Session) time.Duration { return 42 * time.Millisecond }
+	defer func() { heartbeatLatency = oldLat }()
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	handlePing(s, ic, st)
+
+	if !strings.Contains(got, "Gateway latency: 42ms") {
+		t.Fatalf("expected gateway latency in reply, got %q", got)
+	}
+	if !strings.Contains(got, "DB latency: ") || strings.Contains(got, "DB latency: error") {
+		t.Fatalf("expected DB latency in reply, got %q", got)
+	}
+}
+
+func TestHandleHistory_ListsRecentPosts(t *testing.T) {
+	st := state.Load(":memory:")
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+	ic := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "g1"}}
+
+	handleHistory(&discordgo.Session{}, ic, st)
+	if got != "Nothing has been posted in this server yet." {
+		t.Fatalf("expected empty history reply, got %q", got)
+	}
+
+	_ = st.RecordPost("g1", "ufc", "2024-04-06", "m1")
+	_ = st.RecordPost("g1", "ufc", "2024-04-13", "m2")
+	_ = st.RecordPost("g1", "ufc:weighin", "2024-04-12", "m3")
+	handleHistory(&discordgo.Session{}, ic, st)
+	want := "Recent posts (newest first):\nUFC fight nights: 2024-04-13, 2024-04-06\nUFC weigh-in reminders: 2024-04-12"
+	if got != want {
+		t.Fatalf("history:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestMatchZones_FiltersByRegion(t *testing.T) {
+	zones := []string{"America/Chicago", "America/New_York", "Europe/Lisbon", "Europe/London", "Europe/Paris", "UTC"}
+	tests := []struct {
+		region string
+		want   []string
+	}{
+		{"America", []string{"America/Chicago", "America/New_York"}},
+		{"europe/l", []string{"Europe/Lisbon", "Europe/London"}},
+		{"  utc ", []string{"UTC"}},
+		{"Mars", nil},
+		{"", nil},
+	}
+	for _, tc := range tests {
+		got, total := matchZones(zones, tc.region)
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") || total != len(tc.want) {
+			t.Fatalf("region %q: got %v (total %d) want %v", tc.region, got, total, tc.want)
+		}
+	}
+
+	// The bundled list is capped at maxZoneMatches but reports the full count.
+	got, total := matchZones(ianaZones, "America")
+	if len(got) != maxZoneMatches || total <= maxZoneMatches {
+		t.Fatalf("expected a capped list of America zones, got %d of %d", len(got), total)
+	}
+	for _, z := range ianaZones {
+		if _, err := time.LoadLocation(z); err != nil {
+			t.Fatalf("bundled zone %q does not load: %v", z, err)
+		}
+	}
+}
+
+func TestHandleSubscribeRole_Gating(t *testing.T) {
+	st := state.Load(":memory:")
+	var got string
+	oldSend := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = oldSend }()
+	var calls []string
+	var roleErr error
+	oldAdd, oldRemove := addMemberRole, removeMemberRole
+	addMemberRole = func(_ *discordgo.Session, guildID, userID, roleID string) error {
+		calls = append(calls, "add "+guildID+" "+userID+" "+roleID)
+		return roleErr
+	}
+	removeMemberRole = func(_ *discordgo.Session, guildID, userID, roleID string) error {
+		calls = append(calls, "remove "+guildID+" "+userID+" "+roleID)
+		return roleErr
+	}
+	defer func() { addMemberRole, removeMemberRole = oldAdd, oldRemove }()
+
+	ic := func(roles ...string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "u1"}, Roles: roles},
+		}}
+	}
+	s := &discordgo.Session{}
+
+	// No role configured: nothing changes.
+	handleSubscribeRole(s, ic(), st)
+	if len(calls) != 0 || !strings.Contains(got, "no subscriber role") {
+		t.Fatalf("expected refusal without a role, got %q calls=%v", got, calls)
+	}
+	// Outside a server: nothing changes.
+	handleSubscribeRole(s, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{}}, st)
+	if len(calls) != 0 || !strings.Contains(got, "in a server") {
+		t.Fatalf("expected refusal outside a guild, got %q calls=%v", got, calls)
+	}
+
+	st.UpdateGuildSubscriberRole("g1", "r1")
+	handleSubscribeRole(s, ic(), st)
+	handleSubscribeRole(s, ic("r1"), st)
+	want := []string{"add g1 u1 r1", "remove g1 u1 r1"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") || !strings.Contains(got, "Unsubscribed") {
+		t.Fatalf("expected add then remove, got %v (last reply %q)", calls, got)
+	}
+
+	// Missing Manage Roles surfaces a clear reply.
+	roleErr = errors.New("403 Forbidden")
+	handleSubscribeRole(s, ic(), st)
+	if !strings.Contains(got, "needs Manage Roles") {
+		t.Fatalf("expected permission hint, got %q", got)
+	}
+}
+
+func TestHandleCreateEvent_WorksWithAutoEventsOff(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, false)
+
+	start := time.Now().UTC().Add(72 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProvider{})
+
+	var created []*discordgo.GuildScheduledEventParams
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created = append(created, params)
+		return &discordgo.GuildScheduledEvent{ID: "sev1", Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	oldSendMsg := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSendMsg }()
+	var got string
+	oldSend := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = oldSend }()
+
+	ic := func(perms int64) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type:    discordgo.InteractionApplicationCommand,
+			GuildID: gid,
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "u1"}, Permissions: perms},
+			Data:    discordgo.ApplicationCommandInteractionData{Name: "create-event"},
+		}}
+	}
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+
+	// The automatic path stays off.
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if len(created) != 0 {
+		t.Fatalf("expected no automatic creation with events off, got %d", len(created))
+	}
+
+	// Without Manage Events the command refuses.
+	if !dispatchCommand(s, ic(0), st, cfg, mgr) {
+		t.Fatalf("expected /create-event to be routed")
+	}
+	if len(created) != 0 || !strings.Contains(got, "Manage Events") {
+		t.Fatalf("expected refusal without Manage Events, got %q", got)
+	}
+
+	dispatchCommand(s, ic(discordgo.PermissionManageEvents), st, cfg, mgr)
+	if len(created) != 1 || !strings.Contains(got, "Scheduled event created") {
+		t.Fatalf("expected manual creation with auto events off, got %q (%d created)", got, len(created))
+	}
+	if !strings.Contains(created[0].Description, "Created with /create-event") {
+		t.Fatalf("expected the guild-facing footer, got %q", created[0].Description)
+	}
+	if !st.HasScheduledEvent(gid, "ufc", start.Format("2006-01-02")) {
+		t.Fatalf("expected the created event to be tracked")
+	}
+	if st.GetGuildEventsEnabled(gid) {
+		t.Fatalf("manual creation must not turn automatic events on")
+	}
+}
+
+func TestHandlers_NilMember(t *testing.T) {
+	s := &discordgo.Session{}
+	st := state.Load(":memory:")
+	st.UpdateGuildSubscriberRole("g1", "r1")
+
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	ic := func(name string, opts ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data:    discordgo.ApplicationCommandInteractionData{Name: name, Options: opts},
+		}}
+	}
+	sub := func(name string, opts ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+		return &discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionSubCommand, Name: name, Options: opts}
+	}
+	on := &discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionString, Name: "state", Value: "on"}
+
+	for _, tc := range []struct {
+		name string
+		run  func()
+	}{
+		{"org-settings", func() {
+			handleOrgSettings(s, ic("org-settings", &discordgo.ApplicationCommandInteractionDataOption{
+				Type: discordgo.ApplicationCommandOptionSubCommandGroup, Name: "ufc",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{sub("events", on)},
+			}), st)
+		}},
+		{"settings notifications", func() {
+			handleSettings(s, ic("settings", sub("notifications", on)), st, config.Config{}, nil)
+		}},
+		{"subscribe-role", func() {
+			handleSubscribeRole(s, ic("subscribe-role"), st)
+		}},
+	} {
+		got = ""
+		tc.run()
+		if got != unidentifiedUserMsg {
+			t.Fatalf("%s: expected %q, got %q", tc.name, unidentifiedUserMsg, got)
+		}
+	}
+}
+
+func TestCustomMessageTooLong(t *testing.T) {
+	// 500 multi-byte characters fit even though they are well over 500 bytes.
+	if customMessageTooLong(strings.Repeat("é", maxCustomMessageLen)) {
+		t.Fatalf("expected %d characters to fit", maxCustomMessageLen)
+	}
+	if !customMessageTooLong(strings.Repeat("a", maxCustomMessageLen+1)) {
+		t.Fatalf("expected %d characters to be too long", maxCustomMessageLen+1)
+	}
+}
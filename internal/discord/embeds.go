@@ -2,17 +2,140 @@ package discord
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
+// embedOptions carries per-guild presentation toggles for event embeds.
+type embedOptions struct {
+	Preview      bool   // include the editorial preview link/headline
+	Headshots    bool   // use a main-event fighter headshot as the thumbnail
+	StartsFormat string // one of the startsFormat* presets; empty means long
+	LinkPref     string // one of the linkPref* values; empty means the title heuristic
+	Rankings     bool   // annotate fighter names with division ranking/champion status
+	Flags        bool   // prefix fighter names with their country flag when known
+	Notes        bool   // append ESPN bout notes such as "Rematch" when present
+	ShowEnd      bool   // add an "Ends" line when the provider knows the end time
+	Layout       string // one of the embedLayout* values; empty means stacked
+	SectionOrder string // one of the sectionOrder* values; empty means headline
+	DateFormat   string // one of the dateFormat* values; empty means US
+	MainCardSize int    // bouts from the top counted as the main card; 0 uses the heuristic
+	ResultMethod string // one of the resultMethod* values; empty means emoji
+	ResultEmojis string // "ko,sub,dec" emoji overrides; empty entries use defaultResultEmojis
+}
+
+// Presets for the embed description's "Starts" line.
+const (
+	startsFormatLong     = "long"     // Mon Jan 2, 3:04 PM MST (tz)
+	startsFormatShort    = "short"    // date only
+	startsFormatRelative = "relative" // Discord relative timestamp (<t:unix:R>)
+)
+
+// Date styles for times in embeds, command replies and notifier posts.
+const (
+	dateFormatUS   = "us"   // Mon Jan 2, 3:04 PM MST
+	dateFormatIntl = "intl" // 2 Jan 2006, 15:04 MST
+)
+
+// dateLayouts are the time layouts of one date style.
+type dateLayouts struct {
+	Full string // date and time with zone
+	Date string // day and date
+	Time string // time of day
+}
+
+// dateStyles maps each date style to its layouts; a new locale only needs an
+// entry here (and a /settings embed date-format choice).
+var dateStyles = map[string]dateLayouts{
+	dateFormatUS:   {Full: "Mon Jan 2, 3:04 PM MST", Date: "Mon Jan 2", Time: "3:04 PM"},
+	dateFormatIntl: {Full: "2 Jan 2006, 15:04 MST", Date: "Mon 2 Jan", Time: "15:04"},
+}
+
+// dateStyle returns the layouts for a date format, defaulting to US.
+func dateStyle(format string) dateLayouts {
+	if l, ok := dateStyles[format]; ok {
+		return l
+	}
+	return dateStyles[dateFormatUS]
+}
+
+// Layouts for the Main Card/Prelims fields.
+const (
+	embedLayoutStacked = "stacked" // full-width fields, one under the other
+	embedLayoutInline  = "inline"  // side-by-side columns where Discord has room
+)
+
+// Orders for the Main Card/Prelims fields.
+const (
+	sectionOrderHeadline = "headline" // Main Card first
+	sectionOrderChrono   = "chrono"   // Prelims first, as broadcast
+)
+
+// Ways to show the finish method next to a bout's winner.
+const (
+	resultMethodEmoji = "emoji" // W: Jones 💥 KO/TKO R2
+	resultMethodText  = "text"  // W: Jones (KO/TKO, R2)
+	resultMethodOff   = "off"   // W: Jones
+)
+
+// Finish method kinds, indexing the emoji set.
+const (
+	methodKO = iota
+	methodSub
+	methodDec
+)
+
+// maxResultEmojiLen bounds a custom finish method marker; enough for a custom
+// server emoji like <:knockout:123456789012345678>.
+const maxResultEmojiLen = 64
+
+// defaultResultEmojis are the built-in KO/TKO, submission and decision markers.
+var defaultResultEmojis = [3]string{"💥", "🔒", "📋"}
+
+// Preferences for which link the embed title points to.
+const (
+	linkPrefESPN     = "espn"     // an espn.com page
+	linkPrefOfficial = "official" // the org's official site (see orgOfficialDomains)
+	linkPrefFirst    = "first"    // the first link as provided
+)
+
+// orgOfficialDomains maps org keys to their official website domain.
+var orgOfficialDomains = map[string]string{
+	"ufc": "ufc.com",
+	"pfl": "pflmma.com",
+}
+
+// embedOptionsForGuild loads the guild's embed presentation settings.
+func embedOptionsForGuild(st *state.Store, guildID string) embedOptions {
+	return embedOptions{
+		Preview:      st.GetGuildPreviewEnabled(guildID),
+		Headshots:    st.GetGuildHeadshotsEnabled(guildID),
+		StartsFormat: st.GetGuildStartsFormat(guildID),
+		LinkPref:     st.GetGuildLinkPreference(guildID),
+		Rankings:     st.GetGuildRankingsEnabled(guildID),
+		Flags:        st.GetGuildFlagsEnabled(guildID),
+		Notes:        st.GetGuildNotesEnabled(guildID),
+		ShowEnd:      st.GetGuildShowEndEnabled(guildID),
+		Layout:       st.GetGuildEmbedLayout(guildID),
+		SectionOrder: st.GetGuildSectionOrder(guildID),
+		DateFormat:   st.GetGuildDateFormat(guildID),
+		MainCardSize: st.GetGuildMainCardSize(guildID),
+		ResultMethod: st.GetGuildResultMethod(guildID),
+		ResultEmojis: st.GetGuildResultEmojis(guildID),
+	}
+}
+
 // buildEventEmbed creates a rich embed for an event with optional banner, links,
 // and a prelim/main-card breakdown based on scheduled times or order.
-func buildEventEmbed(orgTitle, tzName string, loc *time.Location, e *sources.Event) *discordgo.MessageEmbed {
+func buildEventEmbed(orgTitle, tzName string, loc *time.Location, e *sources.Event, opts embedOptions) *discordgo.MessageEmbed {
 	if e == nil {
 		return nil
 	}
@@ -22,9 +145,15 @@ func buildEventEmbed(orgTitle, tzName string, loc *time.Location, e *sources.Eve
 	}
 	// Description with start summary
 	desc := ""
-	if t, err := parseAPITime(e.Start); err == nil {
-		local := t.In(loc)
-		desc = fmt.Sprintf("Starts: %s (%s)", local.Format("Mon Jan 2, 3:04 PM MST"), tzName)
+	if strings.TrimSpace(e.Start) == "" {
+		desc = "Starts: TBA"
+	} else if t, err := parseAPITime(e.Start); err == nil {
+		desc = formatStartsLine(t, loc, tzName, opts.StartsFormat, opts.DateFormat)
+	}
+	if opts.ShowEnd && desc != "" {
+		if t, ok := parseScheduledUTC(e.End); ok {
+			desc += "\n" + formatEndsLine(t, loc, tzName, opts.StartsFormat, opts.DateFormat)
+		}
 	}
 
 	emb := &discordgo.MessageEmbed{
@@ -32,12 +161,38 @@ func buildEventEmbed(orgTitle, tzName string, loc *time.Location, e *sources.Eve
 		Description: desc,
 		Color:       0xE74C3C, // a reddish tone
 	}
-	if u := primaryEventURL(e); u != "" {
+	if u := primaryEventURL(e, opts.LinkPref); u != "" {
 		emb.URL = u // make the title clickable to the main event page
 	}
 	if strings.TrimSpace(e.BannerURL) != "" {
 		emb.Image = &discordgo.MessageEmbedImage{URL: e.BannerURL}
 	}
+	if opts.Headshots {
+		if u := headlinerHeadshot(e); u != "" {
+			emb.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: u}
+		}
+	}
+
+	// Venue field, skipped when the provider has no venue data
+	if v := venueText(e); v != "" {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Venue", Value: v, Inline: true})
+	}
+
+	// Where to watch, skipped when the provider lists no broadcasts
+	if w := broadcastsText(e); w != "" {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Watch", Value: w, Inline: true})
+	}
+
+	// Preview field (opt-in): surface the editorial preview ahead of other links
+	if opts.Preview {
+		if l, ok := sources.PreviewLink(e); ok {
+			text := strings.TrimSpace(e.PreviewHeadline)
+			if text == "" {
+				text = "Read the preview"
+			}
+			emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Preview", Value: fmt.Sprintf("[%s](%s)", text, l.URL)})
+		}
+	}
 
 	// Links field (if any)
 	if len(e.Links) > 0 {
@@ -58,28 +213,61 @@ func buildEventEmbed(orgTitle, tzName string, loc *time.Location, e *sources.Eve
 		}
 	}
 
-	// Card breakdown — reverse order within each section.
+	// Card breakdown — reverse order within each section. Inline only changes
+	// placement; formatBouts keeps each value within the field limit either way.
+	inline := opts.Layout == embedLayoutInline
 	if isContenderSeries(e) {
 		// Dana White's Contender Series typically has no prelims; show all as Main Card.
 		sorted := sortBouts(e.Bouts)
 		mains := reverseBouts(sorted)
 		if len(mains) > 0 {
-			emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Main Card", Value: formatBouts(mains, loc), Inline: false})
+			emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Main Card", Value: formatMainCard(mains, loc, opts), Inline: inline})
 		}
 	} else {
-		mains, prelims := splitCard(e.Bouts)
+		mains, prelims := splitCard(e.Bouts, opts.MainCardSize)
 		mains = reverseBouts(mains)
 		prelims = reverseBouts(prelims)
+		var sections []*discordgo.MessageEmbedField
 		if len(mains) > 0 {
-			emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Main Card", Value: formatBouts(mains, loc), Inline: false})
+			sections = append(sections, &discordgo.MessageEmbedField{Name: "Main Card", Value: formatMainCard(mains, loc, opts), Inline: inline})
 		}
 		if len(prelims) > 0 {
-			emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{Name: "Prelims", Value: formatBouts(prelims, loc), Inline: false})
+			sections = append(sections, &discordgo.MessageEmbedField{Name: "Prelims", Value: formatBouts(prelims, loc, opts), Inline: inline})
 		}
+		// Chronological order follows the broadcast: prelims, then the main card.
+		if opts.SectionOrder == sectionOrderChrono {
+			slices.Reverse(sections)
+		}
+		emb.Fields = append(emb.Fields, sections...)
 	}
 	return emb
 }
 
+// formatStartsLine renders the "Starts" description line for a preset in the
+// given date style.
+func formatStartsLine(t time.Time, loc *time.Location, tzName, preset, dateFormat string) string {
+	return formatTimeLine("Starts", t, loc, tzName, preset, dateFormat)
+}
+
+// formatEndsLine renders the optional "Ends" line using the same preset as "Starts".
+func formatEndsLine(t time.Time, loc *time.Location, tzName, preset, dateFormat string) string {
+	return formatTimeLine("Ends", t, loc, tzName, preset, dateFormat)
+}
+
+func formatTimeLine(label string, t time.Time, loc *time.Location, tzName, preset, dateFormat string) string {
+	local := t.In(loc)
+	style := dateStyle(dateFormat)
+	switch preset {
+	case startsFormatShort:
+		return fmt.Sprintf("%s: %s (%s)", label, local.Format(style.Date), tzName)
+	case startsFormatRelative:
+		// Discord renders this in each viewer's own locale/timezone
+		return fmt.Sprintf("%s: <t:%d:R>", label, t.Unix())
+	default:
+		return fmt.Sprintf("%s: %s (%s)", label, local.Format(style.Full), tzName)
+	}
+}
+
 func parseScheduledUTC(s string) (time.Time, bool) {
 	if strings.TrimSpace(s) == "" {
 		return time.Time{}, false
@@ -91,7 +279,10 @@ func parseScheduledUTC(s string) (time.Time, bool) {
 	return t.UTC(), true
 }
 
-func splitCard(bouts []sources.Bout) (mainCard, prelims []sources.Bout) {
+// splitCard separates the card into main card and prelims. When mainCardSize is
+// set and the card is longer than that, the last mainCardSize bouts (the top of
+// the card) are the main card; otherwise a size-based heuristic is used.
+func splitCard(bouts []sources.Bout, mainCardSize int) (mainCard, prelims []sources.Bout) {
 	if len(bouts) == 0 {
 		return nil, nil
 	}
@@ -101,12 +292,14 @@ func splitCard(bouts []sources.Bout) (mainCard, prelims []sources.Bout) {
 	n := len(bs)
 	cutoff := 0
 	switch {
+	case mainCardSize > 0 && n > mainCardSize:
+		cutoff = n - mainCardSize
 	case n >= 10:
 		cutoff = n - 6
 	case n >= 6:
 		cutoff = n - 3
 	default:
-		cutoff = n // everything main when short card
+		cutoff = 0 // everything main when short card
 	}
 	if cutoff < 0 {
 		cutoff = 0
@@ -157,12 +350,55 @@ func isContenderSeries(e *sources.Event) bool {
 	return strings.Contains(name, "contender series") || strings.Contains(short, "contender series")
 }
 
+// mainEventBout returns the main event (the last bout by scheduled order).
+func mainEventBout(e *sources.Event) (sources.Bout, bool) {
+	if e == nil {
+		return sources.Bout{}, false
+	}
+	bs := sortBouts(e.Bouts)
+	if len(bs) == 0 {
+		return sources.Bout{}, false
+	}
+	return bs[len(bs)-1], true
+}
+
+// headlinerHeadshot returns a headshot URL for the main event, preferring the red corner.
+func headlinerHeadshot(e *sources.Event) string {
+	main, ok := mainEventBout(e)
+	if !ok {
+		return ""
+	}
+	if u := strings.TrimSpace(main.RedHeadshot); u != "" {
+		return u
+	}
+	return strings.TrimSpace(main.BlueHeadshot)
+}
+
 // primaryEventURL picks the best event link for the embed title URL.
-// Prefers links labeled like event/gamecast/preview when available.
-func primaryEventURL(e *sources.Event) string {
+// A guild preference (espn/official/first) wins when a matching link exists;
+// otherwise prefers links labeled like event/gamecast/preview when available.
+func primaryEventURL(e *sources.Event, pref string) string {
 	if e == nil || len(e.Links) == 0 {
 		return ""
 	}
+	switch pref {
+	case linkPrefESPN:
+		if u := firstLinkOnDomain(e.Links, "espn.com"); u != "" {
+			return u
+		}
+	case linkPrefOfficial:
+		if d := orgOfficialDomains[sources.NormalizeOrg(e.Org)]; d != "" {
+			if u := firstLinkOnDomain(e.Links, d); u != "" {
+				return u
+			}
+		}
+	case linkPrefFirst:
+		for _, l := range e.Links {
+			if strings.TrimSpace(l.URL) != "" {
+				return l.URL
+			}
+		}
+	}
 	// First pass: match common event page titles
 	for _, l := range e.Links {
 		t := strings.ToLower(strings.TrimSpace(l.Title))
@@ -176,34 +412,234 @@ func primaryEventURL(e *sources.Event) string {
 	return e.Links[0].URL
 }
 
-func formatBouts(bs []sources.Bout, loc *time.Location) string {
+// venueText renders "Venue — City, ST" from whichever parts the event has, or
+// "" when it has neither.
+func venueText(e *sources.Event) string {
+	parts := make([]string, 0, 2)
+	for _, p := range []string{safe(e.Venue), safe(e.Location)} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " — ")
+}
+
+// broadcastsText renders the event's outlets as "ESPN+, ABC", or "" when none.
+func broadcastsText(e *sources.Event) string {
+	names := make([]string, 0, len(e.Broadcasts))
+	for _, b := range e.Broadcasts {
+		if b = safe(b); b != "" {
+			names = append(names, b)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// firstLinkOnDomain returns the first link whose host is domain or a subdomain of it.
+func firstLinkOnDomain(links []sources.Link, domain string) string {
+	for _, l := range links {
+		u, err := url.Parse(strings.TrimSpace(l.URL))
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return l.URL
+		}
+	}
+	return ""
+}
+
+// mainEventPrefix marks the headliner, the first line of the Main Card field.
+const mainEventPrefix = "🏆 **Main Event:** "
+
+func formatBouts(bs []sources.Bout, loc *time.Location, opts embedOptions) string {
+	if len(bs) == 0 {
+		return "—"
+	}
+	return joinFieldLines(boutLines(bs, loc, opts), embedFieldValueLimit)
+}
+
+// formatMainCard renders the Main Card field with its first bout, the
+// headliner once the section is reversed, marked as the main event. Every
+// card path (Contender Series, short cards shown all as Main Card) reverses
+// the same way, so the first line is the headliner in each.
+func formatMainCard(bs []sources.Bout, loc *time.Location, opts embedOptions) string {
 	if len(bs) == 0 {
 		return "—"
 	}
+	lines := boutLines(bs, loc, opts)
+	lines[0] = mainEventPrefix + lines[0]
+	return joinFieldLines(lines, embedFieldValueLimit)
+}
+
+// boutLines renders one line per bout, in the given order.
+func boutLines(bs []sources.Bout, loc *time.Location, opts embedOptions) []string {
 	lines := make([]string, 0, len(bs))
 	for _, b := range bs {
-		names := strings.TrimSpace(fmt.Sprintf("%s vs %s", safe(b.RedName), safe(b.BlueName)))
+		red, blue := safe(b.RedName), safe(b.BlueName)
+		if opts.Rankings {
+			red, blue = withRank(red, b.RedRank), withRank(blue, b.BlueRank)
+		}
+		if opts.Flags {
+			red, blue = withFlag(red, b.RedCountry), withFlag(blue, b.BlueCountry)
+		}
+		red, blue = withRecord(red, b.RedRecord), withRecord(blue, b.BlueRecord)
+		names := strings.TrimSpace(fmt.Sprintf("%s vs %s", red, blue))
 		wc := strings.TrimSpace(b.WeightClass)
 		timePart := ""
 		if t, ok := parseScheduledUTC(b.Scheduled); ok {
-			timePart = t.In(loc).Format("3:04 PM")
+			timePart = t.In(loc).Format(dateStyle(opts.DateFormat).Time)
 		}
 		seg := names
 		if wc != "" {
 			seg += " — " + wc
 		}
-		if timePart != "" {
+		if note := safe(b.Note); opts.Notes && note != "" {
+			seg += " — _" + note + "_"
+		}
+		// Once decided, the result replaces the start time.
+		if res := formatResult(b, opts); res != "" {
+			seg += " — " + res
+		} else if timePart != "" {
 			seg += " — " + timePart
 		}
 		lines = append(lines, seg)
 	}
-	out := strings.Join(lines, "\n")
-	if len(out) > 1024 { // Discord field value limit
-		return out[:1021] + "..."
+	return lines
+}
+
+// formatResult renders a decided bout's winner with its finish method in the
+// guild's style, or "" when there is no winner yet. Methods the bot can't
+// classify fall back to plain text in emoji mode.
+func formatResult(b sources.Bout, opts embedOptions) string {
+	winner := safe(b.Winner)
+	if winner == "" {
+		return ""
+	}
+	out := "W: " + winner
+	method := safe(b.Method)
+	if method == "" || opts.ResultMethod == resultMethodOff {
+		return out
+	}
+	kind, known := methodKind(method)
+	round := ""
+	if b.Round > 0 && kind != methodDec {
+		round = fmt.Sprintf("R%d", b.Round)
+	}
+	if opts.ResultMethod != resultMethodText && known {
+		out += " " + resultEmojis(opts.ResultEmojis)[kind] + " " + method
+		if round != "" {
+			out += " " + round
+		}
+		return out
+	}
+	if round != "" {
+		return out + " (" + method + ", " + round + ")"
+	}
+	return out + " (" + method + ")"
+}
+
+// methodKind classifies a provider finish method such as "KO/TKO",
+// "Submission" or "Decision - Split".
+func methodKind(method string) (int, bool) {
+	m := strings.ToLower(method)
+	switch {
+	case strings.Contains(m, "dec"):
+		return methodDec, true
+	case strings.Contains(m, "sub"):
+		return methodSub, true
+	case strings.Contains(m, "ko"), strings.Contains(m, "knockout"):
+		return methodKO, true
+	}
+	return 0, false
+}
+
+// resultEmojis parses a "ko,sub,dec" override, keeping the default for any
+// entry left blank.
+func resultEmojis(set string) [3]string {
+	out := defaultResultEmojis
+	for i, e := range strings.SplitN(set, ",", len(out)) {
+		if e = strings.TrimSpace(e); e != "" {
+			out[i] = e
+		}
 	}
 	return out
 }
 
+// embedFieldValueLimit is Discord's maximum length for an embed field value.
+const embedFieldValueLimit = 1024
+
+// joinFieldLines joins lines into a field value of at most limit characters.
+// When they don't all fit, whole lines are dropped from the end and replaced by
+// a count ("…and 3 more") so no name is cut mid-way; a first line that is too
+// long on its own is shortened.
+func joinFieldLines(lines []string, limit int) string {
+	out := strings.Join(lines, "\n")
+	if utf8.RuneCountInString(out) <= limit {
+		return out
+	}
+	var b strings.Builder
+	used := 0
+	for i, line := range lines {
+		more := fmt.Sprintf("…and %d more", len(lines)-i)
+		n := utf8.RuneCountInString(line)
+		if i > 0 {
+			n++ // newline
+		}
+		// The remaining lines must still leave room for this line's own "more" note,
+		// unless it is the last one.
+		room := limit - used - n
+		if i < len(lines)-1 {
+			room -= utf8.RuneCountInString(fmt.Sprintf("\n…and %d more", len(lines)-i-1))
+		}
+		if room >= 0 {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(line)
+			used += n
+			continue
+		}
+		if i == 0 {
+			// Nothing fits whole: shorten the first line instead of dropping it.
+			rest := ""
+			if len(lines) > 1 {
+				rest = fmt.Sprintf("\n…and %d more", len(lines)-1)
+			}
+			keep := limit - utf8.RuneCountInString(rest) - 1
+			if keep < 0 {
+				keep = 0
+			}
+			return string([]rune(line)[:keep]) + "…" + rest
+		}
+		b.WriteString("\n" + more)
+		break
+	}
+	return b.String()
+}
+
+// withRank appends a ranking label like "(#3)" or "(C)" to a fighter name.
+func withRank(name, rank string) string {
+	if name == "" || strings.TrimSpace(rank) == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.TrimSpace(rank))
+}
+
+// withRecord appends a fight record like "(27-1)" to a fighter name. ESPN's
+// wins-losses-draws summary drops the draws when there are none.
+func withRecord(name, record string) string {
+	record = strings.TrimSpace(record)
+	if name == "" || record == "" {
+		return name
+	}
+	if parts := strings.Split(record, "-"); len(parts) == 3 && parts[2] == "0" {
+		record = parts[0] + "-" + parts[1]
+	}
+	return fmt.Sprintf("%s (%s)", name, record)
+}
+
 func safe(s string) string {
 	return strings.TrimSpace(s)
 }
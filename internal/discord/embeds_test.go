@@ -0,0 +1,548 @@
+package discord
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+)
+
+func findField(emb *discordgo.MessageEmbed, name string) *discordgo.MessageEmbedField {
+	for _, f := range emb.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func TestBuildEventEmbed_PreviewLinkPreferred(t *testing.T) {
+	ev := &sources.Event{
+		Name:  "UFC 300",
+		Start: "2025-04-13T22:00:00Z",
+		Links: []sources.Link{
+			{Title: "Event Page", URL: "https://espn.example/event"},
+			{Title: "Tickets", URL: "https://espn.example/tickets"},
+			{Title: "Preview", URL: "https://espn.example/preview"},
+		},
+		PreviewHeadline: "Pereira vs Hill: what to watch",
+	}
+
+	// Disabled: no Preview field, title URL from the usual heuristic
+	emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{})
+	if f := findField(emb, "Preview"); f != nil {
+		t.Fatalf("expected no preview field when disabled, got %+v", f)
+	}
+	if emb.URL != "https://espn.example/event" {
+		t.Fatalf("expected event page as title URL, got %q", emb.URL)
+	}
+
+	// Enabled: preview-labeled link is surfaced with its headline
+	emb = buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{Preview: true})
+	f := findField(emb, "Preview")
+	if f == nil {
+		t.Fatalf("expected preview field when enabled")
+	}
+	if f.Value != "[Pereira vs Hill: what to watch](https://espn.example/preview)" {
+		t.Fatalf("unexpected preview value: %q", f.Value)
+	}
+	if emb.Fields[0] != f {
+		t.Fatalf("expected preview field before other fields")
+	}
+
+	// No headline fetched: generic link text
+	ev.PreviewHeadline = ""
+	emb = buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{Preview: true})
+	if f := findField(emb, "Preview"); f == nil || !strings.HasPrefix(f.Value, "[Read the preview]") {
+		t.Fatalf("expected generic preview text, got %+v", f)
+	}
+}
+
+func TestBuildEventEmbed_HeadlinerHeadshotThumbnail(t *testing.T) {
+	ev := &sources.Event{
+		Name:  "UFC 300",
+		Start: "2025-04-13T22:00:00Z",
+		Bouts: []sources.Bout{
+			{RedName: "Opener A", BlueName: "Opener B", Scheduled: "2025-04-13T22:00:00Z", RedHeadshot: "https://img.example/opener.png"},
+			{RedName: "Headliner A", BlueName: "Headliner B", Scheduled: "2025-04-14T03:00:00Z", BlueHeadshot: "https://img.example/headliner-b.png"},
+		},
+	}
+	if emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{}); emb.Thumbnail != nil {
+		t.Fatalf("expected no thumbnail when disabled, got %+v", emb.Thumbnail)
+	}
+	emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{Headshots: true})
+	if emb.Thumbnail == nil || emb.Thumbnail.URL != "https://img.example/headliner-b.png" {
+		t.Fatalf("expected headliner headshot thumbnail, got %+v", emb.Thumbnail)
+	}
+}
+
+func TestBuildEventEmbed_StartsFormatPresets(t *testing.T) {
+	ev := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z"}
+	loc, _ := time.LoadLocation("America/New_York")
+	tests := []struct {
+		preset string
+		want   string
+	}{
+		{"", "Starts: Sat Apr 13, 6:00 PM EDT (America/New_York)"},
+		{startsFormatLong, "Starts: Sat Apr 13, 6:00 PM EDT (America/New_York)"},
+		{startsFormatShort, "Starts: Sat Apr 13 (America/New_York)"},
+		{startsFormatRelative, "Starts: <t:1713045600:R>"},
+	}
+	for _, tc := range tests {
+		emb := buildEventEmbed("UFC", "America/New_York", loc, ev, embedOptions{StartsFormat: tc.preset})
+		if emb.Description != tc.want {
+			t.Fatalf("preset %q: got %q want %q", tc.preset, emb.Description, tc.want)
+		}
+	}
+}
+
+func TestBuildEventEmbed_ShowEnd(t *testing.T) {
+	withEnd := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z", End: "2024-04-14T04:00:00Z"}
+	noEnd := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z"}
+	const starts = "Starts: Sat Apr 13, 10:00 PM UTC (UTC)"
+	tests := []struct {
+		ev      *sources.Event
+		showEnd bool
+		want    string
+	}{
+		{withEnd, false, starts},
+		{noEnd, true, starts},
+		{withEnd, true, starts + "\nEnds: Sun Apr 14, 4:00 AM UTC (UTC)"},
+	}
+	for i, tc := range tests {
+		emb := buildEventEmbed("UFC", "UTC", time.UTC, tc.ev, embedOptions{ShowEnd: tc.showEnd})
+		if emb.Description != tc.want {
+			t.Fatalf("case %d: got %q want %q", i, emb.Description, tc.want)
+		}
+	}
+}
+
+func TestBuildEventEmbed_Venue(t *testing.T) {
+	tests := []struct {
+		venue, location string
+		want            string
+	}{
+		{"T-Mobile Arena", "Las Vegas, NV", "T-Mobile Arena — Las Vegas, NV"},
+		{"", "Abu Dhabi, United Arab Emirates", "Abu Dhabi, United Arab Emirates"},
+		{"UFC APEX", "", "UFC APEX"},
+		{"", "", ""},
+	}
+	for i, tc := range tests {
+		ev := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z", Venue: tc.venue, Location: tc.location}
+		emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{})
+		got := findField(emb, "Venue")
+		if tc.want == "" {
+			if got != nil {
+				t.Fatalf("case %d: expected no Venue field, got %q", i, got.Value)
+			}
+			continue
+		}
+		if got == nil || got.Value != tc.want || !got.Inline {
+			t.Fatalf("case %d: got %+v want inline %q", i, got, tc.want)
+		}
+	}
+}
+
+func TestBuildEventEmbed_Broadcasts(t *testing.T) {
+	ev := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z", Broadcasts: []string{"ESPN+", " ", "ABC"}}
+	emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{})
+	if got := findField(emb, "Watch"); got == nil || got.Value != "ESPN+, ABC" || !got.Inline {
+		t.Fatalf("expected inline Watch field, got %+v", got)
+	}
+	ev.Broadcasts = nil
+	if got := findField(buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{}), "Watch"); got != nil {
+		t.Fatalf("expected no Watch field, got %q", got.Value)
+	}
+}
+
+func TestPrimaryEventURL_LinkPreference(t *testing.T) {
+	ev := &sources.Event{
+		Org: "ufc",
+		Links: []sources.Link{
+			{Title: "Tickets", URL: "https://tickets.example.com/ufc-300"},
+			{Title: "Event Page", URL: "https://www.espn.com/mma/fightcenter/_/id/600039"},
+			{Title: "Official", URL: "https://www.ufc.com/event/ufc-300"},
+		},
+	}
+	tests := []struct {
+		pref string
+		want string
+	}{
+		{"", "https://www.espn.com/mma/fightcenter/_/id/600039"},
+		{linkPrefESPN, "https://www.espn.com/mma/fightcenter/_/id/600039"},
+		{linkPrefOfficial, "https://www.ufc.com/event/ufc-300"},
+		{linkPrefFirst, "https://tickets.example.com/ufc-300"},
+	}
+	for _, tc := range tests {
+		if got := primaryEventURL(ev, tc.pref); got != tc.want {
+			t.Fatalf("pref %q: got %q want %q", tc.pref, got, tc.want)
+		}
+	}
+
+	// No official link available: fall back to the default pick
+	ev.Links = ev.Links[:2]
+	if got := primaryEventURL(ev, linkPrefOfficial); got != "https://www.espn.com/mma/fightcenter/_/id/600039" {
+		t.Fatalf("official fallback: got %q", got)
+	}
+}
+
+func TestBuildEventEmbed_RankingAnnotations(t *testing.T) {
+	ev := &sources.Event{
+		Name:  "UFC 300",
+		Start: "2024-04-13T22:00:00Z",
+		Bouts: []sources.Bout{
+			{RedName: "Unranked A", BlueName: "Unranked B"},
+			{RedName: "Alex Pereira", RedRank: "C", BlueName: "Jamahal Hill", BlueRank: "#1"},
+		},
+	}
+	cardText := func(emb *discordgo.MessageEmbed) string {
+		var b strings.Builder
+		for _, f := range emb.Fields {
+			b.WriteString(f.Value + "\n")
+		}
+		return b.String()
+	}
+
+	got := cardText(buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{Rankings: true}))
+	if !strings.Contains(got, "Alex Pereira (C) vs Jamahal Hill (#1)") {
+		t.Fatalf("expected ranked annotation, got %q", got)
+	}
+	if !strings.Contains(got, "Unranked A vs Unranked B") || strings.Contains(got, "Unranked A (") {
+		t.Fatalf("expected unranked names untouched, got %q", got)
+	}
+
+	// Off by default
+	if got := cardText(buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{})); strings.Contains(got, "(C)") {
+		t.Fatalf("expected no annotations when disabled, got %q", got)
+	}
+}
+
+func TestFormatBouts_Notes(t *testing.T) {
+	bouts := []sources.Bout{
+		{RedName: "Israel Adesanya", BlueName: "Alex Pereira", WeightClass: "MW", Note: "Rematch"},
+		{RedName: "Plain A", BlueName: "Plain B", WeightClass: "LW"},
+	}
+	got := formatBouts(bouts, time.UTC, embedOptions{Notes: true})
+	want := "Israel Adesanya vs Alex Pereira — MW — _Rematch_\nPlain A vs Plain B — LW"
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+	if got := formatBouts(bouts, time.UTC, embedOptions{}); strings.Contains(got, "Rematch") {
+		t.Fatalf("expected no notes when disabled, got %q", got)
+	}
+}
+
+func TestFormatBouts_CountryFlags(t *testing.T) {
+	bouts := []sources.Bout{
+		{RedName: "Charles Oliveira", RedCountry: "Brazil", BlueName: "Dustin Poirier", BlueCountry: "USA"},
+		{RedName: "Conor McGregor", RedCountry: "irl", BlueName: "Unknown Fighter"},
+		{RedName: "Leon Edwards", RedCountry: "England", BlueName: "Odd Place", BlueCountry: "Atlantis"},
+	}
+	got := formatBouts(bouts, time.UTC, embedOptions{Flags: true})
+	for _, want := range []string{
+		"🇧🇷 Charles Oliveira vs 🇺🇸 Dustin Poirier",
+		"🇮🇪 Conor McGregor vs Unknown Fighter",
+		" Leon Edwards vs Odd Place",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in %q", want, got)
+		}
+	}
+	if !strings.HasPrefix(strings.Split(got, "\n")[2], "🏴") {
+		t.Fatalf("expected England's flag, got %q", got)
+	}
+
+	// Off by default
+	if got := formatBouts(bouts, time.UTC, embedOptions{}); strings.Contains(got, "🇧🇷") {
+		t.Fatalf("expected no flags when disabled, got %q", got)
+	}
+}
+
+func TestCountryFlag(t *testing.T) {
+	tests := map[string]string{
+		"USA":           "🇺🇸",
+		"united states": "🇺🇸",
+		" BR ":          "🇧🇷",
+		"nzl":           "🇳🇿",
+		"":              "",
+		"ZZ":            "",
+		"Unknownistan":  "",
+	}
+	for in, want := range tests {
+		if got := countryFlag(in); got != want {
+			t.Fatalf("countryFlag(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBuildEventEmbed_Layout(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	var bouts []sources.Bout
+	for i := 0; i < 8; i++ {
+		bouts = append(bouts, sources.Bout{RedName: fmt.Sprintf("Red %d %s", i, long), BlueName: fmt.Sprintf("Blue %d", i)})
+	}
+	ev := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z", Bouts: bouts}
+	tests := []struct {
+		layout string
+		inline bool
+	}{
+		{"", false},
+		{embedLayoutStacked, false},
+		{embedLayoutInline, true},
+	}
+	for _, tc := range tests {
+		emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{Layout: tc.layout})
+		var cards int
+		for _, f := range emb.Fields {
+			if f.Name != "Main Card" && f.Name != "Prelims" {
+				continue
+			}
+			cards++
+			if f.Inline != tc.inline {
+				t.Fatalf("layout %q: field %q inline=%v want %v", tc.layout, f.Name, f.Inline, tc.inline)
+			}
+			if n := len(f.Value); n > 1024 {
+				t.Fatalf("layout %q: field %q value is %d chars, over the limit", tc.layout, f.Name, n)
+			}
+		}
+		if cards != 2 {
+			t.Fatalf("layout %q: expected Main Card and Prelims fields, got %d", tc.layout, cards)
+		}
+	}
+}
+
+func TestBuildEventEmbed_SectionOrder(t *testing.T) {
+	var bouts []sources.Bout
+	for i := 0; i < 8; i++ {
+		bouts = append(bouts, sources.Bout{RedName: fmt.Sprintf("Red %d", i), BlueName: fmt.Sprintf("Blue %d", i)})
+	}
+	ev := &sources.Event{Name: "UFC 300", Start: "2024-04-13T22:00:00Z", Bouts: bouts}
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{"", []string{"Main Card", "Prelims"}},
+		{sectionOrderHeadline, []string{"Main Card", "Prelims"}},
+		{sectionOrderChrono, []string{"Prelims", "Main Card"}},
+	}
+	for _, tc := range tests {
+		emb := buildEventEmbed("UFC", "UTC", time.UTC, ev, embedOptions{SectionOrder: tc.order})
+		var got []string
+		for _, f := range emb.Fields {
+			if f.Name == "Main Card" || f.Name == "Prelims" {
+				got = append(got, f.Name)
+			}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("order %q: sections %v, want %v", tc.order, got, tc.want)
+		}
+	}
+}
+
+func TestBuildEventEmbed_MarksMainEvent(t *testing.T) {
+	var bouts []sources.Bout
+	for i := 0; i < 8; i++ {
+		bouts = append(bouts, sources.Bout{RedName: fmt.Sprintf("Red %d", i), BlueName: fmt.Sprintf("Blue %d", i)})
+	}
+	cases := []struct {
+		name string
+		ev   *sources.Event
+		want string
+	}{
+		{"full card", &sources.Event{Name: "UFC 300", Bouts: bouts}, "Red 7 vs Blue 7"},
+		{"short card", &sources.Event{Name: "UFC Fight Night", Bouts: bouts[:3]}, "Red 2 vs Blue 2"},
+		{"contender series", &sources.Event{Name: "Dana White's Contender Series", Bouts: bouts[:5]}, "Red 4 vs Blue 4"},
+	}
+	for _, tc := range cases {
+		emb := buildEventEmbed("UFC", "UTC", time.UTC, tc.ev, embedOptions{})
+		main := findField(emb, "Main Card")
+		if main == nil {
+			t.Fatalf("%s: no Main Card field", tc.name)
+		}
+		lines := strings.Split(main.Value, "\n")
+		if lines[0] != mainEventPrefix+tc.want {
+			t.Fatalf("%s: first main card line %q, want the headliner marked", tc.name, lines[0])
+		}
+		if strings.Count(main.Value, mainEventPrefix) != 1 {
+			t.Fatalf("%s: main event marked more than once: %q", tc.name, main.Value)
+		}
+		if prelims := findField(emb, "Prelims"); prelims != nil && strings.Contains(prelims.Value, mainEventPrefix) {
+			t.Fatalf("%s: prelims marked: %q", tc.name, prelims.Value)
+		}
+	}
+}
+
+func TestFormatStartsLine_DateFormats(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	at := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC) // 23:00 BST
+	tests := []struct {
+		format, preset, want string
+	}{
+		{"", startsFormatLong, "Starts: Sat Apr 13, 11:00 PM BST (Europe/London)"},
+		{dateFormatUS, startsFormatLong, "Starts: Sat Apr 13, 11:00 PM BST (Europe/London)"},
+		{dateFormatIntl, startsFormatLong, "Starts: 13 Apr 2024, 23:00 BST (Europe/London)"},
+		{dateFormatUS, startsFormatShort, "Starts: Sat Apr 13 (Europe/London)"},
+		{dateFormatIntl, startsFormatShort, "Starts: Sat 13 Apr (Europe/London)"},
+	}
+	for _, tc := range tests {
+		if got := formatStartsLine(at, loc, "Europe/London", tc.preset, tc.format); got != tc.want {
+			t.Fatalf("format %q preset %q: got %q want %q", tc.format, tc.preset, got, tc.want)
+		}
+	}
+
+	// Bout times follow the style too.
+	ev := &sources.Event{Name: "UFC 300", Start: at.Format(time.RFC3339), Bouts: []sources.Bout{{RedName: "A", BlueName: "B", Scheduled: at.Format(time.RFC3339)}}}
+	emb := buildEventEmbed("UFC", "Europe/London", loc, ev, embedOptions{DateFormat: dateFormatIntl})
+	if !strings.Contains(emb.Description, "13 Apr 2024, 23:00") || !strings.Contains(emb.Fields[len(emb.Fields)-1].Value, "23:00") {
+		t.Fatalf("expected intl times in embed: %q / %+v", emb.Description, emb.Fields)
+	}
+
+	// So do the reminder and live-soon posts.
+	intl := dateStyle(dateFormatIntl).Time
+	if got := formatReminderMessage("UFC 300", 1, at.In(loc), intl); got != "Reminder: UFC 300 starts in 1 hour (23:00 BST)." {
+		t.Fatalf("reminder: got %q", got)
+	}
+	if got := formatLiveSoonMessage("UFC 300", 15*time.Minute, at.In(loc), intl, ""); got != "🔴 UFC 300 starts in 15 minutes (23:00 BST)." {
+		t.Fatalf("live-soon: got %q", got)
+	}
+}
+
+func TestSplitCard_MainCardSize(t *testing.T) {
+	base := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	card := func(n int) []sources.Bout {
+		var bouts []sources.Bout
+		for i := 0; i < n; i++ {
+			bouts = append(bouts, sources.Bout{
+				RedName:   fmt.Sprintf("Fighter %d", i),
+				Scheduled: base.Add(time.Duration(i) * 30 * time.Minute).Format(time.RFC3339),
+			})
+		}
+		return bouts
+	}
+
+	// 12 fights with a main card of 5: the last five are the main card.
+	mains, prelims := splitCard(card(12), 5)
+	if len(mains) != 5 || len(prelims) != 7 {
+		t.Fatalf("expected 5 main / 7 prelims, got %d / %d", len(mains), len(prelims))
+	}
+	if mains[0].RedName != "Fighter 7" || mains[4].RedName != "Fighter 11" || prelims[6].RedName != "Fighter 6" {
+		t.Fatalf("unexpected split: mains %v..%v, last prelim %v", mains[0].RedName, mains[4].RedName, prelims[6].RedName)
+	}
+
+	// Unset falls back to the heuristic (last 6 of 10+).
+	if mains, prelims := splitCard(card(12), 0); len(mains) != 6 || len(prelims) != 6 {
+		t.Fatalf("heuristic: expected 6 / 6, got %d / %d", len(mains), len(prelims))
+	}
+	// Cards no longer than the main card size keep the heuristic split.
+	hm, hp := splitCard(card(4), 0)
+	if mains, prelims := splitCard(card(4), 5); len(mains) != len(hm) || len(prelims) != len(hp) {
+		t.Fatalf("short card: expected heuristic %d / %d, got %d / %d", len(hm), len(hp), len(mains), len(prelims))
+	}
+}
+
+func TestFormatBouts_Records(t *testing.T) {
+	bouts := []sources.Bout{
+		{RedName: "Jon Jones", RedRecord: "27-1-0", BlueName: "Stipe Miocic", BlueRecord: "20-4-0", WeightClass: "Heavyweight"},
+		{RedName: "Known Record", RedRecord: "12-3-1", BlueName: "Debutant"},
+		{RedName: "Plain A", BlueName: "Plain B"},
+	}
+	got := formatBouts(bouts, time.UTC, embedOptions{})
+	want := "Jon Jones (27-1) vs Stipe Miocic (20-4) — Heavyweight\nKnown Record (12-3-1) vs Debutant\nPlain A vs Plain B"
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+
+	// Records stay within the field limit.
+	var many []sources.Bout
+	for i := 0; i < 30; i++ {
+		many = append(many, sources.Bout{RedName: fmt.Sprintf("Red %02d %s", i, strings.Repeat("x", 40)), RedRecord: "20-2-0", BlueName: "Blue", BlueRecord: "18-5-0"})
+	}
+	if n := utf8.RuneCountInString(formatBouts(many, time.UTC, embedOptions{})); n > embedFieldValueLimit {
+		t.Fatalf("value is %d characters, over the %d limit", n, embedFieldValueLimit)
+	}
+}
+
+func TestFormatBouts_TruncatesAtLineBoundary(t *testing.T) {
+	var bouts []sources.Bout
+	for i := 0; i < 20; i++ {
+		// Each line is "Red NN xxx… vs Blue NN" (about 100 characters).
+		bouts = append(bouts, sources.Bout{RedName: fmt.Sprintf("Red %02d %s", i, strings.Repeat("x", 80)), BlueName: fmt.Sprintf("Blue %02d", i)})
+	}
+	got := formatBouts(bouts, time.UTC, embedOptions{})
+	if n := utf8.RuneCountInString(got); n > embedFieldValueLimit {
+		t.Fatalf("value is %d characters, over the %d limit", n, embedFieldValueLimit)
+	}
+	lines := strings.Split(got, "\n")
+	shown := lines[:len(lines)-1]
+	for i, l := range shown {
+		if !strings.HasSuffix(l, fmt.Sprintf("vs Blue %02d", i)) {
+			t.Fatalf("line %d was cut mid-way: %q", i, l)
+		}
+	}
+	if want := fmt.Sprintf("…and %d more", len(bouts)-len(shown)); lines[len(lines)-1] != want {
+		t.Fatalf("expected trailing %q, got %q", want, lines[len(lines)-1])
+	}
+
+	// Under the limit nothing is dropped.
+	if got := formatBouts(bouts[:3], time.UTC, embedOptions{}); strings.Contains(got, "more") || strings.Count(got, "\n") != 2 {
+		t.Fatalf("expected all three bouts, got %q", got)
+	}
+}
+
+func TestFormatBouts_ResultMethodMarkers(t *testing.T) {
+	bouts := []sources.Bout{
+		{RedName: "A", BlueName: "B", Winner: "A", Method: "KO/TKO", Round: 2},
+		{RedName: "C", BlueName: "D", Winner: "D", Method: "Submission", Round: 1},
+		{RedName: "E", BlueName: "F", Winner: "E", Method: "Decision - Unanimous", Round: 3},
+		{RedName: "G", BlueName: "H", Winner: "H", Method: "DQ", Round: 2},
+		{RedName: "I", BlueName: "J", Scheduled: "2024-04-14T02:00:00Z"},
+	}
+	tests := []struct {
+		name string
+		opts embedOptions
+		want []string
+	}{
+		{"emoji default", embedOptions{}, []string{
+			"A vs B — W: A 💥 KO/TKO R2",
+			"C vs D — W: D 🔒 Submission R1",
+			"E vs F — W: E 📋 Decision - Unanimous",
+			"G vs H — W: H (DQ, R2)",
+			"I vs J — 2:00 AM",
+		}},
+		{"custom emojis", embedOptions{ResultEmojis: "👊,,⚖️"}, []string{
+			"A vs B — W: A 👊 KO/TKO R2",
+			"C vs D — W: D 🔒 Submission R1",
+			"E vs F — W: E ⚖️ Decision - Unanimous",
+			"G vs H — W: H (DQ, R2)",
+			"I vs J — 2:00 AM",
+		}},
+		{"text", embedOptions{ResultMethod: resultMethodText}, []string{
+			"A vs B — W: A (KO/TKO, R2)",
+			"C vs D — W: D (Submission, R1)",
+			"E vs F — W: E (Decision - Unanimous)",
+			"G vs H — W: H (DQ, R2)",
+			"I vs J — 2:00 AM",
+		}},
+		{"off", embedOptions{ResultMethod: resultMethodOff}, []string{
+			"A vs B — W: A",
+			"C vs D — W: D",
+			"E vs F — W: E",
+			"G vs H — W: H",
+			"I vs J — 2:00 AM",
+		}},
+	}
+	for _, tc := range tests {
+		got := strings.Split(formatBouts(bouts, time.UTC, tc.opts), "\n")
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("%s:\n got %q\nwant %q", tc.name, got, tc.want)
+		}
+	}
+}
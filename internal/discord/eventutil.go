@@ -2,6 +2,10 @@ package discord
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
@@ -18,11 +22,40 @@ func pickNextEvent(ctx context.Context, p sources.Provider) (*sources.Event, boo
 	return getNextEventFunc(ctx, p)
 }
 
+// nextEventTeaser returns a "Then:" line naming the first event starting after
+// ev, or "" when the provider can't list upcoming events or nothing follows.
+// Lookup errors only drop the teaser. dateLayout is the guild's date style.
+func nextEventTeaser(ctx context.Context, p sources.Provider, ev *sources.Event, loc *time.Location, dateLayout string) string {
+	up, ok := p.(sources.UpcomingProvider)
+	if !ok {
+		return ""
+	}
+	evStart, err := parseAPITime(ev.Start)
+	if err != nil {
+		return ""
+	}
+	// ev is not always first in the list (an ongoing card may still be listed
+	// before it), so fetch a little extra.
+	evs, err := up.UpcomingEvents(ctx, 3)
+	if err != nil {
+		logx.Warn("next-event: teaser lookup failed", "err", err)
+		return ""
+	}
+	for _, e := range evs {
+		t, err := parseAPITime(e.Start)
+		if err != nil || !t.After(evStart) || (e.ID != "" && e.ID == ev.ID) {
+			continue
+		}
+		return "\nThen: " + e.Name + " on " + t.In(loc).Format(dateLayout)
+	}
+	return ""
+}
+
 // providerForGuild returns the org key, provider, and context (with any per-org
 // options applied) for a guild. When defaultToUFC is true, it will fall back to
 // "ufc" when no org is set in state.
 func providerForGuild(st *state.Store, mgr *sources.Manager, guildID string, defaultToUFC bool) (string, sources.Provider, context.Context, bool) {
-	org := st.GetGuildOrg(guildID)
+	org := sources.NormalizeOrg(st.GetGuildOrg(guildID))
 	if org == "" && defaultToUFC {
 		org = "ufc"
 	}
@@ -37,5 +70,17 @@ func providerForGuild(st *state.Store, mgr *sources.Manager, guildID string, def
 	if org == "ufc" {
 		ctx = sources.WithUFCIgnoreContender(ctx, st.GetGuildUFCIgnoreContender(guildID))
 	}
+	if st.GetGuildPreviewEnabled(guildID) {
+		ctx = sources.WithPreviewHeadline(ctx, true)
+	}
 	return org, p, ctx, true
 }
+
+// fetchErrorText is the user-facing reply for a failed event lookup. Upstream
+// blocks get a friendly note; other errors stay generic (details are logged).
+func fetchErrorText(err error) string {
+	if errors.Is(err, sources.ErrUnavailable) {
+		return "Fight data temporarily unavailable. Please try again in a few minutes."
+	}
+	return "Error fetching events. Please try again later."
+}
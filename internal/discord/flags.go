@@ -0,0 +1,158 @@
+package discord
+
+import "strings"
+
+// countryCodes maps lowercase country names and ISO 3166-1 alpha-3 codes (as
+// ESPN reports nationality) to alpha-2 codes, which render as flag emojis.
+// The list covers countries with fighters on major MMA cards; others render
+// without a flag.
+var countryCodes = map[string]string{
+	"afghanistan": "AF", "afg": "AF",
+	"albania": "AL", "alb": "AL",
+	"argentina": "AR", "arg": "AR",
+	"armenia": "AM", "arm": "AM",
+	"australia": "AU", "aus": "AU",
+	"austria": "AT", "aut": "AT",
+	"azerbaijan": "AZ", "aze": "AZ",
+	"bahrain": "BH", "bhr": "BH",
+	"belarus": "BY", "blr": "BY",
+	"belgium": "BE", "bel": "BE",
+	"bolivia": "BO", "bol": "BO",
+	"bosnia and herzegovina": "BA", "bih": "BA",
+	"brazil": "BR", "bra": "BR",
+	"bulgaria": "BG", "bul": "BG", "bgr": "BG",
+	"cameroon": "CM", "cmr": "CM",
+	"canada": "CA", "can": "CA",
+	"chile": "CL", "chi": "CL", "chl": "CL",
+	"china": "CN", "chn": "CN",
+	"colombia": "CO", "col": "CO",
+	"costa rica": "CR", "crc": "CR", "cri": "CR",
+	"croatia": "HR", "cro": "HR", "hrv": "HR",
+	"cuba": "CU", "cub": "CU",
+	"czech republic": "CZ", "czechia": "CZ", "cze": "CZ",
+	"denmark": "DK", "den": "DK", "dnk": "DK",
+	"dominican republic": "DO", "dom": "DO",
+	"dr congo": "CD", "democratic republic of the congo": "CD", "cod": "CD",
+	"ecuador": "EC", "ecu": "EC",
+	"egypt": "EG", "egy": "EG",
+	"estonia": "EE", "est": "EE",
+	"finland": "FI", "fin": "FI",
+	"france": "FR", "fra": "FR",
+	"georgia": "GE", "geo": "GE",
+	"germany": "DE", "ger": "DE", "deu": "DE",
+	"ghana": "GH", "gha": "GH",
+	"greece": "GR", "gre": "GR", "grc": "GR",
+	"hungary": "HU", "hun": "HU",
+	"iceland": "IS", "isl": "IS",
+	"india": "IN", "ind": "IN",
+	"indonesia": "ID", "idn": "ID", "ina": "ID",
+	"iran": "IR", "irn": "IR", "iri": "IR",
+	"iraq": "IQ", "irq": "IQ",
+	"ireland": "IE", "irl": "IE",
+	"israel": "IL", "isr": "IL",
+	"italy": "IT", "ita": "IT",
+	"jamaica": "JM", "jam": "JM",
+	"japan": "JP", "jpn": "JP",
+	"jordan": "JO", "jor": "JO",
+	"kazakhstan": "KZ", "kaz": "KZ",
+	"kyrgyzstan": "KG", "kgz": "KG",
+	"lebanon": "LB", "lbn": "LB", "lib": "LB",
+	"lithuania": "LT", "ltu": "LT",
+	"mexico": "MX", "mex": "MX",
+	"moldova": "MD", "mda": "MD",
+	"mongolia": "MN", "mgl": "MN", "mng": "MN",
+	"montenegro": "ME", "mne": "ME",
+	"morocco": "MA", "mar": "MA",
+	"netherlands": "NL", "ned": "NL", "nld": "NL",
+	"new zealand": "NZ", "nzl": "NZ",
+	"nigeria": "NG", "nga": "NG",
+	"north macedonia": "MK", "mkd": "MK",
+	"norway": "NO", "nor": "NO",
+	"panama": "PA", "pan": "PA",
+	"paraguay": "PY", "par": "PY", "pry": "PY",
+	"peru": "PE", "per": "PE",
+	"philippines": "PH", "phi": "PH", "phl": "PH",
+	"poland": "PL", "pol": "PL",
+	"portugal": "PT", "por": "PT", "prt": "PT",
+	"puerto rico": "PR", "pur": "PR", "pri": "PR",
+	"romania": "RO", "rou": "RO",
+	"russia": "RU", "rus": "RU",
+	"serbia": "RS", "srb": "RS",
+	"singapore": "SG", "sgp": "SG",
+	"slovakia": "SK", "svk": "SK",
+	"slovenia": "SI", "slo": "SI", "svn": "SI",
+	"south africa": "ZA", "rsa": "ZA", "zaf": "ZA",
+	"south korea": "KR", "korea": "KR", "kor": "KR",
+	"spain": "ES", "esp": "ES",
+	"suriname": "SR", "sur": "SR",
+	"sweden": "SE", "swe": "SE",
+	"switzerland": "CH", "sui": "CH", "che": "CH",
+	"tajikistan": "TJ", "tjk": "TJ",
+	"thailand": "TH", "tha": "TH",
+	"tunisia": "TN", "tun": "TN",
+	"turkey": "TR", "türkiye": "TR", "tur": "TR",
+	"ukraine": "UA", "ukr": "UA",
+	"united arab emirates": "AE", "uae": "AE", "are": "AE",
+	"united kingdom": "GB", "great britain": "GB", "gbr": "GB",
+	"united states": "US", "united states of america": "US", "usa": "US",
+	"uruguay": "UY", "uru": "UY", "ury": "UY",
+	"uzbekistan": "UZ", "uzb": "UZ",
+	"venezuela": "VE", "ven": "VE",
+	"vietnam": "VN", "vie": "VN", "vnm": "VN",
+}
+
+// subdivisionFlags are the UK home nations, which ESPN may report instead of
+// the United Kingdom and which use tag-sequence emojis rather than a code pair.
+var subdivisionFlags = map[string]string{
+	"england":  "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F",
+	"eng":      "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F",
+	"scotland": "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F",
+	"sco":      "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F",
+	"wales":    "🏴\U000E0067\U000E0062\U000E0077\U000E006C\U000E0073\U000E007F",
+	"wal":      "🏴\U000E0067\U000E0062\U000E0077\U000E006C\U000E0073\U000E007F",
+}
+
+// countryFlag returns the flag emoji for a country name or ISO code (alpha-2
+// or alpha-3), or "" when the country is empty or unrecognized.
+func countryFlag(country string) string {
+	key := strings.ToLower(strings.TrimSpace(country))
+	if key == "" {
+		return ""
+	}
+	if f, ok := subdivisionFlags[key]; ok {
+		return f
+	}
+	code, ok := countryCodes[key]
+	if !ok {
+		if len(key) != 2 || !knownAlpha2(strings.ToUpper(key)) {
+			return ""
+		}
+		code = strings.ToUpper(key)
+	}
+	// Each letter maps to its regional indicator symbol.
+	var b strings.Builder
+	for _, r := range code {
+		b.WriteRune(0x1F1E6 + (r - 'A'))
+	}
+	return b.String()
+}
+
+// knownAlpha2 reports whether code is one of the alpha-2 codes in countryCodes.
+func knownAlpha2(code string) bool {
+	for _, c := range countryCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// withFlag prefixes name with the flag for country, leaving it unchanged when
+// there is no name or the country has no known flag.
+func withFlag(name, country string) string {
+	f := countryFlag(country)
+	if name == "" || f == "" {
+		return name
+	}
+	return f + " " + name
+}
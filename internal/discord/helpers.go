@@ -1,11 +1,14 @@
 package discord
 
 import (
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 
 	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
@@ -22,16 +25,58 @@ func hasManageOrAdmin(s *discordgo.Session, userID, channelID string) (bool, err
 	return false, nil
 }
 
+// isGuildAdmin reports whether the user owns guildID or holds a role there with
+// Administrator. Unlike ic.Member.Permissions it works for a guild other than the
+// one the interaction came from; the bot must share that guild.
+func isGuildAdmin(s *discordgo.Session, guildID, userID string) (bool, error) {
+	g, err := fetchGuild(s, guildID)
+	if err != nil {
+		return false, err
+	}
+	if g.OwnerID == userID {
+		return true, nil
+	}
+	m, err := fetchGuildMember(s, guildID, userID)
+	if err != nil {
+		return false, err
+	}
+	// The @everyone role shares the guild's ID and applies to every member.
+	held := map[string]bool{guildID: true}
+	for _, id := range m.Roles {
+		held[id] = true
+	}
+	for _, r := range g.Roles {
+		if held[r.ID] && r.Permissions&discordgo.PermissionAdministrator != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// unidentifiedUserMsg is the reply when an interaction arrives without the
+// invoking member, so permissions can't be checked.
+const unidentifiedUserMsg = "Could not identify user."
+
+// interactionUserID returns the invoking member's user ID, or false when
+// Discord sent the interaction without a member (or member user).
+func interactionUserID(ic *discordgo.InteractionCreate) (string, bool) {
+	if ic == nil || ic.Interaction == nil || ic.Member == nil || ic.Member.User == nil {
+		return "", false
+	}
+	return ic.Member.User.ID, true
+}
+
 // requireManageOrAdmin checks for Manage Channels or Admin on a target channel and
 // replies with a suitable message when missing or when permission check fails.
 // Returns true when the caller has permission; false otherwise (and the caller
 // has already been replied to ephemerally).
 func requireManageOrAdmin(s *discordgo.Session, ic *discordgo.InteractionCreate, channelID string, notOKMsg string) bool {
-	if ic == nil || ic.Member == nil || ic.Member.User == nil {
-		_ = sendInteractionResponse(s, ic, "Could not check permissions.")
+	userID, ok := interactionUserID(ic)
+	if !ok {
+		_ = sendInteractionResponse(s, ic, unidentifiedUserMsg)
 		return false
 	}
-	ok, err := hasManageOrAdmin(s, ic.Member.User.ID, channelID)
+	ok, err := hasManageOrAdmin(s, userID, channelID)
 	if err != nil {
 		_ = sendInteractionResponse(s, ic, "Could not check permissions.")
 		return false
@@ -44,15 +89,78 @@ func requireManageOrAdmin(s *discordgo.Session, ic *discordgo.InteractionCreate,
 }
 
 // guildLocation resolves the guild's configured timezone (falling back to
-// global config when unset/invalid) and returns the location and tz name.
+// global config when unset) and returns the location and tz name. A timezone
+// that no longer loads falls back to the guild's fallback timezone, then to
+// time.Local.
 func guildLocation(st *state.Store, cfg config.Config, guildID string) (*time.Location, string) {
 	_, tzName, _ := st.GetGuildSettings(guildID)
 	if tzName == "" {
 		tzName = cfg.TZ
 	}
 	loc, err := time.LoadLocation(tzName)
-	if err != nil {
-		loc = time.Local
+	if err == nil {
+		return loc, tzName
+	}
+	fallback := st.GetGuildFallbackTZ(guildID)
+	// Every tick and command resolves the location, so warn once per guild
+	// and broken timezone (and fallback) rather than on each call.
+	_, warned := invalidTZWarned.LoadOrStore(guildID+"|"+tzName+"|"+fallback, true)
+	if fbLoc, fbErr := time.LoadLocation(fallback); fallback != "" && fbErr == nil {
+		if !warned {
+			logx.Warn("guild timezone invalid; using fallback", "guild_id", guildID, "tz", tzName, "fallback_tz", fallback, "err", err)
+		}
+		return fbLoc, fallback
+	}
+	if !warned {
+		logx.Warn("guild timezone invalid; using local time", "guild_id", guildID, "tz", tzName, "err", err)
+	}
+	return time.Local, tzName
+}
+
+// invalidTZWarned records the guild|tz|fallback combinations guildLocation has
+// already warned about.
+var invalidTZWarned sync.Map
+
+// onOff renders a boolean setting for replies.
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// commandStringOption returns the named top-level string option of a slash command,
+// or "" when absent (or when the interaction carries no command data).
+func commandStringOption(ic *discordgo.InteractionCreate, name string) string {
+	if ic == nil || ic.Interaction == nil {
+		return ""
+	}
+	data, ok := ic.Data.(discordgo.ApplicationCommandInteractionData)
+	if !ok {
+		return ""
+	}
+	for _, o := range data.Options {
+		if o.Name == name && o.Type == discordgo.ApplicationCommandOptionString {
+			return strings.TrimSpace(o.StringValue())
+		}
+	}
+	return ""
+}
+
+// commandBoolOption returns the named top-level boolean option of a slash command,
+// or false when absent.
+func commandBoolOption(ic *discordgo.InteractionCreate, name string) bool {
+	if ic == nil || ic.Interaction == nil {
+		return false
+	}
+	data, ok := ic.Data.(discordgo.ApplicationCommandInteractionData)
+	if !ok {
+		return false
+	}
+	for _, o := range data.Options {
+		if o.Name == name && o.Type == discordgo.ApplicationCommandOptionBoolean {
+			return o.BoolValue()
+		}
 	}
-	return loc, tzName
+	return false
 }
@@ -1,7 +1,9 @@
 package discord
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -10,43 +12,112 @@ import (
 
 	"github.com/zodakzach/fight-night-discord-bot/internal/config"
 	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/metrics"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sentryx"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
-func StartNotifier(s *discordgo.Session, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+// Indirections so tests can drive the notifier loop without sleeping or ticking.
+var (
+	initialTickDelay = 2 * time.Second
+	notifierTickFunc = runNotifierTick
+	scheduleFunc     = scheduleHourly
+	// minuteScheduleFunc drives the per-minute reminder and live-soon check.
+	minuteScheduleFunc = scheduleEveryMinute
+
+	markPostedFunc       = (*state.Store).MarkPosted
+	markPostedAttempts   = 3
+	markPostedRetryDelay = 500 * time.Millisecond
+)
+
+func StartNotifier(s *discordgo.Session, st *state.Store, cfg *config.Holder, mgr *sources.Manager) {
 	// Run on an hourly schedule and only notify guilds whose configured run hour
 	// matches the current hour in their timezone. This supports per-guild overrides
 	// while keeping the env RUN_AT as the default (minutes ignored).
 	go func() {
 		// Capture unexpected panics in the notifier loop
 		defer sentryx.Recover()
-		time.Sleep(2 * time.Second)
-		runNotifierTick(s, st, mgr, cfg)
-		scheduleHourly(func() { runNotifierTick(s, st, mgr, cfg) })
+		runNotifierLoop(s, st, mgr, cfg, time.Now)
+	}()
+	// Event reminders and live-soon reminders key off the event start and need
+	// minute granularity, so they run on their own loop rather than the hourly tick.
+	go func() {
+		defer sentryx.Recover()
+		lookups := nextEventLookups{}
+		minuteScheduleFunc(func() { runMinuteTick(s, st, mgr, cfg.Get(), time.Now(), lookups) })
 	}()
 }
 
-// runNotifierTick loops all guilds and notifies only those matching the configured run time.
-func runNotifierTick(s *discordgo.Session, st *state.Store, mgr *sources.Manager, cfg config.Config) {
-	now := time.Now()
+// runNotifierLoop performs the optional immediate tick and then blocks on the
+// hourly schedule. Each tick reads the current config so reloads take effect,
+// and clock so tests can pin the time the tick sees.
+func runNotifierLoop(s *discordgo.Session, st *state.Store, mgr *sources.Manager, cfg *config.Holder, clock func() time.Time) {
+	tick := func() { notifierTickFunc(s, st, mgr, cfg.Get(), clock) }
+	if cfg.Get().SkipInitialTick {
+		logx.Info("notifier: skipping initial tick; waiting for first scheduled tick")
+	} else {
+		time.Sleep(initialTickDelay)
+		tick()
+	}
+	scheduleFunc(tick)
+}
+
+// runNotifierTick loops all guilds and notifies only those matching the configured
+// run time as of clock().
+func runNotifierTick(s *discordgo.Session, st *state.Store, mgr *sources.Manager, cfg config.Config, clock func() time.Time) {
+	metrics.NotifierTicks.Inc()
+	if cfg.Maintenance {
+		logx.Info("maintenance: skipping")
+		return
+	}
+	now := clock()
 	for _, gid := range st.GuildIDs() {
 		if shouldRunNow(st, gid, cfg, now) {
-			// Create tomorrow's scheduled event first (if any), then post today's message.
-			ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg)
-			notifyGuild(s, st, gid, mgr, cfg)
+			promptMissingChannel(s, st, gid)
+			// Create tomorrow's scheduled event first (if any), then post today's messages.
+			ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, clock)
+			postFightWeekPromo(s, st, gid, mgr, cfg, now)
+			postWeighInReminder(s, st, gid, mgr, cfg, now)
+			notifyGuild(s, st, gid, mgr, cfg, now)
+		} else {
+			// A short-notice event can be listed after the run hour; it still
+			// gets its scheduled event while there is time left before it starts.
+			ensureSameDayScheduledEvent(s, st, gid, mgr, cfg, clock)
 		}
+		// A card that was TBA at post time can fill in on any later tick.
+		refreshPendingCard(s, st, gid, mgr, cfg, now)
 	}
+	updateBotPresence(s, st, mgr, cfg)
 }
 
+// References for interpreting the run hour (/settings run-time-reference).
+const (
+	runTimeLocal = "local" // the guild's timezone (default)
+	runTimeUTC   = "utc"   // UTC, for schedules coordinated across guilds
+)
+
 // shouldRunNow returns true if the given moment's hour matches the guild's configured
-// hour (guild override via state, falling back to cfg.RunAt) in the guild's timezone
-// (falling back to cfg.TZ when unset/invalid).
+// hour (guild override via state, falling back to cfg.RunAt) in the guild's run
+// time reference (see runLocation).
 func shouldRunNow(st *state.Store, guildID string, cfg config.Config, instant time.Time) bool {
-	// Determine timezone
-	loc, _ := guildLocation(st, cfg, guildID)
-	// Determine run hour
+	loc, _ := runLocation(st, cfg, guildID)
+	return instant.In(loc).Hour() == guildRunHour(st, cfg, guildID)
+}
+
+// runLocation returns the location the run hour is read in: UTC when the guild
+// chose the utc reference, otherwise the guild's timezone (falling back to
+// cfg.TZ when unset/invalid).
+func runLocation(st *state.Store, cfg config.Config, guildID string) (*time.Location, string) {
+	if st.GetGuildRunTimeReference(guildID) == runTimeUTC {
+		return time.UTC, "UTC"
+	}
+	return guildLocation(st, cfg, guildID)
+}
+
+// guildRunHour returns the guild's configured run hour, falling back to the hour
+// of cfg.RunAt and then config.DefaultRunAt.
+func guildRunHour(st *state.Store, cfg config.Config, guildID string) int {
 	hour := st.GetGuildRunHour(guildID)
 	if hour < 0 {
 		// Fall back to env default RUN_AT
@@ -57,8 +128,22 @@ func shouldRunNow(st *state.Store, guildID string, cfg config.Config, instant ti
 			hour, _ = strconv.Atoi(strings.Split(config.DefaultRunAt, ":")[0])
 		}
 	}
-	tlocal := instant.In(loc)
-	return tlocal.Hour() == hour
+	return hour
+}
+
+// nextRunAt returns the first hourly tick strictly after instant whose local hour
+// in loc equals hour. Ticks fire at the top of each UTC hour (see scheduleHourly),
+// so for zones with non-whole-hour offsets the run lands mid-hour locally.
+func nextRunAt(instant time.Time, loc *time.Location, hour int) time.Time {
+	next := instant.Truncate(time.Hour).Add(time.Hour)
+	// Any local hour recurs within two days, even across DST transitions.
+	for i := 0; i < 48; i++ {
+		if next.In(loc).Hour() == hour {
+			return next
+		}
+		next = next.Add(time.Hour)
+	}
+	return next
 }
 
 // scheduleHourly invokes fn at the start of each UTC hour (which aligns to :00 in all timezones).
@@ -73,19 +158,67 @@ func scheduleHourly(fn func()) {
 	}
 }
 
-func notifyGuild(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config) {
+// scheduleEveryMinute invokes fn at the start of each minute.
+func scheduleEveryMinute(fn func()) {
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		<-timer.C
+		fn()
+	}
+}
+
+// noChannelPromptDays is how many daily runs a guild can have notifications on
+// without a channel before its owner is DMed about it.
+const noChannelPromptDays = 3
+
+// promptMissingChannel nudges the guild owner, once, when notifications have
+// been on for noChannelPromptDays daily runs with no channel to post to; each
+// of those runs otherwise skips silently. Setting a channel resets the count.
+func promptMissingChannel(s *discordgo.Session, st *state.Store, guildID string) {
+	if alertChannel(st, guildID) != "" {
+		st.ResetGuildNoChannelDays(guildID)
+		return
+	}
+	if !st.GetGuildNotifyEnabled(guildID) {
+		return
+	}
+	if n := st.RecordGuildNoChannelDay(guildID); n != noChannelPromptDays {
+		return
+	}
+	g, err := fetchGuild(s, guildID)
+	if err != nil || g == nil || g.OwnerID == "" {
+		logx.Warn("missing channel prompt: guild lookup failed", "guild_id", guildID, "err", err)
+		return
+	}
+	name := safe(g.Name)
+	if name == "" {
+		name = "your server"
+	}
+	msg := fmt.Sprintf("Fight-night alerts are turned on in %s, but no channel is set, so nothing has been posted for %d days. Run /settings channel there to pick one, or /settings notifications state:off to stop them.", name, noChannelPromptDays)
+	if err := sendDirectMessage(s, g.OwnerID, msg); err != nil {
+		logx.Warn("missing channel prompt send failed", "guild_id", guildID, "err", err)
+		return
+	}
+	logx.Info("missing channel prompt sent", "guild_id", guildID)
+}
+
+func notifyGuild(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, now time.Time) {
 	// Production path: no force, no channel override
-	_, _ = notifyGuildCore(s, st, guildID, mgr, cfg, false, "")
+	_, _ = notifyGuildCore(s, st, guildID, mgr, cfg, now, false, "")
 }
 
 // notifyGuildCore performs the same logic as notifyGuild, with extras to support
 // dev/testing via a force flag and an optional channel override. It returns whether
 // a message was posted and a human-readable reason when it didn’t.
-func notifyGuildCore(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, force bool, channelOverride string) (bool, string) {
-	chConfigured, _, lastPosted := st.GetGuildSettings(guildID)
+func notifyGuildCore(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, now time.Time, force bool, channelOverride string) (bool, string) {
+	if cfg.Maintenance {
+		logx.Info("maintenance: skipping", "guild_id", guildID)
+		return false, "Maintenance mode"
+	}
 	channelID := strings.TrimSpace(channelOverride)
 	if channelID == "" {
-		channelID = chConfigured
+		channelID = alertChannel(st, guildID)
 	}
 	if channelID == "" {
 		return false, "No channel configured"
@@ -109,13 +242,17 @@ func notifyGuildCore(s *discordgo.Session, st *state.Store, guildID string, mgr
 	}
 
 	loc, tz := guildLocation(st, cfg, guildID)
-	now := time.Now().In(loc)
+	now = now.In(loc)
 
 	// Use provider-driven selection and gate on "today" only unless forced.
 	evt, okNext, err := pickNextEvent(ctx, provider)
 	if err != nil || !okNext {
 		return false, "No upcoming event"
 	}
+	// Without a start time there is no event day to post on.
+	if strings.TrimSpace(evt.Start) == "" {
+		return false, "Event date TBA"
+	}
 	stUTC, err := parseAPITime(evt.Start)
 	if err != nil {
 		return false, "Invalid event time"
@@ -126,12 +263,24 @@ func notifyGuildCore(s *discordgo.Session, st *state.Store, guildID string, mgr
 		// Not the event day; skip posting when not forced.
 		return false, "Not event day"
 	}
+	if !force && skippingUntilPPV(st, guildID, org, evt.Name) {
+		return false, "Skipping until next PPV"
+	}
 	todayKey := nextAt.In(loc).Format("2006-01-02")
-
-	already := lastPosted != nil && lastPosted[org] == todayKey
-	if !force && already {
+	key := state.DedupKey(org, state.PostAlert, todayKey)
+	if !force && eventPosted(st, guildID, org, evt.ID, state.PostAlert, key) {
 		return false, "Already posted today"
 	}
+	// The stored channel may since have been converted or replaced by a category;
+	// skip with a clear reason instead of a confusing send failure. A failed
+	// lookup is not fatal: the send below surfaces the real error.
+	ch, chErr := fetchChannel(s, channelID)
+	if chErr != nil {
+		logx.Warn("channel lookup failed", "guild_id", guildID, "channel_id", channelID, "err", chErr)
+	} else if ch != nil && !isSendableChannel(ch.Type) {
+		logx.Warn("notification channel cannot receive messages", "guild_id", guildID, "channel_id", channelID, "type", ch.Type)
+		return false, "Channel is not a text channel"
+	}
 	// Build a lightweight one-event list from the selected pick for messaging.
 	todays := []sources.Event{{
 		Org:       org,
@@ -139,55 +288,692 @@ func notifyGuildCore(s *discordgo.Session, st *state.Store, guildID string, mgr
 		ShortName: evt.ShortName,
 		Start:     nextAt.UTC().Format(time.RFC3339),
 	}}
-	msg := buildMessage(org, todays, loc)
-	// Build embed for the event details
-	emb := buildEventEmbed(strings.ToUpper(org), tz, loc, evt)
-	toSend := &discordgo.MessageSend{Content: msg}
-	if emb != nil {
-		toSend.Embeds = []*discordgo.MessageEmbed{emb}
+	sections := st.GetGuildAlertSections(guildID)
+	msg := buildMessage(org, todays, loc, sections, st.GetGuildMessageTemplate(guildID))
+	// Ping one role: the admin-chosen ping role takes precedence over the
+	// opt-in subscriber role, so members holding both aren't pinged twice.
+	var pingRoles []string
+	if roleID := st.GetGuildPingRole(guildID); roleID != "" {
+		msg = "<@&" + roleID + "> " + msg
+		pingRoles = append(pingRoles, roleID)
+	} else if roleID := st.GetGuildSubscriberRole(guildID); roleID != "" {
+		msg = "<@&" + roleID + ">\n" + msg
+		pingRoles = append(pingRoles, roleID)
 	}
-	sent, sendErr := sendChannelMessageComplex(s, channelID, toSend)
-	if sendErr != nil {
-		logx.Error("send message error", "guild_id", guildID, "err", sendErr)
-		return false, "Send failed"
+	// Build embed for the event details, unless the guild turned it off
+	var emb *discordgo.MessageEmbed
+	if sections.Embed {
+		emb = buildEventEmbed(sources.DisplayOrg(org), tz, loc, evt, embedOptionsForGuild(st, guildID))
+	}
+	// Claim the post before sending so a restart mid-send can't post it twice.
+	claimed := false
+	if !force {
+		claimed = claimPost(st, guildID, key)
+		claimEventPost(st, guildID, org, evt.ID, state.PostAlert, todayKey)
+	}
+	// Long content is split across messages; the embed rides on the last one.
+	chunks := splitForDiscord(msg)
+	sentMsgs := make([]*discordgo.Message, 0, len(chunks))
+	embedMsgID := ""
+	for i, chunk := range chunks {
+		toSend := &discordgo.MessageSend{Content: chunk, AllowedMentions: allowedMentions()}
+		if len(pingRoles) > 0 && i == 0 {
+			toSend.AllowedMentions = allowedMentions(pingRoles...)
+		}
+		if emb != nil && i == len(chunks)-1 {
+			toSend.Embeds = []*discordgo.MessageEmbed{emb}
+		}
+		sent, sendErr := sendChannelMessageComplex(s, channelID, toSend)
+		if sendErr != nil {
+			metrics.MessageSendErrors.Inc()
+			logx.Error("send message error", "guild_id", guildID, "part", i+1, "parts", len(chunks), "err", sendErr)
+			if i == 0 {
+				if claimed {
+					releasePost(st, guildID, key)
+				}
+				if !force {
+					releaseEventPost(st, guildID, org, evt.ID, state.PostAlert)
+				}
+				return false, "Send failed"
+			}
+			// Part of the alert is already out; stop but keep it marked posted
+			// rather than re-posting the earlier parts next tick.
+			break
+		}
+		metrics.MessagesSent.Inc()
+		if sent != nil {
+			sentMsgs = append(sentMsgs, sent)
+			if toSend.Embeds != nil {
+				embedMsgID = sent.ID
+			}
+		}
 	}
 
 	// If announcement mode is enabled and the channel supports it, attempt to crosspost.
-	if st.GetGuildAnnounceEnabled(guildID) && sent != nil {
-		ch, chErr := s.Channel(channelID)
+	if st.GetGuildAnnounceEnabled(guildID) && len(sentMsgs) > 0 {
 		if chErr == nil && ch != nil && ch.Type == discordgo.ChannelTypeGuildNews {
-			if _, xerr := s.ChannelMessageCrosspost(channelID, sent.ID); xerr != nil {
-				logx.Warn("crosspost failed", "guild_id", guildID, "channel_id", channelID, "message_id", sent.ID, "err", xerr)
+			for _, sent := range sentMsgs {
+				if _, xerr := s.ChannelMessageCrosspost(channelID, sent.ID); xerr != nil {
+					logx.Warn("crosspost failed", "guild_id", guildID, "channel_id", channelID, "message_id", sent.ID, "err", xerr)
+				}
 			}
 		}
 	}
 
+	if st.GetGuildPinEnabled(guildID) && len(sentMsgs) > 0 {
+		pinNotifierMessage(s, st, guildID, channelID, sentMsgs[0].ID)
+	}
+
 	if !force {
-		st.MarkPosted(guildID, org, todayKey)
+		recordPost(st, guildID, key, sentMsgs)
+		notifyWebhook(st, guildID, org, evt, now)
+		// The awaited PPV is out: end the one-shot skip mode.
+		if sources.IsPPV(org, evt.Name) && st.GetGuildSkipUntilPPV(guildID) {
+			st.UpdateGuildSkipUntilPPV(guildID, false)
+			logx.Info("skip-until-ppv cleared", "guild_id", guildID, "event", evt.Name)
+		}
+		// Remember an alert posted before the card was known so it can be updated.
+		if len(evt.Bouts) == 0 && embedMsgID != "" && mgr.Capabilities(org).HasCards {
+			if err := st.SetPendingCard(guildID, org, todayKey, channelID, embedMsgID); err != nil {
+				logx.Warn("record pending card failed", "guild_id", guildID, "org", org, "err", err)
+			}
+		}
+		if !claimed {
+			// The message went out but dedup state didn't persist; the next tick may re-post.
+			return true, "Posted, but failed to record it (may re-post)"
+		}
 	}
 	return true, "OK"
 }
 
-// ensureTomorrowScheduledEvent creates a Discord Scheduled Event the day before the
-// next event (based on guild timezone) if not already created.
-func ensureTomorrowScheduledEvent(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config) {
+// Card update modes for /settings card-update-mode.
+const (
+	cardUpdateEdit = "edit" // quietly edit the original alert's embed
+	cardUpdateNew  = "new"  // post a follow-up "Card update" message
+)
+
+// refreshPendingCard delivers today's card once it becomes available for an
+// alert that was posted while the card was still TBA, either by editing the
+// alert or posting a follow-up per the guild's card update mode. Failures are
+// retried on the next tick.
+func refreshPendingCard(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, now time.Time) {
+	if !st.HasGuildOrg(guildID) {
+		return
+	}
+	org := st.GetGuildOrg(guildID)
+	// Names-only providers never fill in a card; don't wait for one.
+	if !mgr.Capabilities(org).HasCards {
+		return
+	}
+	loc, tz := guildLocation(st, cfg, guildID)
+	today := now.In(loc).Format("2006-01-02")
+	channelID, messageID := st.PendingCard(guildID, org, today)
+	if messageID == "" {
+		return
+	}
+	_, provider, ctx, ok := providerForGuild(st, mgr, guildID, false)
+	if !ok {
+		return
+	}
+	evt, ok, err := pickNextEvent(ctx, provider)
+	if err != nil || !ok || len(evt.Bouts) == 0 {
+		return
+	}
+	stUTC, err := parseAPITime(evt.Start)
+	if err != nil || stUTC.In(loc).Format("2006-01-02") != today {
+		return
+	}
+	emb := buildEventEmbed(sources.DisplayOrg(org), tz, loc, evt, embedOptionsForGuild(st, guildID))
+	if emb == nil {
+		return
+	}
+	if st.GetGuildCardUpdateMode(guildID) == cardUpdateNew {
+		_, err = sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{
+			Content:         "Card update: " + safe(evt.Name),
+			Embeds:          []*discordgo.MessageEmbed{emb},
+			AllowedMentions: allowedMentions(),
+		})
+	} else {
+		embeds := []*discordgo.MessageEmbed{emb}
+		_, err = editChannelMessageComplex(s, &discordgo.MessageEdit{ID: messageID, Channel: channelID, Embeds: &embeds})
+	}
+	if err != nil {
+		logx.Warn("card update failed", "guild_id", guildID, "channel_id", channelID, "message_id", messageID, "err", err)
+		return
+	}
+	if err := st.SetPendingCard(guildID, org, today, "", ""); err != nil {
+		logx.Warn("clear pending card failed; card update may repeat", "guild_id", guildID, "org", org, "err", err)
+	}
+}
+
+// isSendableChannel reports whether the bot can post messages in a channel of this type.
+func isSendableChannel(t discordgo.ChannelType) bool {
+	switch t {
+	case discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews,
+		discordgo.ChannelTypeGuildNewsThread, discordgo.ChannelTypeGuildPublicThread, discordgo.ChannelTypeGuildPrivateThread:
+		return true
+	default:
+		return false
+	}
+}
+
+// defaultWeighInMessage is the weigh-in reminder used when the guild hasn't set one.
+const defaultWeighInMessage = "Weigh-ins are today for {event}! Fight night is tomorrow."
+
+// formatWeighInMessage fills the {event} placeholder in a weigh-in reminder.
+func formatWeighInMessage(tmpl, eventName string) string {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = defaultWeighInMessage
+	}
+	return strings.ReplaceAll(tmpl, "{event}", eventName)
+}
+
+// postWeighInReminder posts the opt-in weigh-in reminder on the day before the
+// next event (guild timezone), at most once per event.
+func postWeighInReminder(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, now time.Time) {
+	if !st.GetGuildWeighInEnabled(guildID) || !st.HasGuildOrg(guildID) {
+		return
+	}
+	channelID := alertChannel(st, guildID)
+	if channelID == "" {
+		return
+	}
+	org := st.GetGuildOrg(guildID)
+	_, provider, ctx, ok := providerForGuild(st, mgr, guildID, false)
+	if !ok {
+		return
+	}
+	evt, ok, err := pickNextEvent(ctx, provider)
+	if err != nil || !ok || skippingUntilPPV(st, guildID, org, evt.Name) {
+		return
+	}
+	stUTC, err := parseAPITime(evt.Start)
+	if err != nil {
+		return
+	}
+	loc, _ := guildLocation(st, cfg, guildID)
+	evLocal := stUTC.In(loc)
+	// Weigh-ins are the day before the event.
+	if calendarDaysBetween(now.In(loc), evLocal) != 1 {
+		return
+	}
+	date := evLocal.Format("2006-01-02")
+	key := state.DedupKey(org, state.PostWeighIn, date)
+	if eventPosted(st, guildID, org, evt.ID, state.PostWeighIn, key) {
+		return
+	}
+	msg := formatWeighInMessage(st.GetGuildWeighInMessage(guildID), safe(evt.Name))
+	claimed := claimPost(st, guildID, key)
+	claimEventPost(st, guildID, org, evt.ID, state.PostWeighIn, date)
+	sent, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()})
+	if err != nil {
+		logx.Warn("weigh-in reminder send failed", "guild_id", guildID, "org", org, "err", err)
+		if claimed {
+			releasePost(st, guildID, key)
+		}
+		releaseEventPost(st, guildID, org, evt.ID, state.PostWeighIn)
+		return
+	}
+	recordPost(st, guildID, key, []*discordgo.Message{sent})
+}
+
+// reminderWindow is how long after (start - offset) a reminder may still fire.
+// It matches the per-minute tick spacing, so each offset lands on exactly one
+// tick.
+const reminderWindow = time.Minute
+
+// reminderDue reports whether now falls within window of the moment offset
+// before start, and the event hasn't started yet.
+func reminderDue(now, start time.Time, offset, window time.Duration) bool {
+	at := start.Add(-offset)
+	return !now.Before(at) && now.Before(at.Add(window)) && now.Before(start)
+}
+
+// formatReminderMessage renders the reminder for one offset, e.g. "Reminder:
+// UFC 300 starts in 1 hour (10:00 PM EDT)." timeLayout is the guild's date
+// style time of day.
+func formatReminderMessage(eventName string, hours int, startLocal time.Time, timeLayout string) string {
+	in := "1 hour"
+	if hours != 1 {
+		in = fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("Reminder: %s starts in %s (%s).", eventName, in, startLocal.Format(timeLayout+" MST"))
+}
+
+// postEventReminders posts one reminder per configured offset whose window
+// contains now. Each offset is marked per event (the event date when the
+// provider has no ID) before sending, so later ticks and restarts don't repeat
+// it and two events on one day each get their reminders.
+func postEventReminders(s *discordgo.Session, st *state.Store, g minuteGuild, cfg config.Config, now time.Time, evt *sources.Event) {
+	guildID, org := g.id, g.org
+	if skippingUntilPPV(st, guildID, org, evt.Name) {
+		return
+	}
+	stUTC, err := parseAPITime(evt.Start)
+	if err != nil {
+		return
+	}
+	channelID := alertChannel(st, guildID)
+	loc, _ := guildLocation(st, cfg, guildID)
+	date := stUTC.In(loc).Format("2006-01-02")
+	key := evt.ID
+	if key == "" {
+		key = date
+	}
+	for _, h := range g.offsets {
+		kind := state.ReminderKind(h)
+		if !reminderDue(now, stUTC, time.Duration(h)*time.Hour, reminderWindow) || st.HasPostedKind(guildID, org, key, kind) {
+			continue
+		}
+		if err := st.MarkPostedKind(guildID, org, key, kind, date); err != nil {
+			logx.Warn("reminder mark failed; skipping", "guild_id", guildID, "org", org, "offset_hours", h, "err", err)
+			continue
+		}
+		msg := formatReminderMessage(safe(evt.Name), h, stUTC.In(loc), dateStyle(st.GetGuildDateFormat(guildID)).Time)
+		if _, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); err != nil {
+			logx.Warn("reminder send failed", "guild_id", guildID, "org", org, "offset_hours", h, "err", err)
+			if err := st.UnmarkPostedKind(guildID, org, key, kind); err != nil {
+				logx.Warn("reminder unmark failed", "guild_id", guildID, "org", org, "offset_hours", h, "err", err)
+			}
+		}
+	}
+}
+
+// nextEventRecheck is how long a looked-up start time is trusted while nothing
+// is due for it, so a rescheduled event is noticed.
+const nextEventRecheck = time.Hour
+
+// nextEventLookup is the last next-event lookup for one provider query.
+type nextEventLookup struct {
+	evt     *sources.Event // nil when there was no usable next event
+	start   time.Time
+	fetched time.Time
+}
+
+// nextEventLookups remembers the last next-event lookup per provider query
+// across minute ticks. Only the minute loop uses it.
+type nextEventLookups map[string]nextEventLookup
+
+// next returns the event for key, calling fetch only when there is no trusted
+// lookup or due reports that something may post for the known start. When
+// nothing is due the tick does no provider work at all. A failed fetch isn't
+// remembered, so the next tick tries again instead of waiting out
+// nextEventRecheck.
+func (l nextEventLookups) next(key string, now time.Time, due func(start time.Time) bool, fetch func() (*sources.Event, bool, error)) (*sources.Event, bool) {
+	if e, ok := l[key]; ok && now.Sub(e.fetched) < nextEventRecheck {
+		if e.evt == nil || !due(e.start) {
+			return nil, false
+		}
+	}
+	evt, ok, err := fetch()
+	if err != nil {
+		return nil, false
+	}
+	entry := nextEventLookup{fetched: now}
+	if ok {
+		if t, err := parseAPITime(evt.Start); err == nil {
+			entry.evt, entry.start = evt, t
+		}
+	}
+	l[key] = entry
+	return entry.evt, entry.evt != nil
+}
+
+// minuteGuild is a guild waiting on event reminders or a live-soon reminder.
+type minuteGuild struct {
+	id, org string
+	lead    time.Duration // live-soon lead; 0 when off
+	offsets []int         // reminder offsets in hours
+}
+
+// due reports whether a reminder or live-soon post for start may fire at now.
+func (g minuteGuild) due(now, start time.Time) bool {
+	if g.lead > 0 && reminderDue(now, start, g.lead, g.lead) {
+		return true
+	}
+	for _, h := range g.offsets {
+		if reminderDue(now, start, time.Duration(h)*time.Hour, reminderWindow) {
+			return true
+		}
+	}
+	return false
+}
+
+// runMinuteTick posts due event reminders and live-soon reminders. Opted-in
+// guilds are grouped by provider query (org, plus the Contender Series filter
+// for UFC) so each next event is resolved at most once per tick and shared
+// across guilds.
+func runMinuteTick(s *discordgo.Session, st *state.Store, mgr *sources.Manager, cfg config.Config, now time.Time, lookups nextEventLookups) {
+	if cfg.Maintenance {
+		return
+	}
+	groups := map[string][]minuteGuild{}
+	for _, gid := range st.GuildIDs() {
+		minutes := st.GetGuildLiveSoonMinutes(gid)
+		offsets := st.GetGuildReminderOffsets(gid)
+		if (minutes <= 0 && len(offsets) == 0) || !st.GetGuildNotifyEnabled(gid) || !st.HasGuildOrg(gid) || alertChannel(st, gid) == "" {
+			continue
+		}
+		org := sources.NormalizeOrg(st.GetGuildOrg(gid))
+		key := org
+		if org == "ufc" && !st.GetGuildUFCIgnoreContender(gid) {
+			key += ":contender"
+		}
+		groups[key] = append(groups[key], minuteGuild{id: gid, org: org, lead: time.Duration(max(minutes, 0)) * time.Minute, offsets: offsets})
+	}
+	for key, guilds := range groups {
+		due := func(start time.Time) bool {
+			for _, g := range guilds {
+				if g.due(now, start) {
+					return true
+				}
+			}
+			return false
+		}
+		evt, ok := lookups.next(key, now, due, func() (*sources.Event, bool, error) {
+			_, provider, ctx, ok := providerForGuild(st, mgr, guilds[0].id, false)
+			if !ok {
+				return nil, false, nil
+			}
+			evt, ok, err := pickNextEvent(ctx, provider)
+			if err != nil {
+				logx.Warn("minute tick: next event lookup failed", "key", key, "err", err)
+			}
+			return evt, ok, err
+		})
+		if !ok {
+			continue
+		}
+		for _, g := range guilds {
+			if len(g.offsets) > 0 {
+				postEventReminders(s, st, g, cfg, now, evt)
+			}
+			if g.lead > 0 {
+				postLiveSoonReminder(s, st, g, cfg, now, evt)
+			}
+		}
+	}
+}
+
+// formatLiveSoonMessage renders the live-soon reminder, linking the Discord
+// scheduled event when one is tracked. timeLayout is the guild's date style
+// time of day.
+func formatLiveSoonMessage(eventName string, left time.Duration, startLocal time.Time, timeLayout, eventURL string) string {
+	mins := int(left.Round(time.Minute) / time.Minute)
+	in := "1 minute"
+	if mins != 1 {
+		in = fmt.Sprintf("%d minutes", mins)
+	}
+	msg := fmt.Sprintf("🔴 %s starts in %s (%s).", eventName, in, startLocal.Format(timeLayout+" MST"))
+	if eventURL != "" {
+		msg += " " + eventURL
+	}
+	return msg
+}
+
+// postLiveSoonReminder posts once per event when evt's start is within the
+// guild's live-soon lead. The claim is keyed on the event ID (the event date
+// when the provider has none) before sending and released if the send fails.
+func postLiveSoonReminder(s *discordgo.Session, st *state.Store, g minuteGuild, cfg config.Config, now time.Time, evt *sources.Event) {
+	guildID, org := g.id, g.org
+	if skippingUntilPPV(st, guildID, org, evt.Name) {
+		return
+	}
+	stUTC, err := parseAPITime(evt.Start)
+	if err != nil || !reminderDue(now, stUTC, g.lead, g.lead) {
+		return
+	}
+	channelID := alertChannel(st, guildID)
+	loc, _ := guildLocation(st, cfg, guildID)
+	date := stUTC.In(loc).Format("2006-01-02")
+	key := evt.ID
+	if key == "" {
+		key = date
+	}
+	if st.HasPostedKind(guildID, org, key, state.PostLiveSoon) {
+		return
+	}
+	if err := st.MarkPostedKind(guildID, org, key, state.PostLiveSoon, date); err != nil {
+		logx.Warn("live-soon mark failed; skipping", "guild_id", guildID, "org", org, "err", err)
+		return
+	}
+	eventURL := ""
+	if sevID, _, ok := st.ScheduledEvent(guildID, org, date); ok {
+		eventURL = fmt.Sprintf("https://discord.com/events/%s/%s", guildID, sevID)
+	}
+	msg := formatLiveSoonMessage(safe(evt.Name), stUTC.Sub(now), stUTC.In(loc), dateStyle(st.GetGuildDateFormat(guildID)).Time, eventURL)
+	if _, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); err != nil {
+		logx.Warn("live-soon send failed", "guild_id", guildID, "org", org, "err", err)
+		if err := st.UnmarkPostedKind(guildID, org, key, state.PostLiveSoon); err != nil {
+			logx.Warn("live-soon unmark failed", "guild_id", guildID, "org", org, "err", err)
+		}
+	}
+}
+
+// defaultFightWeekMessage is the fight-week promo used when the guild hasn't set one.
+const defaultFightWeekMessage = "Fight week is here! {event} is {days} away."
+
+// formatFightWeekMessage fills the {event} and {days} placeholders in a fight-week promo.
+func formatFightWeekMessage(tmpl, eventName string, days int) string {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = defaultFightWeekMessage
+	}
+	return strings.NewReplacer("{event}", eventName, "{days}", leadDaysText(days)).Replace(tmpl)
+}
+
+// postFightWeekPromo posts the opt-in fight-week kickoff, with the card embed,
+// when the next event is exactly the configured number of days away (guild
+// timezone), at most once per event.
+func postFightWeekPromo(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, now time.Time) {
+	days := st.GetGuildFightWeekDays(guildID)
+	if days <= 0 || !st.HasGuildOrg(guildID) {
+		return
+	}
+	channelID := alertChannel(st, guildID)
+	if channelID == "" {
+		return
+	}
+	org := st.GetGuildOrg(guildID)
+	_, provider, ctx, ok := providerForGuild(st, mgr, guildID, false)
+	if !ok {
+		return
+	}
+	evt, ok, err := pickNextEvent(ctx, provider)
+	if err != nil || !ok || skippingUntilPPV(st, guildID, org, evt.Name) {
+		return
+	}
+	stUTC, err := parseAPITime(evt.Start)
+	if err != nil {
+		return
+	}
+	loc, tz := guildLocation(st, cfg, guildID)
+	evLocal := stUTC.In(loc)
+	if calendarDaysBetween(now.In(loc), evLocal) != days {
+		return
+	}
+	date := evLocal.Format("2006-01-02")
+	key := state.DedupKey(org, state.PostFightWeek, date)
+	if eventPosted(st, guildID, org, evt.ID, state.PostFightWeek, key) {
+		return
+	}
+	msg := &discordgo.MessageSend{
+		Content:         formatFightWeekMessage(st.GetGuildFightWeekMessage(guildID), safe(evt.Name), days),
+		AllowedMentions: allowedMentions(),
+	}
+	if emb := buildEventEmbed(sources.DisplayOrg(org), tz, loc, evt, embedOptionsForGuild(st, guildID)); emb != nil {
+		msg.Embeds = []*discordgo.MessageEmbed{emb}
+	}
+	claimed := claimPost(st, guildID, key)
+	claimEventPost(st, guildID, org, evt.ID, state.PostFightWeek, date)
+	sent, err := sendChannelMessageComplex(s, channelID, msg)
+	if err != nil {
+		logx.Warn("fight-week promo send failed", "guild_id", guildID, "org", org, "err", err)
+		if claimed {
+			releasePost(st, guildID, key)
+		}
+		releaseEventPost(st, guildID, org, evt.ID, state.PostFightWeek)
+		return
+	}
+	recordPost(st, guildID, key, []*discordgo.Message{sent})
+}
+
+// skippingUntilPPV reports whether the guild's skip-until-ppv mode suppresses
+// notifications for the named event, i.e. the mode is on and it isn't a PPV.
+func skippingUntilPPV(st *state.Store, guildID, org, eventName string) bool {
+	return st.GetGuildSkipUntilPPV(guildID) && !sources.IsPPV(org, eventName)
+}
+
+// claimPost marks a post (a state.DedupKey) as sent before the send itself, so
+// a restart between sending and marking can't post it twice. When the mark
+// can't be saved it returns false and callers send anyway: a missed post is
+// worse than a rare duplicate.
+func claimPost(st *state.Store, guildID, key string) bool {
+	if err := markPostedWithRetry(st, guildID, key); err != nil {
+		logx.Error("mark posted failed; duplicate post possible", "guild_id", guildID, "key", key, "attempts", markPostedAttempts, "err", err)
+		return false
+	}
+	return true
+}
+
+// eventPosted reports whether the post of the kind (a fight-night alert,
+// weigh-in reminder or fight-week promo) for the event was already sent. Posts
+// are deduped per event, so two events on one day each get one. The date mark
+// (key, a state.DedupKey) alone still counts when no event was marked that day:
+// posts from before per-event marks existed, events without an ID, or a failed
+// event mark.
+func eventPosted(st *state.Store, guildID, org, eventID, kind, key string) bool {
+	if eventID != "" && st.HasPostedKind(guildID, org, eventID, kind) {
+		return true
+	}
+	if !st.HasPosted(guildID, key) {
+		return false
+	}
+	_, date := state.SplitDedupKey(key)
+	return eventID == "" || !st.HasPostedKindOn(guildID, org, kind, date)
+}
+
+// claimEventPost marks the post under its event ID alongside the date claim.
+// Failures are logged only; eventPosted falls back to the date mark.
+func claimEventPost(st *state.Store, guildID, org, eventID, kind, date string) {
+	if eventID == "" {
+		return
+	}
+	if err := st.MarkPostedKind(guildID, org, eventID, kind, date); err != nil {
+		logx.Warn("mark event posted failed", "guild_id", guildID, "org", org, "event_id", eventID, "kind", kind, "err", err)
+	}
+}
+
+// releaseEventPost undoes claimEventPost after a failed send.
+func releaseEventPost(st *state.Store, guildID, org, eventID, kind string) {
+	if eventID == "" {
+		return
+	}
+	if err := st.UnmarkPostedKind(guildID, org, eventID, kind); err != nil {
+		logx.Warn("release event post claim failed", "guild_id", guildID, "org", org, "event_id", eventID, "kind", kind, "err", err)
+	}
+}
+
+// releasePost undoes a claim whose send failed so a later run can retry it.
+func releasePost(st *state.Store, guildID, key string) {
+	if err := st.UnmarkPosted(guildID, key); err != nil {
+		logx.Warn("release post claim failed", "guild_id", guildID, "key", key, "err", err)
+	}
+}
+
+// recordPost adds a delivered post to the guild's history (best effort; dedup
+// relies on last_posted, not history).
+func recordPost(st *state.Store, guildID, key string, sent []*discordgo.Message) {
+	messageID := ""
+	if len(sent) > 0 && sent[0] != nil {
+		messageID = sent[0].ID
+	}
+	sport, day := state.SplitDedupKey(key)
+	if err := st.RecordPost(guildID, sport, day, messageID); err != nil {
+		logx.Warn("record post history failed", "guild_id", guildID, "key", key, "err", err)
+	}
+}
+
+// pinNotifierMessage pins the new alert and unpins the one the bot pinned for the
+// previous event. Failures (missing Manage Messages, the 50-pin limit) are logged
+// and otherwise ignored; the alert itself has already been delivered.
+func pinNotifierMessage(s *discordgo.Session, st *state.Store, guildID, channelID, messageID string) {
+	// Unpin first so a full channel has room for the new pin.
+	if prevCh, prevMsg := st.GetGuildPinnedMessage(guildID); prevMsg != "" && prevMsg != messageID {
+		if err := unpinChannelMessage(s, prevCh, prevMsg); err != nil {
+			logx.Warn("unpin previous alert failed", "guild_id", guildID, "channel_id", prevCh, "message_id", prevMsg, "err", err)
+		}
+		st.UpdateGuildPinnedMessage(guildID, "", "")
+	}
+	if err := pinChannelMessage(s, channelID, messageID); err != nil {
+		var restErr *discordgo.RESTError
+		reason := "error"
+		if errors.As(err, &restErr) && restErr.Message != nil {
+			switch restErr.Message.Code {
+			case discordgo.ErrCodeMaximumPinsReached:
+				reason = "pin limit reached"
+			case discordgo.ErrCodeMissingPermissions:
+				reason = "missing Manage Messages permission"
+			}
+		}
+		logx.Warn("pin alert failed", "guild_id", guildID, "channel_id", channelID, "message_id", messageID, "reason", reason, "err", err)
+		return
+	}
+	st.UpdateGuildPinnedMessage(guildID, channelID, messageID)
+}
+
+// markPostedWithRetry retries MarkPosted a few times to ride out transient DB errors
+// (e.g., SQLITE_BUSY) and returns the last error when every attempt fails.
+func markPostedWithRetry(st *state.Store, guildID, key string) error {
+	var err error
+	for i := 0; i < markPostedAttempts; i++ {
+		if i > 0 {
+			time.Sleep(markPostedRetryDelay)
+		}
+		if err = markPostedFunc(st, guildID, key); err == nil {
+			return nil
+		}
+		logx.Warn("mark posted attempt failed", "guild_id", guildID, "key", key, "attempt", i+1, "err", err)
+	}
+	return err
+}
+
+// ensureTomorrowScheduledEvent creates a Discord Scheduled Event once the next
+// event is within the guild's lead time (default: the day before, based on guild
+// timezone) if not already created. clock supplies the current time.
+func ensureTomorrowScheduledEvent(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, clock func() time.Time) {
+	ensureScheduledEvent(s, st, guildID, mgr, cfg, clock, false)
+}
+
+// ensureSameDayScheduledEvent is the check run on the hours between daily runs:
+// it only creates a scheduled event for an event later today that has none yet,
+// honoring cfg.SameDayEventGrace.
+func ensureSameDayScheduledEvent(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, clock func() time.Time) {
+	ensureScheduledEvent(s, st, guildID, mgr, cfg, clock, true)
+}
+
+func ensureScheduledEvent(s *discordgo.Session, st *state.Store, guildID string, mgr *sources.Manager, cfg config.Config, clock func() time.Time, sameDayOnly bool) {
 	// Require org and events toggle enabled to avoid surprising behavior.
 	if !st.GetGuildEventsEnabled(guildID) || !st.HasGuildOrg(guildID) {
 		return
 	}
 	org := st.GetGuildOrg(guildID)
+	// Per-org opt-out on top of the guild-wide toggle
+	if st.GetGuildOrgEventsExcluded(guildID, org) {
+		return
+	}
 	loc, _ := guildLocation(st, cfg, guildID)
-	nowLocal := time.Now().In(loc)
+	nowLocal := clock().In(loc)
 	_, provider, ctx, ok := providerForGuild(st, mgr, guildID, false)
 	if !ok {
 		return
 	}
-	// We want to create the event exactly on the day before the event (at the guild's run hour).
-	// So: find the next upcoming event, get its local date, and only create if today == eventDate - 1 day.
+	// Create between the lead-days mark and the day before the event (at the guild's run
+	// hour). With the default lead of 1 that is exactly the day before. An event first
+	// seen on its own day is created then, as long as it starts far enough ahead.
 
 	// Use the same next-event selection logic as the command.
 	evt, ok, err := pickNextEvent(ctx, provider)
-	if err != nil || !ok {
+	if err != nil || !ok || skippingUntilPPV(st, guildID, org, evt.Name) {
 		return
 	}
 	stUTC, err := parseAPITime(evt.Start)
@@ -196,40 +982,325 @@ func ensureTomorrowScheduledEvent(s *discordgo.Session, st *state.Store, guildID
 	}
 	evLocal := stUTC.In(loc)
 	evDateKey := evLocal.Format("2006-01-02")
-	// Only create on the day before the event
-	if nowLocal.Format("2006-01-02") != evLocal.AddDate(0, 0, -1).Format("2006-01-02") {
+	d := calendarDaysBetween(nowLocal, evLocal)
+	if sameDayOnly {
+		// Between daily runs, only a not-yet-created event for today is handled;
+		// recreation and moved-event cleanup stay with the daily run.
+		if d != 0 {
+			return
+		}
+		if _, _, tracked := st.ScheduledEvent(guildID, org, evDateKey); tracked {
+			return
+		}
+	} else {
+		removeMovedScheduledEvents(s, st, guildID, org, nowLocal.AddDate(0, 0, 1).Format("2006-01-02"), evDateKey)
+	}
+	switch {
+	case d == 0:
+		// Short notice: the event wasn't listed in time for the day-before run.
+		// Still create it today unless it starts too soon (or already started).
+		grace := cfg.SameDayEventGrace
+		if grace <= 0 {
+			grace = config.DefaultSameDayEventGrace
+		}
+		if stUTC.Sub(nowLocal) < grace {
+			return
+		}
+	case d < 0 || d > st.GetGuildScheduledEventLeadDays(guildID):
 		return
 	}
-	// Skip if already created for this event date
-	if st.HasScheduledEvent(guildID, org, evDateKey) {
+	// Skip if already created for this event date, unless an admin deleted it
+	// (recreated at most scheduledEventRecreateLimit times).
+	eventID, recreated, tracked := st.ScheduledEvent(guildID, org, evDateKey)
+	if tracked {
+		_, err := fetchGuildScheduledEvent(s, guildID, eventID)
+		if !shouldRecreateScheduledEvent(err, recreated) {
+			return
+		}
+		logx.Info("scheduled event deleted; recreating", "guild_id", guildID, "org", org, "event_id", eventID, "date", evDateKey)
+	}
+
+	// Create an EXTERNAL scheduled event at the event start time so Discord's native
+	// "starting soon" notifications fire for users who RSVP.
+	params := scheduledEventParams(org, st.GetGuildEventNamePrefix(guildID), evt, stUTC.In(loc), "Auto-created by Fight Night bot")
+	if err := validateScheduledEventParams(params, nowLocal); err != nil {
+		logx.Warn("scheduled event params invalid", "guild_id", guildID, "org", org, "err", err)
 		return
 	}
+	// Manage Events permission is required for the bot; if missing, this will fail.
+	sev, err := createGuildScheduledEvent(s, guildID, params)
+	if err != nil {
+		logx.Warn("scheduled event create failed", "guild_id", guildID, "org", org, "err", err)
+		recordScheduledEventFailure(s, st, guildID, cfg, err)
+		return
+	}
+	st.ResetGuildEventFailures(guildID)
+	// Mark by the actual event date to avoid duplicates for the same event
+	if tracked {
+		st.MarkScheduledEventRecreated(guildID, org, evDateKey, sev.ID)
+	} else {
+		st.MarkScheduledEvent(guildID, org, evDateKey, sev.ID)
+	}
+	announceScheduledEvent(s, st, guildID, evt, sev.ID)
+}
+
+// removeMovedScheduledEvents deletes bot-created scheduled events dated from
+// fromDate up to (not including) nextDate, the date of the next listed event:
+// the card each was created for is no longer listed then, so it was postponed
+// or cancelled. Forgetting the record keeps the notice to once per change, and
+// a postponed card gets a fresh event once it is within the lead time again.
+func removeMovedScheduledEvents(s *discordgo.Session, st *state.Store, guildID, org, fromDate, nextDate string) {
+	for date, eventID := range st.ScheduledEventDates(guildID, org, fromDate) {
+		if date >= nextDate {
+			continue
+		}
+		name := ""
+		if sev, err := fetchGuildScheduledEvent(s, guildID, eventID); err == nil && sev != nil {
+			name = sev.Name
+		}
+		if err := deleteGuildScheduledEvent(s, guildID, eventID); err != nil && !scheduledEventGone(err) {
+			logx.Warn("scheduled event delete failed", "guild_id", guildID, "org", org, "event_id", eventID, "err", err)
+		}
+		st.ForgetScheduledEvent(guildID, org, date)
+		logx.Info("scheduled event no longer listed; removed", "guild_id", guildID, "org", org, "event_id", eventID, "date", date, "next_date", nextDate)
+		postPostponeNotice(s, st, guildID, org, name, date)
+	}
+}
+
+// postPostponeNotice tells the alert channel that an event the bot had put on
+// the server calendar is no longer listed for its date, when the guild opted in
+// with /settings extra-posts postpone-notice.
+func postPostponeNotice(s *discordgo.Session, st *state.Store, guildID, org, name, date string) {
+	if !st.GetGuildPostponeNotice(guildID) {
+		return
+	}
+	channelID := alertChannel(st, guildID)
+	if channelID == "" {
+		return
+	}
+	if name == "" {
+		name = "The " + strings.ToUpper(org) + " event"
+	}
+	when := date
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		when = d.Format(dateStyle(st.GetGuildDateFormat(guildID)).Date)
+	}
+	msg := fmt.Sprintf("⚠️ %s has been postponed or cancelled; it is no longer listed for %s.", safe(name), when)
+	if _, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); err != nil {
+		logx.Warn("postpone notice send failed", "guild_id", guildID, "channel_id", channelID, "err", err)
+	}
+}
+
+// scheduledEventRecreateLimit bounds how often a deleted bot-created event is
+// recreated for the same date; deleting it again means the admin meant it.
+const scheduledEventRecreateLimit = 1
+
+// shouldRecreateScheduledEvent reports whether a tracked scheduled event should
+// be recreated, given the error from looking it up and how often it already
+// was. Only an event Discord reports as gone qualifies: other lookup failures
+// (network, permissions) leave it alone, as do canceled events, which still
+// exist.
+func shouldRecreateScheduledEvent(lookupErr error, recreated int) bool {
+	return recreated < scheduledEventRecreateLimit && scheduledEventGone(lookupErr)
+}
+
+// scheduledEventGone reports whether a scheduled event lookup failed because
+// the event no longer exists.
+func scheduledEventGone(lookupErr error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(lookupErr, &restErr) {
+		return false
+	}
+	if restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownGuildScheduledEvent {
+		return true
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+// alertChannel returns where fight-night alerts, weigh-in reminders and
+// fight-week promos go: the alert override when set, else the main channel.
+func alertChannel(st *state.Store, guildID string) string {
+	if ch := st.GetGuildAlertChannel(guildID); ch != "" {
+		return ch
+	}
+	ch, _, _ := st.GetGuildSettings(guildID)
+	return ch
+}
+
+// eventChannel returns where scheduled-event links are announced: the event
+// override when set, else the main channel.
+func eventChannel(st *state.Store, guildID string) string {
+	if ch := st.GetGuildEventChannel(guildID); ch != "" {
+		return ch
+	}
+	ch, _, _ := st.GetGuildSettings(guildID)
+	return ch
+}
+
+// announceScheduledEvent posts a link to a newly created scheduled event so
+// members can RSVP. Failures are logged only; the event itself already exists.
+func announceScheduledEvent(s *discordgo.Session, st *state.Store, guildID string, evt *sources.Event, eventID string) {
+	channelID := eventChannel(st, guildID)
+	if channelID == "" {
+		return
+	}
+	msg := fmt.Sprintf("%s is on the server calendar. RSVP to get Discord's reminder when it starts: https://discord.com/events/%s/%s", safe(evt.Name), guildID, eventID)
+	if _, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); err != nil {
+		logx.Warn("scheduled event announce failed", "guild_id", guildID, "channel_id", channelID, "event_id", eventID, "err", err)
+	}
+}
+
+// recordScheduledEventFailure counts a failed creation and, once the guild hits
+// cfg.EventFailureLimit in a row, turns scheduled events off and tells the
+// server why in its notification channel rather than failing quietly each day.
+func recordScheduledEventFailure(s *discordgo.Session, st *state.Store, guildID string, cfg config.Config, err error) {
+	limit := cfg.EventFailureLimit
+	if limit <= 0 {
+		limit = config.DefaultEventFailureLimit
+	}
+	n := st.RecordGuildEventFailure(guildID)
+	if n < limit {
+		return
+	}
+	st.UpdateGuildEventsEnabled(guildID, false)
+	st.ResetGuildEventFailures(guildID)
+	reason := scheduledEventFailureReason(err)
+	logx.Warn("scheduled events disabled after repeated failures", "guild_id", guildID, "failures", n, "reason", reason)
+	channelID, _, _ := st.GetGuildSettings(guildID)
+	if channelID == "" {
+		return
+	}
+	msg := fmt.Sprintf("Scheduled events have been turned off after %d failed attempts to create one (%s). Fix the issue, then turn them back on with /settings events state:on.", n, reason)
+	if _, sendErr := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); sendErr != nil {
+		logx.Warn("scheduled event failure notice send failed", "guild_id", guildID, "channel_id", channelID, "err", sendErr)
+	}
+}
+
+// scheduledEventFailureReason turns a scheduled event creation error into a
+// short reason an admin can act on.
+func scheduledEventFailureReason(err error) string {
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Message != nil {
+		switch restErr.Message.Code {
+		case discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeMissingAccess:
+			return "the bot is missing the Manage Events permission"
+		}
+		if restErr.Message.Message != "" {
+			return restErr.Message.Message
+		}
+	}
+	return "Discord rejected the request"
+}
 
-	// Create an EXTERNAL scheduled event at the event start time; end time = +3h.
-	start := stUTC.In(loc)
+// calendarDaysBetween returns the number of calendar days from a to b, using the
+// date each has in its own location (b before a gives a negative count).
+func calendarDaysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// Discord limits for scheduled event fields.
+const (
+	scheduledEventNameMax     = 100
+	scheduledEventDescMax     = 1000
+	scheduledEventLocationMax = 100
+)
+
+// scheduledEventParams builds the Discord scheduled event for an org event. The
+// name is the guild's prefix (default "ORG:") and the event name; the
+// description summarizes the card (main event, bout count, event page) when card
+// data is available; the location points at the event page when it fits.
+func scheduledEventParams(org, namePrefix string, evt *sources.Event, start time.Time, footer string) *discordgo.GuildScheduledEventParams {
 	end := start.Add(3 * time.Hour)
-	// Manage Events permission is required for the bot; if missing, this will fail.
-	params := &discordgo.GuildScheduledEventParams{
-		Name:               strings.ToUpper(org) + ": " + evt.Name,
-		Description:        "Auto-created by Fight Night bot",
+	if t, err := parseAPITime(evt.End); err == nil && t.After(start) {
+		end = t.In(start.Location())
+	}
+
+	var desc []string
+	if main, ok := mainEventBout(evt); ok && safe(main.RedName) != "" && safe(main.BlueName) != "" {
+		line := fmt.Sprintf("Main event: %s vs %s", safe(main.RedName), safe(main.BlueName))
+		if wc := strings.TrimSpace(main.WeightClass); wc != "" {
+			line += " (" + wc + ")"
+		}
+		desc = append(desc, line)
+		if n := len(evt.Bouts); n > 1 {
+			desc = append(desc, fmt.Sprintf("Full card: %d bouts", n))
+		}
+	} else {
+		desc = append(desc, "Fight card to be announced.")
+	}
+	url := primaryEventURL(evt, "")
+	if url != "" {
+		desc = append(desc, "Event page: "+url)
+	}
+	if footer != "" {
+		desc = append(desc, footer)
+	}
+
+	location := sources.DisplayOrg(org) + " — see event page"
+	if url != "" && len(url) <= scheduledEventLocationMax {
+		location = url
+	}
+	return &discordgo.GuildScheduledEventParams{
+		Name:               truncateRunes(scheduledEventName(org, namePrefix, evt.Name), scheduledEventNameMax),
+		Description:        truncateRunes(strings.Join(desc, "\n"), scheduledEventDescMax),
 		ScheduledStartTime: &start,
 		ScheduledEndTime:   &end,
 		PrivacyLevel:       discordgo.GuildScheduledEventPrivacyLevelGuildOnly,
 		EntityType:         discordgo.GuildScheduledEventEntityTypeExternal,
-		EntityMetadata:     &discordgo.GuildScheduledEventEntityMetadata{Location: "TBD"},
+		EntityMetadata:     &discordgo.GuildScheduledEventEntityMetadata{Location: location},
 	}
-	sev, err := s.GuildScheduledEventCreate(guildID, params)
-	if err != nil {
-		logx.Warn("scheduled event create failed", "guild_id", guildID, "org", org, "err", err)
-		return
+}
+
+// scheduledEventName joins the name prefix (the org, e.g. "UFC:", when empty)
+// and the event name.
+func scheduledEventName(org, prefix, name string) string {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		prefix = sources.DisplayOrg(org) + ":"
 	}
-	// Mark by the actual event date to avoid duplicates for the same event
-	st.MarkScheduledEvent(guildID, org, evDateKey, sev.ID)
+	return prefix + " " + strings.TrimSpace(name)
+}
+
+// validateScheduledEventParams checks what Discord would otherwise reject: the
+// start must be in the future and precede the end.
+func validateScheduledEventParams(p *discordgo.GuildScheduledEventParams, now time.Time) error {
+	if p.ScheduledStartTime == nil || !p.ScheduledStartTime.After(now) {
+		return fmt.Errorf("start time must be in the future")
+	}
+	if p.ScheduledEndTime != nil && !p.ScheduledEndTime.After(*p.ScheduledStartTime) {
+		return fmt.Errorf("end time must be after start time")
+	}
+	return nil
 }
 
-func buildMessage(org string, events []sources.Event, loc *time.Location) string {
+// truncateRunes shortens s to at most n characters, marking the cut with "…".
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// alertTrailer closes a fight-night alert when the guild turns the trailer on.
+const alertTrailer = "Enjoy the fights!"
+
+// buildMessage renders the alert text: an optional header, one line per event,
+// and an optional trailer, per the guild's alert sections. A non-empty tmpl
+// replaces all three (see expandMessageTemplate); a template that renders
+// blank falls back to the built-in format.
+func buildMessage(org string, events []sources.Event, loc *time.Location, sections state.AlertSections, tmpl string) string {
+	if tmpl != "" {
+		if msg := expandMessageTemplate(tmpl, org, events, loc); strings.TrimSpace(msg) != "" {
+			return msg
+		}
+	}
 	var b strings.Builder
-	b.WriteString(strings.ToUpper(org) + " Fight Night Alert:\n")
+	if sections.Header {
+		b.WriteString(sources.DisplayOrg(org) + " Fight Night Alert:\n")
+	}
 	for _, e := range events {
 		name := e.Name
 		if name == "" {
@@ -246,9 +1317,37 @@ func buildMessage(org string, events []sources.Event, loc *time.Location) string
 			fmt.Fprintf(&b, "• %s\n", name)
 		}
 	}
+	if sections.Trailer {
+		b.WriteString(alertTrailer + "\n")
+	}
 	return b.String()
 }
 
+// expandMessageTemplate fills a guild's alert template: {org} is the org's
+// display name, {event} the event names (comma-separated), {time} the first
+// event's start in loc, and {count} the number of events. Unknown
+// placeholders are left as written.
+func expandMessageTemplate(tmpl, org string, events []sources.Event, loc *time.Location) string {
+	names := make([]string, 0, len(events))
+	tstr := ""
+	for _, e := range events {
+		name := e.Name
+		if name == "" {
+			name = e.ShortName
+		}
+		names = append(names, name)
+		if t, err := parseAPITime(e.Start); err == nil && tstr == "" {
+			tstr = t.In(loc).Format("Mon 3:04 PM")
+		}
+	}
+	return strings.NewReplacer(
+		"{org}", sources.DisplayOrg(org),
+		"{event}", strings.Join(names, ", "),
+		"{time}", tstr,
+		"{count}", strconv.Itoa(len(events)),
+	).Replace(tmpl)
+}
+
 func parseHHMM(s string) (int, int, error) {
 	parts := strings.Split(s, ":")
 	if len(parts) != 2 {
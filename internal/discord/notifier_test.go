@@ -2,16 +2,41 @@ package discord
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/metrics"
 	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
+// TestMain points channel lookups at a text channel, reports tracked scheduled
+// events as still existing and accepts their deletion, since a bare Session
+// cannot reach Discord; tests that care override fetchChannel,
+// fetchGuildScheduledEvent or deleteGuildScheduledEvent.
+func TestMain(m *testing.M) {
+	fetchChannel = func(_ *discordgo.Session, channelID string) (*discordgo.Channel, error) {
+		return &discordgo.Channel{ID: channelID, Type: discordgo.ChannelTypeGuildText}, nil
+	}
+	fetchGuildScheduledEvent = func(_ *discordgo.Session, guildID, eventID string) (*discordgo.GuildScheduledEvent, error) {
+		return &discordgo.GuildScheduledEvent{ID: eventID, GuildID: guildID, Status: discordgo.GuildScheduledEventStatusScheduled}, nil
+	}
+	deleteGuildScheduledEvent = func(*discordgo.Session, string, string) error { return nil }
+	os.Exit(m.Run())
+}
+
 // fakeProv implements sources.Provider for tests.
 type fakeProv struct {
 	name string
@@ -19,6 +44,13 @@ type fakeProv struct {
 	ok   bool
 }
 
+// cardProv is a fakeProv that declares card support, like the UFC provider.
+type cardProv struct{ fakeProv }
+
+func (*cardProv) Capabilities() sources.Capabilities {
+	return sources.Capabilities{HasCards: true}
+}
+
 func (f *fakeProv) NextEvent(_ context.Context) (*sources.Event, bool, error) {
 	if !f.ok {
 		return nil, false, nil
@@ -26,6 +58,10 @@ func (f *fakeProv) NextEvent(_ context.Context) (*sources.Event, bool, error) {
 	return &sources.Event{Org: "ufc", Name: f.name, Start: f.at.UTC().Format(time.RFC3339)}, true, nil
 }
 
+func (f *fakeProv) LastEvent(_ context.Context) (*sources.Event, bool, error) {
+	return nil, false, nil
+}
+
 func TestParseHHMM(t *testing.T) {
 	h, m, err := parseHHMM("16:00")
 	if err != nil || h != 16 || m != 0 {
@@ -42,13 +78,79 @@ func TestParseHHMM(t *testing.T) {
 	}
 }
 
+func TestNextRunAt(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	kol, _ := time.LoadLocation("Asia/Kolkata")
+	tests := []struct {
+		name string
+		now  time.Time
+		loc  *time.Location
+		hour int
+		want time.Time
+	}{
+		{"later today", time.Date(2024, 4, 13, 9, 15, 0, 0, ny), ny, 16, time.Date(2024, 4, 13, 16, 0, 0, 0, ny)},
+		{"already passed today", time.Date(2024, 4, 13, 17, 0, 0, 0, ny), ny, 16, time.Date(2024, 4, 14, 16, 0, 0, 0, ny)},
+		{"exactly on the hour rolls to tomorrow", time.Date(2024, 4, 13, 16, 0, 0, 0, ny), ny, 16, time.Date(2024, 4, 14, 16, 0, 0, 0, ny)},
+		{"across DST start", time.Date(2024, 3, 9, 20, 0, 0, 0, ny), ny, 16, time.Date(2024, 3, 10, 16, 0, 0, 0, ny)},
+		{"half-hour offset lands mid-hour", time.Date(2024, 4, 13, 9, 0, 0, 0, kol), kol, 16, time.Date(2024, 4, 13, 16, 30, 0, 0, kol)},
+	}
+	for _, tc := range tests {
+		got := nextRunAt(tc.now, tc.loc, tc.hour)
+		if !got.Equal(tc.want) {
+			t.Fatalf("%s: got %v want %v", tc.name, got.In(tc.loc), tc.want)
+		}
+	}
+}
+
+func TestRunHourText_StatesUTCEquivalent(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	kol, _ := time.LoadLocation("Asia/Kolkata")
+	tests := []struct {
+		name string
+		now  time.Time
+		loc  *time.Location
+		tz   string
+		want string
+	}{
+		{"daylight time", time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC), ny, "America/New_York", "I'll check daily at 16:00 America/New_York, which is 20:00 UTC."},
+		{"standard time", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), ny, "America/New_York", "I'll check daily at 16:00 America/New_York, which is 21:00 UTC."},
+		{"utc needs no conversion", time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC), time.UTC, "UTC", "I'll check daily at 16:00 UTC."},
+		{"half-hour offset", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), kol, "Asia/Kolkata", "I'll check daily at 16:30 Asia/Kolkata, which is 11:00 UTC."},
+	}
+	for _, tc := range tests {
+		if got := runHourText(16, tc.loc, tc.tz, tc.now); got != tc.want {
+			t.Fatalf("%s: got %q want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestShouldRunNow_RunTimeReference(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "America/New_York")
+	st.UpdateGuildRunHour(gid, 16)
+	cfg := config.Config{RunAt: "16:00", TZ: "UTC"}
+
+	// 2024-07-01 16:00 in New York is 20:00 UTC.
+	ny := time.Date(2024, 7, 1, 20, 0, 0, 0, time.UTC)
+	utc := time.Date(2024, 7, 1, 16, 0, 0, 0, time.UTC)
+
+	if !shouldRunNow(st, gid, cfg, ny) || shouldRunNow(st, gid, cfg, utc) {
+		t.Fatalf("local reference should run at 16:00 guild time only")
+	}
+	st.UpdateGuildRunTimeReference(gid, runTimeUTC)
+	if !shouldRunNow(st, gid, cfg, utc) || shouldRunNow(st, gid, cfg, ny) {
+		t.Fatalf("utc reference should run at 16:00 UTC only")
+	}
+}
+
 func TestBuildMessage_FormatsHeaderAndLines(t *testing.T) {
 	loc := time.UTC
 	evs := []sources.Event{
 		{Name: "Event A", Start: "2025-01-02T15:04:00Z"},
 		{ShortName: "Event B", Start: "2025-01-02T18:30:00Z"},
 	}
-	msg := buildMessage("ufc", evs, loc)
+	msg := buildMessage("ufc", evs, loc, state.DefaultAlertSections, "")
 	if !strings.HasPrefix(msg, "UFC Fight Night Alert:\n") {
 		t.Fatalf("missing/incorrect header: %q", msg)
 	}
@@ -58,7 +160,123 @@ func TestBuildMessage_FormatsHeaderAndLines(t *testing.T) {
 	if !strings.Contains(msg, "• Event B — Thu 6:30 PM") {
 		t.Fatalf("missing second line with time, got: %q", msg)
 	}
-	// Trailer text removed by design; only header and lines are required.
+	// The trailer is off by default.
+	if strings.Contains(msg, alertTrailer) {
+		t.Fatalf("unexpected trailer by default: %q", msg)
+	}
+}
+
+func TestBuildMessage_Template(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	evs := []sources.Event{
+		{Name: "UFC 300", Start: "2025-04-13T22:00:00Z"},
+		{ShortName: "Prelims", Start: "2025-04-14T00:00:00Z"},
+	}
+	msg := buildMessage("ufc", evs, loc, state.DefaultAlertSections, "{org} tonight: {event} at {time} ({count} events) {unknown}")
+	want := "UFC tonight: UFC 300, Prelims at Sun 6:00 PM (2 events) {unknown}"
+	if msg != want {
+		t.Fatalf("template: got %q want %q", msg, want)
+	}
+
+	// A blank template, or one that renders blank, uses the built-in format.
+	def := buildMessage("ufc", evs, loc, state.DefaultAlertSections, "")
+	if !strings.HasPrefix(def, "UFC Fight Night Alert:\n") {
+		t.Fatalf("expected default format, got %q", def)
+	}
+	if got := buildMessage("ufc", []sources.Event{{Name: "UFC 300"}}, loc, state.DefaultAlertSections, "  {time} "); got != buildMessage("ufc", []sources.Event{{Name: "UFC 300"}}, loc, state.DefaultAlertSections, "") {
+		t.Fatalf("expected blank render to fall back, got %q", got)
+	}
+}
+
+func TestNotifyGuildCore_AlertSections(t *testing.T) {
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+	var sends []*discordgo.MessageSend
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends = append(sends, m)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	for _, header := range []bool{true, false} {
+		for _, trailer := range []bool{true, false} {
+			for _, embed := range []bool{true, false} {
+				sections := state.AlertSections{Header: header, Trailer: trailer, Embed: embed}
+				st := state.Load(":memory:")
+				gid := "g1"
+				st.UpdateGuildChannel(gid, "chan1")
+				st.UpdateGuildTZ(gid, "UTC")
+				st.UpdateGuildOrg(gid, "ufc")
+				st.UpdateGuildNotifyEnabled(gid, true)
+				st.UpdateGuildAlertSections(gid, sections)
+				sends = nil
+
+				if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), false, ""); !posted {
+					t.Fatalf("%+v: expected post, got %q", sections, reason)
+				}
+				if len(sends) != 1 {
+					t.Fatalf("%+v: expected one message, got %d", sections, len(sends))
+				}
+				m := sends[0]
+				if got := strings.Contains(m.Content, "UFC Fight Night Alert:"); got != header {
+					t.Fatalf("%+v: header present=%v in %q", sections, got, m.Content)
+				}
+				if got := strings.Contains(m.Content, alertTrailer); got != trailer {
+					t.Fatalf("%+v: trailer present=%v in %q", sections, got, m.Content)
+				}
+				if got := len(m.Embeds) > 0; got != embed {
+					t.Fatalf("%+v: embed present=%v", sections, got)
+				}
+				if !strings.Contains(m.Content, "• UFC 300") {
+					t.Fatalf("%+v: expected the event line always, got %q", sections, m.Content)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitForDiscord(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n" // 100 chars per line
+
+	under := strings.Repeat(line, 19) + strings.Repeat("y", 99) // 1999
+	if got := splitForDiscord(under); len(got) != 1 || got[0] != under {
+		t.Fatalf("just under: expected one unchanged chunk, got %d", len(got))
+	}
+
+	at := strings.Repeat(line, 20) // exactly 2000
+	if got := splitForDiscord(at); len(got) != 1 || got[0] != at {
+		t.Fatalf("at limit: expected one unchanged chunk, got %d", len(got))
+	}
+
+	over := strings.Repeat(line, 25) // 2500
+	got := splitForDiscord(over)
+	if len(got) != 2 {
+		t.Fatalf("over: expected 2 chunks, got %d", len(got))
+	}
+	if strings.Join(got, "") != over {
+		t.Fatalf("over: chunks do not reassemble to the original content")
+	}
+	for i, c := range got {
+		if len([]rune(c)) > discordMessageLimit {
+			t.Fatalf("over: chunk %d has %d chars", i, len([]rune(c)))
+		}
+		if !strings.HasSuffix(c, "\n") {
+			t.Fatalf("over: chunk %d not split on a line boundary", i)
+		}
+	}
+
+	// A single line longer than the limit is hard-split by characters (not bytes).
+	long := strings.Repeat("é", 4500)
+	got = splitForDiscord(long)
+	if len(got) != 3 || len([]rune(got[0])) != discordMessageLimit || len([]rune(got[2])) != 500 {
+		t.Fatalf("long line: unexpected chunks %d", len(got))
+	}
 }
 
 func TestNotifyGuild_SendsAndMarksPosted(t *testing.T) {
@@ -101,7 +319,7 @@ func TestNotifyGuild_SendsAndMarksPosted(t *testing.T) {
 	// Run
 	s := &discordgo.Session{}
 	cfg := config.Config{TZ: "UTC"}
-	notifyGuild(s, st, gid, mgr, cfg)
+	notifyGuild(s, st, gid, mgr, cfg, time.Now())
 
 	if sent != 1 || !strings.Contains(lastMsg, "UFC Fight Night Alert:") || !strings.Contains(lastMsg, "Test Event") {
 		t.Fatalf("expected one send with content, got sent=%d msg=%q", sent, lastMsg)
@@ -113,41 +331,1952 @@ func TestNotifyGuild_SendsAndMarksPosted(t *testing.T) {
 	}
 
 	// Second call should not send again
-	notifyGuild(s, st, gid, mgr, cfg)
+	notifyGuild(s, st, gid, mgr, cfg, time.Now())
 	if sent != 1 {
 		t.Fatalf("expected no second send, got sent=%d", sent)
 	}
 }
 
-func TestNotifyGuild_SkipsWhenNoOrgOrDisabled(t *testing.T) {
+func TestNotifyGuildCore_SurfacesMarkPostedFailure(t *testing.T) {
 	st := state.Load(":memory:")
-	gid := "g2"
+	gid := "g1"
 	st.UpdateGuildChannel(gid, "chan1")
-	// No org set, notify off by default
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	now := time.Now().UTC()
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "Test Event", Start: now.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{ok: true, name: "Test Event", at: now})
+
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		return &discordgo.Message{}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	// Fail every MarkPosted attempt
+	attempts := 0
+	oldMark, oldDelay := markPostedFunc, markPostedRetryDelay
+	markPostedFunc = func(_ *state.Store, _, _ string) error {
+		attempts++
+		return errors.New("database is locked")
+	}
+	markPostedRetryDelay = 0
+	defer func() { markPostedFunc, markPostedRetryDelay = oldMark, oldDelay }()
+
+	posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), false, "")
+	if !posted {
+		t.Fatalf("expected message to be reported as posted")
+	}
+	if reason == "OK" || !strings.Contains(reason, "failed to record") {
+		t.Fatalf("expected mark failure to be surfaced in reason, got %q", reason)
+	}
+	if attempts != markPostedAttempts {
+		t.Fatalf("expected %d attempts, got %d", markPostedAttempts, attempts)
+	}
+}
+
+func TestPostDedup_SurvivesRestart(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name  string
+		kind  string
+		ahead time.Duration
+		setup func(st *state.Store, gid string)
+		post  func(s *discordgo.Session, st *state.Store, gid string, mgr *sources.Manager, cfg config.Config, now time.Time)
+	}{
+		{"alert", state.PostAlert, 0, func(st *state.Store, gid string) { st.UpdateGuildNotifyEnabled(gid, true) }, notifyGuild},
+		{"weigh-in", state.PostWeighIn, day, func(st *state.Store, gid string) { st.UpdateGuildWeighInEnabled(gid, true) }, postWeighInReminder},
+		{"fight-week", state.PostFightWeek, 5 * day, func(st *state.Store, gid string) { st.UpdateGuildFightWeekDays(gid, 5) }, postFightWeekPromo},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.db")
+			gid := "g1"
+			st := state.Load(path)
+			st.UpdateGuildChannel(gid, "chan1")
+			st.UpdateGuildTZ(gid, "UTC")
+			st.UpdateGuildOrg(gid, "ufc")
+			tc.setup(st, gid)
+
+			start := time.Now().UTC().Add(tc.ahead)
+			key := state.DedupKey("ufc", tc.kind, start.Format("2006-01-02"))
+			oldGet := getNextEventFunc
+			getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+				return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+			}
+			defer func() { getNextEventFunc = oldGet }()
+			mgr := sources.NewManager()
+			mgr.Register("ufc", &fakeProv{})
+
+			sends := 0
+			oldSend := sendChannelMessageComplex
+			sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+				sends++
+				// A process restarting right now must already see the post as done.
+				if !state.Load(path).HasPosted(gid, key) {
+					t.Errorf("%s not marked before it was sent", key)
+				}
+				return &discordgo.Message{ID: "m1"}, nil
+			}
+			defer func() { sendChannelMessageComplex = oldSend }()
+
+			s := &discordgo.Session{}
+			cfg := config.Config{TZ: "UTC"}
+			tc.post(s, st, gid, mgr, cfg, time.Now())
+			if sends != 1 {
+				t.Fatalf("expected one send, got %d", sends)
+			}
+			// Restart: a fresh store over the same file must not post again.
+			tc.post(s, state.Load(path), gid, mgr, cfg, time.Now())
+			if sends != 1 {
+				t.Fatalf("expected no re-send after restart, got %d sends", sends)
+			}
+		})
+	}
+}
+
+func TestPostDedup_ReleasedWhenSendFails(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildWeighInEnabled(gid, true)
 
+	start := time.Now().UTC().Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
 	mgr := sources.NewManager()
 	mgr.Register("ufc", &fakeProv{})
 
-	sent := 0
-	old := sendChannelMessageComplex
+	sendErr := errors.New("503 Service Unavailable")
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		if sendErr != nil {
+			return nil, sendErr
+		}
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	key := state.DedupKey("ufc", state.PostWeighIn, start.Format("2006-01-02"))
+	postWeighInReminder(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now())
+	if st.HasPosted(gid, key) {
+		t.Fatalf("expected the claim released after a failed send")
+	}
+	sendErr = nil
+	postWeighInReminder(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now())
+	if !st.HasPosted(gid, key) {
+		t.Fatalf("expected the retry to mark the reminder posted")
+	}
+}
+
+func TestMarkPostedWithRetry_RecoversFromTransientFailure(t *testing.T) {
+	st := state.Load(":memory:")
+	attempts := 0
+	oldMark, oldDelay := markPostedFunc, markPostedRetryDelay
+	markPostedFunc = func(st *state.Store, gid, key string) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("database is locked")
+		}
+		return st.MarkPosted(gid, key)
+	}
+	markPostedRetryDelay = 0
+	defer func() { markPostedFunc, markPostedRetryDelay = oldMark, oldDelay }()
+
+	if err := markPostedWithRetry(st, "g1", state.DedupKey("ufc", state.PostAlert, "2025-01-02")); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if _, _, last := st.GetGuildSettings("g1"); last["ufc"] != "2025-01-02" {
+		t.Fatalf("expected mark persisted after retry, got %q", last["ufc"])
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_RespectsPerOrgExclusion(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: tomorrow.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: "sev1", Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+
+	st.UpdateGuildOrgEventsExcluded(gid, "ufc", true)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 0 {
+		t.Fatalf("expected no scheduled event for excluded org, got %d", created)
+	}
+
+	st.UpdateGuildOrgEventsExcluded(gid, "ufc", false)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 1 {
+		t.Fatalf("expected scheduled event once org is included, got %d", created)
+	}
+}
+
+func TestRunNotifierTick_ScheduledEventFixedClock(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "America/New_York")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+	st.UpdateGuildRunHour(gid, 12)
+
+	// UFC 300: Sat Apr 13 2024, 6:00 PM EDT.
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: "sev1", Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	oldSend := sendChannelMessageComplex
 	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
-		sent++
 		return &discordgo.Message{}, nil
 	}
-	defer func() { sendChannelMessageComplex = old }()
+	defer func() { sendChannelMessageComplex = oldSend }()
 
 	s := &discordgo.Session{}
 	cfg := config.Config{TZ: "UTC"}
-	notifyGuild(s, st, gid, mgr, cfg)
+	at := func(t time.Time) func() time.Time { return func() time.Time { return t } }
 
-	if sent != 0 {
-		t.Fatalf("expected no send when org unset and notify disabled, got %d", sent)
+	// Two days before at the run hour: too early.
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 11, 16, 0, 0, 0, time.UTC)))
+	if created != 0 {
+		t.Fatalf("expected no create two days before, got %d", created)
+	}
+	// The day before, but not the run hour: the tick skips the guild.
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 12, 15, 0, 0, 0, time.UTC)))
+	if created != 0 {
+		t.Fatalf("expected no create outside the run hour, got %d", created)
+	}
+	// The day before at 12:00 EDT.
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 12, 16, 0, 0, 0, time.UTC)))
+	if created != 1 {
+		t.Fatalf("expected a create at the run hour the day before, got %d", created)
 	}
+}
 
-	// Enable notify but still no org set -> still skip
+func TestRunNotifierTick_SameDayEventAfterRunHour(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "America/New_York")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+	st.UpdateGuildRunHour(gid, 12)
+
+	// Short-notice card: Sat Apr 13 2024, 6:00 PM EDT, first listed at 2 PM.
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	listed := false
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		if !listed {
+			return nil, false, nil
+		}
+		return &sources.Event{Org: "ufc", Name: "UFC Short Notice", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: "sev1", Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC", SameDayEventGrace: time.Hour}
+	at := func(t time.Time) func() time.Time { return func() time.Time { return t } }
+
+	// The run hour passes with nothing listed.
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 13, 16, 0, 0, 0, time.UTC)))
+	listed = true
+	// Listed at 2 PM EDT: the next hourly tick creates it, once.
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 13, 18, 0, 0, 0, time.UTC)))
+	runNotifierTick(s, st, mgr, cfg, at(time.Date(2024, 4, 13, 19, 0, 0, 0, time.UTC)))
+	if created != 1 {
+		t.Fatalf("expected one scheduled event after the run hour, got %d", created)
+	}
+
+	// Inside the grace the hourly check leaves it alone.
+	st2 := state.Load(":memory:")
+	st2.UpdateGuildTZ(gid, "America/New_York")
+	st2.UpdateGuildOrg(gid, "ufc")
+	st2.UpdateGuildEventsEnabled(gid, true)
+	st2.UpdateGuildRunHour(gid, 12)
+	runNotifierTick(s, st2, mgr, cfg, at(time.Date(2024, 4, 13, 21, 30, 0, 0, time.UTC)))
+	if created != 1 {
+		t.Fatalf("expected no scheduled event inside the grace, got %d", created)
+	}
+}
+
+func TestRunNotifierTick_PostsFollowFixedClock(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "America/New_York")
+	st.UpdateGuildOrg(gid, "ufc")
 	st.UpdateGuildNotifyEnabled(gid, true)
-	notifyGuild(s, st, gid, mgr, cfg)
-	if sent != 0 {
-		t.Fatalf("expected no send when org unset even if notify enabled, got %d", sent)
+	st.UpdateGuildWeighInEnabled(gid, true)
+	st.UpdateGuildFightWeekDays(gid, 5)
+	st.UpdateGuildRunHour(gid, 12)
+
+	// UFC 300: Sat Apr 13 2024, 6:00 PM EDT, long before the wall clock.
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	at := func(t time.Time) func() time.Time { return func() time.Time { return t } }
+	tick := func(t time.Time) int {
+		sent = nil
+		runNotifierTick(s, st, mgr, cfg, at(t))
+		return len(sent)
+	}
+
+	// Fight week (5 days out), the day before (weigh-ins) and the event day
+	// (alert), each at 12:00 EDT.
+	if n := tick(time.Date(2024, 4, 8, 16, 0, 0, 0, time.UTC)); n != 1 || !strings.Contains(sent[0], "Fight week") {
+		t.Fatalf("expected the fight-week promo on Apr 8, got %q", sent)
+	}
+	if n := tick(time.Date(2024, 4, 12, 16, 0, 0, 0, time.UTC)); n != 1 || !strings.Contains(strings.ToLower(sent[0]), "weigh-in") {
+		t.Fatalf("expected the weigh-in reminder on Apr 12, got %q", sent)
+	}
+	if n := tick(time.Date(2024, 4, 13, 16, 0, 0, 0, time.UTC)); n == 0 || !strings.Contains(sent[0], "UFC 300") {
+		t.Fatalf("expected the fight-night alert on Apr 13, got %q", sent)
+	}
+}
+
+// scrapeMetric returns a metric's value from the /metrics handler.
+func scrapeMetric(t *testing.T, name string) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if v, ok := strings.CutPrefix(line, name+" "); ok {
+			return v
+		}
+	}
+	t.Fatalf("metric %s not found in:\n%s", name, rec.Body.String())
+	return ""
+}
+
+func TestRunNotifierTick_IncrementsMetrics(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	now := time.Now().UTC()
+	st.UpdateGuildRunHour(gid, now.Hour())
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: now.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	ticks, sent := metrics.NotifierTicks.Value(), metrics.MessagesSent.Value()
+	runNotifierTick(&discordgo.Session{}, st, mgr, config.Config{TZ: "UTC"}, func() time.Time { return now })
+
+	if got, want := scrapeMetric(t, "notifier_ticks_total"), strconv.FormatUint(ticks+1, 10); got != want {
+		t.Fatalf("notifier_ticks_total = %s, want %s", got, want)
+	}
+	if got, want := scrapeMetric(t, "messages_sent_total"), strconv.FormatUint(sent+1, 10); got != want {
+		t.Fatalf("messages_sent_total = %s, want %s", got, want)
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_PostponeNotice(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+	st.UpdateGuildPostponeNotice(gid, true)
+	st.UpdateGuildScheduledEventLeadDays(gid, 14)
+
+	now := time.Now().UTC()
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
+	st.MarkScheduledEvent(gid, "ufc", tomorrow, "sev1")
+
+	// The card set for tomorrow is gone; the next listed event is a week later.
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 301", Start: now.AddDate(0, 0, 7).Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	oldFetch := fetchGuildScheduledEvent
+	fetchGuildScheduledEvent = func(_ *discordgo.Session, guildID, eventID string) (*discordgo.GuildScheduledEvent, error) {
+		return &discordgo.GuildScheduledEvent{ID: eventID, GuildID: guildID, Name: "UFC 300"}, nil
+	}
+	defer func() { fetchGuildScheduledEvent = oldFetch }()
+	var deleted []string
+	oldDelete := deleteGuildScheduledEvent
+	deleteGuildScheduledEvent = func(_ *discordgo.Session, _ string, eventID string) error {
+		deleted = append(deleted, eventID)
+		return nil
+	}
+	defer func() { deleteGuildScheduledEvent = oldDelete }()
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		return &discordgo.GuildScheduledEvent{ID: "sev2", Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	var notices []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		if strings.Contains(m.Content, "postponed") {
+			notices = append(notices, m.Content)
+		}
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+
+	if len(deleted) != 1 || deleted[0] != "sev1" {
+		t.Fatalf("expected sev1 deleted once, got %v", deleted)
+	}
+	if len(notices) != 1 || !strings.Contains(notices[0], "UFC 300 has been postponed or cancelled") {
+		t.Fatalf("expected one postpone notice, got %q", notices)
+	}
+	if _, _, ok := st.ScheduledEvent(gid, "ufc", tomorrow); ok {
+		t.Fatal("expected the moved event to be forgotten")
+	}
+}
+
+func TestNotifyGuildCore_DedupesPerEvent(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	now := time.Now().UTC()
+	evt := &sources.Event{Org: "ufc", ID: "600041", Name: "UFC Fight Night 1", Start: now.Format(time.RFC3339)}
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return evt, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+	sends := 0
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends++
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, "")
+	notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, "")
+	if sends != 1 {
+		t.Fatalf("expected one alert for the first event, got %d", sends)
+	}
+	// A second event later the same day gets its own alert.
+	evt = &sources.Event{Org: "ufc", ID: "600042", Name: "UFC Fight Night 2", Start: now.Format(time.RFC3339)}
+	if posted, reason := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, ""); !posted {
+		t.Fatalf("expected the second event to post, got %q", reason)
+	}
+	notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, "")
+	if sends != 2 {
+		t.Fatalf("expected one alert per event, got %d", sends)
+	}
+
+	// A date-only mark from before per-event marks still counts.
+	legacy := state.Load(":memory:")
+	legacy.UpdateGuildChannel(gid, "chan1")
+	legacy.UpdateGuildTZ(gid, "UTC")
+	legacy.UpdateGuildOrg(gid, "ufc")
+	legacy.UpdateGuildNotifyEnabled(gid, true)
+	if err := legacy.MarkPosted(gid, state.DedupKey("ufc", state.PostAlert, now.Format("2006-01-02"))); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+	if posted, reason := notifyGuildCore(s, legacy, gid, mgr, cfg, time.Now(), false, ""); posted || reason != "Already posted today" {
+		t.Fatalf("expected legacy date mark to dedupe, got posted=%v %q", posted, reason)
+	}
+}
+
+func TestShouldRecreateScheduledEvent(t *testing.T) {
+	unknown := &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+		Message:  &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownGuildScheduledEvent},
+	}
+	forbidden := &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusForbidden},
+		Message:  &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingAccess},
+	}
+	tests := []struct {
+		name      string
+		err       error
+		recreated int
+		want      bool
+	}{
+		{"still exists", nil, 0, false},
+		{"deleted", unknown, 0, true},
+		{"deleted, bare 404", &discordgo.RESTError{Response: &http.Response{StatusCode: http.StatusNotFound}}, 0, true},
+		{"deleted again after recreation", unknown, 1, false},
+		{"lookup forbidden", forbidden, 0, false},
+		{"network error", errors.New("dial tcp: timeout"), 0, false},
+	}
+	for _, tc := range tests {
+		if got := shouldRecreateScheduledEvent(tc.err, tc.recreated); got != tc.want {
+			t.Fatalf("%s: got %v want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_RecreatesDeletedOnce(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	start := time.Now().UTC().Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: fmt.Sprintf("sev%d", created), Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	deleted := map[string]bool{}
+	oldFetch := fetchGuildScheduledEvent
+	fetchGuildScheduledEvent = func(_ *discordgo.Session, _, eventID string) (*discordgo.GuildScheduledEvent, error) {
+		if deleted[eventID] {
+			return nil, &discordgo.RESTError{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+				Message:  &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownGuildScheduledEvent},
+			}
+		}
+		return &discordgo.GuildScheduledEvent{ID: eventID}, nil
+	}
+	defer func() { fetchGuildScheduledEvent = oldFetch }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	dateKey := start.Format("2006-01-02")
+
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 1 {
+		t.Fatalf("expected one event while it exists, got %d", created)
+	}
+
+	// Accidental delete: recreated and the replacement is tracked.
+	deleted["sev1"] = true
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 2 {
+		t.Fatalf("expected deleted event recreated, got %d creations", created)
+	}
+	if id, n, _ := st.ScheduledEvent(gid, "ufc", dateKey); id != "sev2" || n != 1 {
+		t.Fatalf("expected replacement tracked, got id=%q recreated=%d", id, n)
+	}
+
+	// Deleted again: the admin meant it, so no recreate loop.
+	deleted["sev2"] = true
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 2 {
+		t.Fatalf("expected no second recreation, got %d creations", created)
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_LeadDays(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	var start time.Time
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: fmt.Sprintf("sev%d", created), Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	tenDaysOut := time.Now().UTC().AddDate(0, 0, 10)
+
+	// N=1 (default): an event 10 days out is too early.
+	start = tenDaysOut
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 0 {
+		t.Fatalf("default lead: expected no event 10 days out, got %d", created)
+	}
+	// N=1 still creates the day before.
+	start = time.Now().UTC().Add(24 * time.Hour)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 1 {
+		t.Fatalf("default lead: expected event the day before, got %d", created)
+	}
+
+	// N=14: an event 10 days out is within the window.
+	st.UpdateGuildScheduledEventLeadDays(gid, 14)
+	start = tenDaysOut
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 2 {
+		t.Fatalf("lead 14: expected event 10 days out, got %d", created)
+	}
+	// Already created for that event date: later runs in the window skip it.
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 2 {
+		t.Fatalf("lead 14: expected no duplicate, got %d", created)
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_SameDay(t *testing.T) {
+	// Pick a fixed-offset zone where it's about noon now, so "a few hours from
+	// now" stays on today's date whenever the test runs. Etc/GMT signs are inverted.
+	offset := 12 - time.Now().UTC().Hour()
+	tz := fmt.Sprintf("Etc/GMT%+d", -offset)
+	if offset == 0 {
+		tz = "UTC"
+	}
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, tz)
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	var start time.Time
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	created := 0
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		created++
+		return &discordgo.GuildScheduledEvent{ID: fmt.Sprintf("sev%d", created), Name: params.Name}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	now := time.Now().UTC()
+
+	// Already started, or starting inside the default grace: too late to create.
+	for _, at := range []time.Time{now.Add(-time.Hour), now.Add(5 * time.Minute)} {
+		start = at
+		ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+		if created != 0 {
+			t.Fatalf("expected no event starting at %v, got %d", at, created)
+		}
+	}
+	// A longer configured grace also rules out an event two hours away.
+	start = now.Add(2 * time.Hour)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, config.Config{TZ: "UTC", SameDayEventGrace: 3 * time.Hour}, time.Now)
+	if created != 0 {
+		t.Fatalf("expected the 3h grace to skip an event 2h away, got %d", created)
+	}
+	// Short notice later today: created immediately, once.
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if created != 1 {
+		t.Fatalf("expected one same-day event, got %d", created)
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_FailureLimit(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	start := time.Now().UTC().Add(24 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Test", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var createErr error
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, _ *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		if createErr != nil {
+			return nil, createErr
+		}
+		return &discordgo.GuildScheduledEvent{ID: "sev1"}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	var notices []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		notices = append(notices, m.Content)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC", EventFailureLimit: 2}
+	missingPerms := &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingPermissions, Message: "Missing Permissions"}}
+
+	// A success in between resets the streak.
+	createErr = missingPerms
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if n := st.GetGuildEventFailures(gid); n != 1 {
+		t.Fatalf("expected 1 failure, got %d", n)
+	}
+	createErr = nil
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if n := st.GetGuildEventFailures(gid); n != 0 || !st.GetGuildEventsEnabled(gid) {
+		t.Fatalf("expected success to reset failures (got %d) and keep events on", n)
+	}
+	notices = nil // drop the event-link announcement from the success
+
+	// Two failures in a row trip the limit (a later event, so nothing is marked yet).
+	st.UpdateGuildScheduledEventLeadDays(gid, 2)
+	start = start.Add(24 * time.Hour)
+	createErr = missingPerms
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if !st.GetGuildEventsEnabled(gid) || len(notices) != 0 {
+		t.Fatalf("expected events still on after one failure, notices=%q", notices)
+	}
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if st.GetGuildEventsEnabled(gid) {
+		t.Fatalf("expected events disabled after reaching the failure limit")
+	}
+	if len(notices) != 1 || !strings.Contains(notices[0], "missing the Manage Events permission") {
+		t.Fatalf("expected one notice with the reason, got %q", notices)
+	}
+	if n := st.GetGuildEventFailures(gid); n != 0 {
+		t.Fatalf("expected failures reset after tripping, got %d", n)
+	}
+}
+
+func TestPostWeighInReminder_DayBeforeOnly(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildWeighInEnabled(gid, true)
+	st.UpdateGuildWeighInMessage(gid, "Weigh-ins for {event} today")
+
+	var start time.Time
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+
+	// Event day: no reminder.
+	start = time.Now().UTC()
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	if len(sent) != 0 {
+		t.Fatalf("expected no reminder on event day, got %q", sent)
+	}
+
+	// Day before: one reminder, deduped on later runs.
+	start = time.Now().UTC().Add(24 * time.Hour)
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	if len(sent) != 1 || sent[0] != "Weigh-ins for UFC 300 today" {
+		t.Fatalf("expected one reminder the day before, got %q", sent)
+	}
+	if h, _ := st.ListPostHistory(gid, 0); len(h) != 1 || h[0].Org != "ufc:weighin" || h[0].MessageID != "m1" {
+		t.Fatalf("expected weigh-in recorded in history, got %+v", h)
+	}
+	// The weigh-in dedup key doesn't block the event-day alert.
+	if _, _, lp := st.GetGuildSettings(gid); lp["ufc"] != "" {
+		t.Fatalf("expected event-day dedup untouched, got %q", lp["ufc"])
+	}
+}
+
+func TestPostWeighInReminder_PerEventOnSameDay(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildWeighInEnabled(gid, true)
+	st.UpdateGuildWeighInMessage(gid, "Weigh-ins for {event} today")
+
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	evt := &sources.Event{ID: "1", Org: "ufc", Name: "UFC Fight Night", Start: tomorrow.Format(time.RFC3339)}
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return evt, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	evt = &sources.Event{ID: "2", Org: "ufc", Name: "UFC 300", Start: tomorrow.Format(time.RFC3339)}
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	want := []string{"Weigh-ins for UFC Fight Night today", "Weigh-ins for UFC 300 today"}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("got weigh-in reminders %q want %q", sent, want)
+	}
+}
+
+func TestPostFightWeekPromo_FiresOnceOnConfiguredDay(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+
+	var start time.Time
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{
+			Org:   "ufc",
+			Name:  "UFC 300",
+			Start: start.Format(time.RFC3339),
+			Bouts: []sources.Bout{{RedName: "Alex Pereira", BlueName: "Jamahal Hill"}},
+		}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []*discordgo.MessageSend
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	daysOut := func(n int) time.Time { return time.Now().UTC().Add(time.Duration(n) * 24 * time.Hour) }
+
+	// Off by default.
+	start = daysOut(6)
+	postFightWeekPromo(s, st, gid, mgr, cfg, time.Now())
+	if len(sent) != 0 {
+		t.Fatalf("expected no promo while disabled, got %d", len(sent))
+	}
+
+	st.UpdateGuildFightWeekDays(gid, 6)
+	// Too early and too late: nothing.
+	for _, n := range []int{7, 5} {
+		start = daysOut(n)
+		postFightWeekPromo(s, st, gid, mgr, cfg, time.Now())
+	}
+	if len(sent) != 0 {
+		t.Fatalf("expected no promo off the configured day, got %d", len(sent))
+	}
+
+	// Exactly six days out: one promo with the card, deduped on later runs.
+	start = daysOut(6)
+	postFightWeekPromo(s, st, gid, mgr, cfg, time.Now())
+	postFightWeekPromo(s, st, gid, mgr, cfg, time.Now())
+	if len(sent) != 1 {
+		t.Fatalf("expected exactly one promo, got %d", len(sent))
+	}
+	if sent[0].Content != "Fight week is here! UFC 300 is 6 days away." {
+		t.Fatalf("unexpected promo content: %q", sent[0].Content)
+	}
+	if len(sent[0].Embeds) != 1 || len(sent[0].Embeds[0].Fields) == 0 {
+		t.Fatalf("expected the card embed with the promo, got %+v", sent[0].Embeds)
+	}
+	_, _, lp := st.GetGuildSettings(gid)
+	if lp["ufc:fightweek"] != start.Format("2006-01-02") || lp["ufc"] != "" {
+		t.Fatalf("expected only the fight-week key marked, got %v", lp)
+	}
+}
+
+func TestNotifyGuildCore_SkipsNonTextChannel(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "cat1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	oldFetch := fetchChannel
+	fetchChannel = func(_ *discordgo.Session, channelID string) (*discordgo.Channel, error) {
+		return &discordgo.Channel{ID: channelID, Type: discordgo.ChannelTypeGuildCategory}, nil
+	}
+	defer func() { fetchChannel = oldFetch }()
+	sends := 0
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends++
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), false, "")
+	if posted || reason != "Channel is not a text channel" {
+		t.Fatalf("expected skip for category channel, got posted=%v reason=%q", posted, reason)
+	}
+	if sends != 0 {
+		t.Fatalf("expected no send to a category channel, got %d", sends)
+	}
+	if _, _, lp := st.GetGuildSettings(gid); lp["ufc"] != "" {
+		t.Fatalf("expected nothing marked posted, got %q", lp["ufc"])
+	}
+}
+
+func TestRunNotifierTick_MaintenanceSkipsPosting(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildRunHour(gid, time.Now().UTC().Hour())
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	sends := 0
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends++
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC", Maintenance: true}
+	runNotifierTick(s, st, mgr, cfg, time.Now)
+	if sends != 0 {
+		t.Fatalf("expected no sends in maintenance mode, got %d", sends)
+	}
+	if posted, reason := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), true, ""); posted || reason != "Maintenance mode" {
+		t.Fatalf("expected forced post blocked in maintenance, got posted=%v reason=%q", posted, reason)
+	}
+	if _, _, lp := st.GetGuildSettings(gid); lp["ufc"] != "" {
+		t.Fatalf("expected nothing marked posted during maintenance, got %q", lp["ufc"])
+	}
+
+	cfg.Maintenance = false
+	runNotifierTick(s, st, mgr, cfg, time.Now)
+	if sends != 1 {
+		t.Fatalf("expected the alert once maintenance ends, got %d sends", sends)
+	}
+}
+
+func TestNotifyGuildCore_PingsSubscriberRole(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildSubscriberRole(gid, "r1")
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sends []*discordgo.MessageSend
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends = append(sends, m)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), false, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if len(sends) != 1 || !strings.HasPrefix(sends[0].Content, "<@&r1>\n") {
+		t.Fatalf("expected the alert to mention the subscriber role, got %+v", sends)
+	}
+	if am := sends[0].AllowedMentions; am == nil || len(am.Parse) != 0 || len(am.Roles) != 1 || am.Roles[0] != "r1" {
+		t.Fatalf("expected only the subscriber role allowed to ping, got %+v", sends[0].AllowedMentions)
+	}
+}
+
+func TestNotifyGuildCore_PingsPingRole(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildPingRole(gid, "p1")
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sends []*discordgo.MessageSend
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends = append(sends, m)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), true, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if len(sends) != 1 || !strings.HasPrefix(sends[0].Content, "<@&p1> ") {
+		t.Fatalf("expected the alert to mention the ping role, got %+v", sends)
+	}
+	if am := sends[0].AllowedMentions; am == nil || len(am.Parse) != 0 || !reflect.DeepEqual(am.Roles, []string{"p1"}) {
+		t.Fatalf("expected only the ping role allowed to ping, got %+v", sends[0].AllowedMentions)
+	}
+
+	// Alongside the subscriber role, only the ping role is mentioned and allowed.
+	st.UpdateGuildSubscriberRole(gid, "r1")
+	sends = nil
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), true, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if !strings.HasPrefix(sends[0].Content, "<@&p1> ") || strings.Contains(sends[0].Content, "<@&r1>") || !reflect.DeepEqual(sends[0].AllowedMentions.Roles, []string{"p1"}) {
+		t.Fatalf("expected only the ping role, got %q %+v", sends[0].Content, sends[0].AllowedMentions)
+	}
+
+	// Clearing the ping role falls back to the subscriber role.
+	st.UpdateGuildPingRole(gid, "")
+	sends = nil
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), true, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if !strings.HasPrefix(sends[0].Content, "<@&r1>\n") || !reflect.DeepEqual(sends[0].AllowedMentions.Roles, []string{"r1"}) {
+		t.Fatalf("expected the subscriber role, got %q %+v", sends[0].Content, sends[0].AllowedMentions)
+	}
+}
+
+func TestNotifyGuildCore_SkipUntilPPV(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildSkipUntilPPV(gid, true)
+
+	name := "UFC Fight Night: Nicolau vs. Perez"
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: name, Start: time.Now().UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	sends := 0
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends++
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	cfg := config.Config{TZ: "UTC"}
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, cfg, time.Now(), false, ""); posted || reason != "Skipping until next PPV" {
+		t.Fatalf("expected the Fight Night to be skipped, got %v %q", posted, reason)
+	}
+	if sends != 0 || !st.GetGuildSkipUntilPPV(gid) {
+		t.Fatalf("expected no sends and the mode kept, got %d sends", sends)
+	}
+
+	name = "UFC 300: Pereira vs. Hill"
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, cfg, time.Now(), false, ""); !posted {
+		t.Fatalf("expected the PPV to post, got %q", reason)
+	}
+	if sends != 1 {
+		t.Fatalf("expected one send, got %d", sends)
+	}
+	if st.GetGuildSkipUntilPPV(gid) {
+		t.Fatalf("expected the mode cleared after the PPV posted")
+	}
+}
+
+func TestSkippingUntilPPV_GatesOptInPosts(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildWeighInEnabled(gid, true)
+	st.UpdateGuildSkipUntilPPV(gid, true)
+
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC Fight Night: Nicolau vs. Perez", Start: time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	sends := 0
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends++
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	postWeighInReminder(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now())
+	if sends != 0 {
+		t.Fatalf("expected the weigh-in reminder skipped, got %d sends", sends)
+	}
+	st.UpdateGuildSkipUntilPPV(gid, false)
+	postWeighInReminder(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now())
+	if sends != 1 {
+		t.Fatalf("expected the weigh-in reminder once the mode is off, got %d sends", sends)
+	}
+}
+
+func TestReminderDue(t *testing.T) {
+	start := time.Date(2024, 4, 13, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		now    time.Time
+		offset time.Duration
+		want   bool
+	}{
+		{time.Date(2024, 4, 13, 21, 30, 0, 0, time.UTC), time.Hour, true},         // exactly at start-1h
+		{time.Date(2024, 4, 13, 21, 30, 59, 0, time.UTC), time.Hour, true},        // later in the minute
+		{time.Date(2024, 4, 13, 21, 31, 0, 0, time.UTC), time.Hour, false},        // next tick
+		{time.Date(2024, 4, 13, 21, 29, 0, 0, time.UTC), time.Hour, false},        // before the window
+		{time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC), 30 * time.Minute, true},   // window start
+		{time.Date(2024, 4, 13, 22, 30, 0, 0, time.UTC), 30 * time.Minute, false}, // event started
+		{time.Date(2024, 4, 12, 22, 30, 0, 0, time.UTC), 24 * time.Hour, true},
+		{time.Date(2024, 4, 12, 23, 30, 0, 0, time.UTC), 24 * time.Hour, false}, // window passed
+	}
+	for i, tc := range tests {
+		if got := reminderDue(tc.now, start, tc.offset, reminderWindow); got != tc.want {
+			t.Fatalf("case %d: reminderDue(%v, -%v) = %v want %v", i, tc.now, tc.offset, got, tc.want)
+		}
+	}
+}
+
+func TestPostEventReminders_OncePerOffset(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildReminderOffsets(gid, []int{24, 1})
+
+	// Starts off the hour: each offset still posts on the minute it names.
+	start := time.Date(2024, 4, 13, 22, 45, 0, 0, time.UTC)
+	lookupsMade := 0
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		lookupsMade++
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	cfg := config.Config{TZ: "UTC"}
+	lookups := nextEventLookups{}
+	for _, now := range []time.Time{
+		start.Add(-25 * time.Hour),             // nothing due yet
+		start.Add(-24*time.Hour - time.Minute), // a minute early
+		start.Add(-24 * time.Hour),             // 24h reminder
+		start.Add(-24*time.Hour + time.Minute), // window passed
+		start.Add(-time.Hour),                  // 1h reminder
+		start.Add(-time.Hour + 30*time.Minute),
+	} {
+		runMinuteTick(&discordgo.Session{}, st, mgr, cfg, now, lookups)
+	}
+	want := []string{
+		"Reminder: UFC 300 starts in 24 hours (10:45 PM UTC).",
+		"Reminder: UFC 300 starts in 1 hour (10:45 PM UTC).",
+	}
+	// One lookup to learn the start, then only on the minutes a reminder is due.
+	if lookupsMade != 3 {
+		t.Fatalf("expected 3 lookups, got %d", lookupsMade)
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("got reminders %q want %q", sent, want)
+	}
+}
+
+func TestRunMinuteTick_RetriesFailedLookup(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildReminderOffsets(gid, []int{24})
+
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	fail := true
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		if fail {
+			return nil, false, errors.New("espn unavailable")
+		}
+		return &sources.Event{ID: "1", Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	cfg := config.Config{TZ: "UTC"}
+	lookups := nextEventLookups{}
+	// The failure isn't cached for nextEventRecheck, so the lookup half an
+	// hour later still finds the event in time for the 24h reminder.
+	runMinuteTick(&discordgo.Session{}, st, mgr, cfg, start.Add(-24*time.Hour-30*time.Minute), lookups)
+	fail = false
+	runMinuteTick(&discordgo.Session{}, st, mgr, cfg, start.Add(-24*time.Hour), lookups)
+	if len(sent) != 1 || sent[0] != "Reminder: UFC 300 starts in 24 hours (10:00 PM UTC)." {
+		t.Fatalf("expected the 24h reminder after a failed lookup, got %q", sent)
+	}
+}
+
+func TestPostEventReminders_PerEventOnSameDay(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	g := minuteGuild{id: gid, org: "ufc", offsets: []int{1}}
+	cfg := config.Config{TZ: "UTC"}
+	early := time.Date(2024, 4, 13, 15, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	for _, evt := range []*sources.Event{
+		{ID: "1", Org: "ufc", Name: "UFC Fight Night", Start: early.Format(time.RFC3339)},
+		{ID: "2", Org: "ufc", Name: "UFC 300", Start: late.Format(time.RFC3339)},
+	} {
+		start, _ := parseAPITime(evt.Start)
+		postEventReminders(&discordgo.Session{}, st, g, cfg, start.Add(-time.Hour), evt)
+		postEventReminders(&discordgo.Session{}, st, g, cfg, start.Add(-time.Hour), evt)
+	}
+	want := []string{
+		"Reminder: UFC Fight Night starts in 1 hour (3:00 PM UTC).",
+		"Reminder: UFC 300 starts in 1 hour (10:00 PM UTC).",
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("got reminders %q want %q", sent, want)
+	}
+}
+
+func TestPostLiveSoonReminder_OnceInWindow(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildLiveSoonMinutes(gid, 15)
+	st.MarkScheduledEvent(gid, "ufc", "2024-04-13", "sev1")
+
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", ID: "401", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sent []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent = append(sent, m.Content)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	cfg := config.Config{TZ: "UTC"}
+	lookups := nextEventLookups{}
+	for _, now := range []time.Time{
+		start.Add(-16 * time.Minute), // outside the window
+		start.Add(-15 * time.Minute), // due
+		start.Add(-14 * time.Minute), // already posted
+		start.Add(-time.Minute),
+		start.Add(time.Minute), // started
+	} {
+		runMinuteTick(&discordgo.Session{}, st, mgr, cfg, now, lookups)
+	}
+	want := []string{"🔴 UFC 300 starts in 15 minutes (10:00 PM UTC). https://discord.com/events/g1/sev1"}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("got live-soon posts %q want %q", sent, want)
+	}
+}
+
+func TestRunMinuteTick_SharesLookupPerOrg(t *testing.T) {
+	st := state.Load(":memory:")
+	for _, gid := range []string{"g1", "g2"} {
+		st.UpdateGuildChannel(gid, "chan-"+gid)
+		st.UpdateGuildTZ(gid, "UTC")
+		st.UpdateGuildOrg(gid, "ufc")
+		st.UpdateGuildNotifyEnabled(gid, true)
+	}
+	st.UpdateGuildLiveSoonMinutes("g1", 15)
+	st.UpdateGuildLiveSoonMinutes("g2", 30)
+
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	lookupsMade := 0
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		lookupsMade++
+		return &sources.Event{Org: "ufc", ID: "401", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var channels []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, ch string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		channels = append(channels, ch)
+		return &discordgo.Message{ID: "m"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	cfg := config.Config{TZ: "UTC"}
+	lookups := nextEventLookups{}
+	steps := []struct {
+		at      time.Time
+		lookups int
+		posts   int
+	}{
+		{start.Add(-2 * time.Hour), 1, 0},              // first tick: one lookup shared by both guilds
+		{start.Add(-2*time.Hour + time.Minute), 1, 0},  // cached start is outside every lead
+		{start.Add(-time.Hour - 30*time.Minute), 1, 0}, // still cached
+		{start.Add(-30 * time.Minute), 2, 1},           // inside g2's lead
+		{start.Add(-15 * time.Minute), 3, 2},           // inside g1's lead
+		{start.Add(time.Minute), 3, 2},                 // started: no lookup
+	}
+	for i, step := range steps {
+		runMinuteTick(&discordgo.Session{}, st, mgr, cfg, step.at, lookups)
+		if lookupsMade != step.lookups || len(channels) != step.posts {
+			t.Fatalf("step %d: got %d lookups, %d posts; want %d, %d", i, lookupsMade, len(channels), step.lookups, step.posts)
+		}
+	}
+	if !reflect.DeepEqual(channels, []string{"chan-g2", "chan-g1"}) {
+		t.Fatalf("got posts to %q", channels)
+	}
+
+	// A stale lookup is refreshed even when the start is far away.
+	runMinuteTick(&discordgo.Session{}, st, mgr, cfg, start.Add(2*time.Hour), lookups)
+	if lookupsMade != 4 {
+		t.Fatalf("expected a refresh after %s, got %d lookups", nextEventRecheck, lookupsMade)
+	}
+}
+
+func TestChannelRouting_ByPurpose(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "main")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+	st.UpdateGuildEventsEnabled(gid, true)
+	st.UpdateGuildWeighInEnabled(gid, true)
+
+	start := time.Now().UTC()
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, _ *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		return &discordgo.GuildScheduledEvent{ID: "sev1"}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+	var channels []string
+	var contents []string
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, ch string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		channels = append(channels, ch)
+		contents = append(contents, m.Content)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+
+	// Defaults: everything goes to the main channel.
+	start = time.Now().UTC().Add(24 * time.Hour)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	if len(channels) != 1 || channels[0] != "main" || !strings.Contains(contents[0], "https://discord.com/events/g1/sev1") {
+		t.Fatalf("expected event link in main channel, got %q %q", channels, contents)
+	}
+
+	st.UpdateGuildAlertChannel(gid, "alerts")
+	st.UpdateGuildEventChannel(gid, "events")
+	channels, contents = nil, nil
+
+	// Day before: the event link and the weigh-in reminder split by purpose.
+	start = time.Now().UTC().Add(48 * time.Hour)
+	st.UpdateGuildScheduledEventLeadDays(gid, 2)
+	ensureTomorrowScheduledEvent(s, st, gid, mgr, cfg, time.Now)
+	start = time.Now().UTC().Add(24 * time.Hour)
+	postWeighInReminder(s, st, gid, mgr, cfg, time.Now())
+	if len(channels) != 2 || channels[0] != "events" || channels[1] != "alerts" {
+		t.Fatalf("expected event link in events and weigh-in in alerts, got %q", channels)
+	}
+
+	// Event day: the fight-night alert uses the alert channel.
+	channels = nil
+	start = time.Now().UTC()
+	if posted, reason := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if len(channels) != 1 || channels[0] != "alerts" {
+		t.Fatalf("expected alert in alerts channel, got %q", channels)
+	}
+
+	// Clearing the override falls back to the main channel.
+	st.UpdateGuildAlertChannel(gid, "")
+	channels = nil
+	if posted, reason := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), true, ""); !posted {
+		t.Fatalf("expected forced post, got %q", reason)
+	}
+	if len(channels) != 1 || channels[0] != "main" {
+		t.Fatalf("expected alert back in main channel, got %q", channels)
+	}
+}
+
+func TestRefreshPendingCard_Modes(t *testing.T) {
+	for _, mode := range []string{"", cardUpdateNew} {
+		st := state.Load(":memory:")
+		gid := "g1"
+		st.UpdateGuildChannel(gid, "chan1")
+		st.UpdateGuildTZ(gid, "UTC")
+		st.UpdateGuildOrg(gid, "ufc")
+		st.UpdateGuildNotifyEnabled(gid, true)
+		st.UpdateGuildCardUpdateMode(gid, mode)
+
+		var bouts []sources.Bout
+		oldGet := getNextEventFunc
+		getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+			return &sources.Event{Org: "ufc", Name: "UFC 300", Start: time.Now().UTC().Format(time.RFC3339), Bouts: bouts}, true, nil
+		}
+		mgr := sources.NewManager()
+		mgr.Register("ufc", &cardProv{})
+
+		var sends []*discordgo.MessageSend
+		oldSend := sendChannelMessageComplex
+		sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+			sends = append(sends, m)
+			return &discordgo.Message{ID: fmt.Sprintf("m%d", len(sends))}, nil
+		}
+		var edits []*discordgo.MessageEdit
+		oldEdit := editChannelMessageComplex
+		editChannelMessageComplex = func(_ *discordgo.Session, m *discordgo.MessageEdit) (*discordgo.Message, error) {
+			edits = append(edits, m)
+			return &discordgo.Message{ID: m.ID}, nil
+		}
+		restore := func() {
+			getNextEventFunc = oldGet
+			sendChannelMessageComplex = oldSend
+			editChannelMessageComplex = oldEdit
+		}
+
+		s := &discordgo.Session{}
+		cfg := config.Config{TZ: "UTC"}
+		if posted, reason := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), false, ""); !posted {
+			restore()
+			t.Fatalf("mode %q: expected the initial alert, got %q", mode, reason)
+		}
+		// Card still TBA: nothing to update.
+		refreshPendingCard(s, st, gid, mgr, cfg, time.Now())
+		if len(sends) != 1 || len(edits) != 0 {
+			restore()
+			t.Fatalf("mode %q: expected no update before the card is known, got %d sends %d edits", mode, len(sends), len(edits))
+		}
+
+		bouts = []sources.Bout{{RedName: "Alex Pereira", BlueName: "Jamahal Hill"}}
+		refreshPendingCard(s, st, gid, mgr, cfg, time.Now())
+		refreshPendingCard(s, st, gid, mgr, cfg, time.Now())
+		restore()
+
+		if mode == cardUpdateNew {
+			if len(edits) != 0 || len(sends) != 2 || sends[1].Content != "Card update: UFC 300" || len(sends[1].Embeds[0].Fields) == 0 {
+				t.Fatalf("new mode: expected one follow-up with the card, got %d edits, sends %+v", len(edits), sends)
+			}
+			continue
+		}
+		if len(sends) != 1 || len(edits) != 1 {
+			t.Fatalf("edit mode: expected one edit and no new message, got %d sends %d edits", len(sends), len(edits))
+		}
+		if e := edits[0]; e.ID != "m1" || e.Channel != "chan1" || e.Embeds == nil || len((*e.Embeds)[0].Fields) == 0 {
+			t.Fatalf("edit mode: expected the alert's embed replaced with the card, got %+v", e)
+		}
+	}
+}
+
+func TestNotifyGuildCore_RestrictsAllowedMentions(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	now := time.Now().UTC()
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "@everyone <@&123> Fight Night", Start: now.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var sends []*discordgo.MessageSend
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		sends = append(sends, m)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	if posted, reason := notifyGuildCore(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now(), false, ""); !posted {
+		t.Fatalf("expected post, got %q", reason)
+	}
+	if len(sends) == 0 {
+		t.Fatalf("expected a send")
+	}
+	for _, m := range sends {
+		am := m.AllowedMentions
+		if am == nil || am.Parse == nil || len(am.Parse) != 0 || len(am.Roles) != 0 || len(am.Users) != 0 {
+			t.Fatalf("expected restrictive allowed mentions, got %+v", am)
+		}
+	}
+}
+
+func TestNotifyGuildCore_PinsWhenEnabled(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildChannel(gid, "chan1")
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	now := time.Now().UTC()
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{Org: "ufc", Name: "Test Event", Start: now.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{ok: true, name: "Test Event", at: now})
+
+	nextID := 1
+	oldSend := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		nextID++
+		return &discordgo.Message{ID: fmt.Sprintf("m%d", nextID)}, nil
+	}
+	defer func() { sendChannelMessageComplex = oldSend }()
+
+	var pinned, unpinned []string
+	oldPin, oldUnpin := pinChannelMessage, unpinChannelMessage
+	pinChannelMessage = func(_ *discordgo.Session, ch, msg string) error {
+		pinned = append(pinned, ch+"/"+msg)
+		return nil
+	}
+	unpinChannelMessage = func(_ *discordgo.Session, ch, msg string) error {
+		unpinned = append(unpinned, ch+"/"+msg)
+		return nil
+	}
+	defer func() { pinChannelMessage, unpinChannelMessage = oldPin, oldUnpin }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+
+	// Disabled: no pin attempted
+	notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), true, "")
+	if len(pinned) != 0 {
+		t.Fatalf("expected no pin when disabled, got %v", pinned)
+	}
+	nextID = 1
+
+	// Enabled: previous alert unpinned, new one pinned and recorded
+	st.UpdateGuildPinEnabled(gid, true)
+	st.UpdateGuildPinnedMessage(gid, "chan1", "m1")
+	notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), true, "")
+	if len(unpinned) != 1 || unpinned[0] != "chan1/m1" {
+		t.Fatalf("expected previous alert unpinned, got %v", unpinned)
+	}
+	if len(pinned) != 1 || pinned[0] != "chan1/m2" {
+		t.Fatalf("expected new alert pinned, got %v", pinned)
+	}
+	if ch, msg := st.GetGuildPinnedMessage(gid); ch != "chan1" || msg != "m2" {
+		t.Fatalf("expected pinned message recorded, got %s/%s", ch, msg)
+	}
+
+	// Pin failure (e.g., 50-pin limit) is tolerated and not recorded
+	pinChannelMessage = func(_ *discordgo.Session, _, _ string) error {
+		return &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMaximumPinsReached}}
+	}
+	if posted, _ := notifyGuildCore(s, st, gid, mgr, cfg, time.Now(), true, ""); !posted {
+		t.Fatalf("expected post to succeed despite pin failure")
+	}
+	if _, msg := st.GetGuildPinnedMessage(gid); msg != "" {
+		t.Fatalf("expected no pinned record after failed pin, got %q", msg)
+	}
+}
+
+func TestEnsureTomorrowScheduledEvent_ParamsDescribeCard(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildTZ(gid, "UTC")
+	st.UpdateGuildOrg(gid, "ufc")
+	st.UpdateGuildEventsEnabled(gid, true)
+
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Minute)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{
+			Org:   "ufc",
+			Name:  "UFC 300: Pereira vs. Hill",
+			Start: start.Format(time.RFC3339),
+			Links: []sources.Link{{Title: "Event Page", URL: "https://www.espn.com/mma/fightcenter/_/id/600039"}},
+			Bouts: []sources.Bout{
+				{RedName: "Prelim A", BlueName: "Prelim B", Scheduled: start.Format(time.RFC3339)},
+				{RedName: "Alex Pereira", BlueName: "Jamahal Hill", WeightClass: "LHW", Scheduled: start.Add(3 * time.Hour).Format(time.RFC3339)},
+			},
+		}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	var got *discordgo.GuildScheduledEventParams
+	oldCreate := createGuildScheduledEvent
+	createGuildScheduledEvent = func(_ *discordgo.Session, _ string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+		got = params
+		return &discordgo.GuildScheduledEvent{ID: "sev1"}, nil
+	}
+	defer func() { createGuildScheduledEvent = oldCreate }()
+
+	ensureTomorrowScheduledEvent(&discordgo.Session{}, st, gid, mgr, config.Config{TZ: "UTC"}, time.Now)
+	if got == nil {
+		t.Fatalf("expected scheduled event to be created")
+	}
+	if !got.ScheduledStartTime.After(time.Now()) || !got.ScheduledStartTime.Equal(start) {
+		t.Fatalf("expected future start at event time, got %v", got.ScheduledStartTime)
+	}
+	if !strings.Contains(got.Description, "Main event: Alex Pereira vs Jamahal Hill (LHW)") || !strings.Contains(got.Description, "Full card: 2 bouts") {
+		t.Fatalf("expected card summary in description, got %q", got.Description)
+	}
+	if got.EntityMetadata == nil || got.EntityMetadata.Location != "https://www.espn.com/mma/fightcenter/_/id/600039" {
+		t.Fatalf("expected event page as location, got %+v", got.EntityMetadata)
+	}
+}
+
+func TestScheduledEventParams_NamePrefix(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	evt := &sources.Event{Name: "UFC 300: Pereira vs. Hill"}
+	if got := scheduledEventParams("ufc", "", evt, start, "").Name; got != "UFC: UFC 300: Pereira vs. Hill" {
+		t.Fatalf("default prefix: got %q", got)
+	}
+	if got := scheduledEventParams("ufc", "🥊 Watch Party:", evt, start, "").Name; got != "🥊 Watch Party: UFC 300: Pereira vs. Hill" {
+		t.Fatalf("custom prefix: got %q", got)
+	}
+
+	long := &sources.Event{Name: strings.Repeat("x", 120)}
+	got := scheduledEventParams("ufc", "🥊 Watch Party:", long, start, "").Name
+	if n := utf8.RuneCountInString(got); n != scheduledEventNameMax {
+		t.Fatalf("expected name truncated to %d characters, got %d", scheduledEventNameMax, n)
+	}
+	if !strings.HasPrefix(got, "🥊 Watch Party: xxx") || !strings.HasSuffix(got, "…") {
+		t.Fatalf("expected prefixed, truncated name, got %q", got)
+	}
+}
+
+func TestValidateScheduledEventParams_RejectsPastStart(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	p := scheduledEventParams("ufc", "", &sources.Event{Name: "UFC Test"}, past, "")
+	if err := validateScheduledEventParams(p, now); err == nil {
+		t.Fatalf("expected error for past start")
+	}
+	if !strings.Contains(p.Description, "to be announced") {
+		t.Fatalf("expected TBA description without card data, got %q", p.Description)
+	}
+}
+
+func TestNotifyGuild_SkipsWhenNoOrgOrDisabled(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g2"
+	st.UpdateGuildChannel(gid, "chan1")
+	// No org set, notify off by default
+
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+
+	sent := 0
+	old := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, _ string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		sent++
+		return &discordgo.Message{}, nil
+	}
+	defer func() { sendChannelMessageComplex = old }()
+
+	s := &discordgo.Session{}
+	cfg := config.Config{TZ: "UTC"}
+	notifyGuild(s, st, gid, mgr, cfg, time.Now())
+
+	if sent != 0 {
+		t.Fatalf("expected no send when org unset and notify disabled, got %d", sent)
+	}
+
+	// Enable notify but still no org set -> still skip
+	st.UpdateGuildNotifyEnabled(gid, true)
+	notifyGuild(s, st, gid, mgr, cfg, time.Now())
+	if sent != 0 {
+		t.Fatalf("expected no send when org unset even if notify enabled, got %d", sent)
+	}
+}
+
+func TestRunNotifierLoop_SkipInitialTick(t *testing.T) {
+	oldDelay, oldTick, oldSched := initialTickDelay, notifierTickFunc, scheduleFunc
+	defer func() { initialTickDelay, notifierTickFunc, scheduleFunc = oldDelay, oldTick, oldSched }()
+
+	ticks := 0
+	scheduled := 0
+	initialTickDelay = 0
+	notifierTickFunc = func(_ *discordgo.Session, _ *state.Store, _ *sources.Manager, _ config.Config, _ func() time.Time) {
+		ticks++
+	}
+	scheduleFunc = func(fn func()) { scheduled++ }
+
+	st := state.Load(":memory:")
+	runNotifierLoop(&discordgo.Session{}, st, sources.NewManager(), config.NewHolder(config.Config{}), time.Now)
+	if ticks != 1 || scheduled != 1 {
+		t.Fatalf("default: expected immediate tick then schedule, got ticks=%d scheduled=%d", ticks, scheduled)
+	}
+
+	ticks, scheduled = 0, 0
+	runNotifierLoop(&discordgo.Session{}, st, sources.NewManager(), config.NewHolder(config.Config{SkipInitialTick: true}), time.Now)
+	if ticks != 0 || scheduled != 1 {
+		t.Fatalf("skip: expected no immediate tick, got ticks=%d scheduled=%d", ticks, scheduled)
+	}
+}
+
+func TestPromptMissingChannel_Once(t *testing.T) {
+	st := state.Load(":memory:")
+	gid := "g1"
+	st.UpdateGuildNotifyEnabled(gid, true)
+
+	oldGuild := fetchGuild
+	fetchGuild = func(_ *discordgo.Session, id string) (*discordgo.Guild, error) {
+		return &discordgo.Guild{ID: id, Name: "Fight Club", OwnerID: "owner1"}, nil
+	}
+	defer func() { fetchGuild = oldGuild }()
+	var dms []string
+	oldDM := sendDirectMessage
+	sendDirectMessage = func(_ *discordgo.Session, userID, content string) error {
+		dms = append(dms, userID+": "+content)
+		return nil
+	}
+	defer func() { sendDirectMessage = oldDM }()
+
+	s := &discordgo.Session{}
+	for i := 0; i < noChannelPromptDays+2; i++ {
+		promptMissingChannel(s, st, gid)
+		if i < noChannelPromptDays-1 && len(dms) != 0 {
+			t.Fatalf("run %d: expected no prompt yet, got %v", i+1, dms)
+		}
+	}
+	if len(dms) != 1 {
+		t.Fatalf("expected exactly one prompt, got %d: %v", len(dms), dms)
+	}
+	if !strings.HasPrefix(dms[0], "owner1: ") || !strings.Contains(dms[0], "Fight Club") || !strings.Contains(dms[0], "/settings channel") {
+		t.Fatalf("unexpected prompt: %q", dms[0])
+	}
+
+	// Setting a channel clears the count, so a later misconfiguration prompts again.
+	st.UpdateGuildChannel(gid, "c1")
+	if n := st.GetGuildNoChannelDays(gid); n != 0 {
+		t.Fatalf("expected count reset by a channel, got %d", n)
+	}
+	st.UpdateGuildChannel(gid, "")
+	for i := 0; i < noChannelPromptDays; i++ {
+		promptMissingChannel(s, st, gid)
+	}
+	if len(dms) != 2 {
+		t.Fatalf("expected a new prompt after the channel was cleared again, got %d", len(dms))
+	}
+
+	// Notifications off: no counting, no prompt.
+	st.UpdateGuildNotifyEnabled("g2", false)
+	for i := 0; i < noChannelPromptDays; i++ {
+		promptMissingChannel(s, st, "g2")
+	}
+	if len(dms) != 2 || st.GetGuildNoChannelDays("g2") != 0 {
+		t.Fatalf("expected no prompt with notifications off, got %d", len(dms))
 	}
 }
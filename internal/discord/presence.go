@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+// staticPresence is the activity shown in static mode and when no event is known.
+const staticPresence = "/help"
+
+// presenceMaxLen is Discord's limit for an activity name.
+const presenceMaxLen = 128
+
+// updatePresence is an indirection so tests can capture presence updates.
+var updatePresence = func(s *discordgo.Session, text string) error {
+	return s.UpdateWatchStatus(0, text)
+}
+
+// updateBotPresence sets the bot's "Watching …" activity per cfg.PresenceMode:
+// a static hint, or the nearest upcoming event across guilds with
+// notifications on. Failures are logged; presence is cosmetic.
+func updateBotPresence(s *discordgo.Session, st *state.Store, mgr *sources.Manager, cfg config.Config) {
+	var text string
+	switch cfg.PresenceMode {
+	case config.PresenceStatic:
+		text = staticPresence
+	case config.PresenceNextEvent:
+		loc, err := time.LoadLocation(cfg.TZ)
+		if err != nil {
+			loc = time.UTC
+		}
+		text = presenceText(nearestActiveEvent(st, mgr), loc, time.Now())
+	default:
+		return
+	}
+	if err := updatePresence(s, text); err != nil {
+		logx.Warn("presence update failed", "text", text, "err", err)
+	}
+}
+
+// nearestActiveEvent returns the soonest upcoming event among the orgs that
+// guilds with notifications on follow, or nil when none is known. Each org's
+// provider is queried once.
+func nearestActiveEvent(st *state.Store, mgr *sources.Manager) *sources.Event {
+	cfgs, err := st.ListAllGuildConfigs()
+	if err != nil {
+		logx.Warn("presence: list guild configs failed", "err", err)
+		return nil
+	}
+	seen := map[string]bool{}
+	var best *sources.Event
+	var bestAt time.Time
+	for _, gc := range cfgs {
+		org := sources.NormalizeOrg(gc.Org)
+		if !gc.NotifyEnabled || org == "" || seen[org] {
+			continue
+		}
+		seen[org] = true
+		p, ok := mgr.Provider(org)
+		if !ok {
+			continue
+		}
+		evt, ok, err := pickNextEvent(context.Background(), p)
+		if err != nil || !ok {
+			continue
+		}
+		at, err := parseAPITime(evt.Start)
+		if err != nil {
+			continue
+		}
+		if best == nil || at.Before(bestAt) {
+			if evt.Org == "" {
+				evt.Org = org
+			}
+			best, bestAt = evt, at
+		}
+	}
+	return best
+}
+
+// presenceText renders the activity for an event, e.g. "UFC 300 · Sat 10:00 PM EDT",
+// or "UFC 300 · live now" once it has started. A nil or undated event yields the
+// static hint.
+func presenceText(evt *sources.Event, loc *time.Location, now time.Time) string {
+	if evt == nil {
+		return staticPresence
+	}
+	name := safe(evt.ShortName)
+	if name == "" {
+		name = safe(evt.Name)
+	}
+	if name == "" {
+		return staticPresence
+	}
+	when := "date TBA"
+	if at, err := parseAPITime(evt.Start); err == nil {
+		if at.After(now) {
+			when = at.In(loc).Format("Mon 3:04 PM MST")
+		} else {
+			when = "live now"
+		}
+	}
+	text := name + " · " + when
+	if utf8.RuneCountInString(text) > presenceMaxLen {
+		text = string([]rune(text)[:presenceMaxLen-1]) + "…"
+	}
+	return text
+}
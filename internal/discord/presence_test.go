@@ -0,0 +1,71 @@
+package discord
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+func TestPresenceText(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		evt  *sources.Event
+		want string
+	}{
+		{"no event", nil, "/help"},
+		{"upcoming", &sources.Event{Name: "UFC 300: Pereira vs. Hill", ShortName: "UFC 300", Start: "2024-04-14T02:00:00Z"}, "UFC 300 · Sat 10:00 PM EDT"},
+		{"name fallback", &sources.Event{Name: "UFC Fight Night", Start: "2024-04-14T02:00:00Z"}, "UFC Fight Night · Sat 10:00 PM EDT"},
+		{"started", &sources.Event{ShortName: "UFC 300", Start: "2024-04-10T11:00:00Z"}, "UFC 300 · live now"},
+		{"undated", &sources.Event{ShortName: "UFC 301"}, "UFC 301 · date TBA"},
+	}
+	for _, tc := range tests {
+		if got := presenceText(tc.evt, ny, now); got != tc.want {
+			t.Fatalf("%s: got %q want %q", tc.name, got, tc.want)
+		}
+	}
+	long := presenceText(&sources.Event{Name: strings.Repeat("x", 200)}, ny, now)
+	if n := len([]rune(long)); n != presenceMaxLen {
+		t.Fatalf("expected presence capped at %d characters, got %d", presenceMaxLen, n)
+	}
+}
+
+func TestUpdateBotPresence_NearestEventFromActiveGuilds(t *testing.T) {
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	st.UpdateGuildNotifyEnabled("g1", true)
+	st.UpdateGuildOrg("g2", "pfl") // notifications off: ignored
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &fakeProv{})
+	mgr.Register("pfl", &fakeProv{})
+
+	start := time.Now().UTC().Add(48 * time.Hour)
+	oldGet := getNextEventFunc
+	getNextEventFunc = func(_ context.Context, _ sources.Provider) (*sources.Event, bool, error) {
+		return &sources.Event{ShortName: "UFC 300", Start: start.Format(time.RFC3339)}, true, nil
+	}
+	defer func() { getNextEventFunc = oldGet }()
+	var got []string
+	old := updatePresence
+	updatePresence = func(_ *discordgo.Session, text string) error {
+		got = append(got, text)
+		return nil
+	}
+	defer func() { updatePresence = old }()
+
+	s := &discordgo.Session{}
+	updateBotPresence(s, st, mgr, config.Config{TZ: "UTC", PresenceMode: config.PresenceOff})
+	updateBotPresence(s, st, mgr, config.Config{TZ: "UTC", PresenceMode: config.PresenceStatic})
+	updateBotPresence(s, st, mgr, config.Config{TZ: "UTC", PresenceMode: config.PresenceNextEvent})
+	want := []string{"/help", "UFC 300 · " + start.Format("Mon 3:04 PM MST")}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
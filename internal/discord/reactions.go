@@ -0,0 +1,225 @@
+package discord
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+// statusToggle is a setting change applied by reacting to a status message.
+type statusToggle struct {
+	Setting string // "notifications" or "events"
+	Enabled bool
+	Label   string
+}
+
+// statusReactions lists the quick-toggle emoji in the order the bot adds them.
+var statusReactions = []struct {
+	Emoji  string
+	Toggle statusToggle
+}{
+	{"✅", statusToggle{Setting: "notifications", Enabled: true, Label: "notifications on"}},
+	{"🔕", statusToggle{Setting: "notifications", Enabled: false, Label: "notifications off"}},
+	{"📅", statusToggle{Setting: "events", Enabled: true, Label: "scheduled events on"}},
+	{"🚫", statusToggle{Setting: "events", Enabled: false, Label: "scheduled events off"}},
+}
+
+// toggleForEmoji maps a reaction emoji to its setting change.
+func toggleForEmoji(emoji string) (statusToggle, bool) {
+	for _, r := range statusReactions {
+		if r.Emoji == emoji {
+			return r.Toggle, true
+		}
+	}
+	return statusToggle{}, false
+}
+
+// statusReactionLegend explains the quick toggles under a status message.
+func statusReactionLegend() string {
+	parts := make([]string, 0, len(statusReactions))
+	for _, r := range statusReactions {
+		parts = append(parts, r.Emoji+" "+r.Toggle.Label)
+	}
+	return "React to change (Manage Channels required): " + strings.Join(parts, " · ")
+}
+
+// maxTrackedStatusMessages bounds the in-memory set of reaction-enabled status
+// messages; the oldest stop responding once the limit is reached.
+const maxTrackedStatusMessages = 100
+
+// statusMessages tracks the bot's reaction-enabled status messages (message ID ->
+// guild ID) so reactions elsewhere are ignored. It lives in memory, so messages
+// posted before a restart no longer respond.
+var statusMessages = struct {
+	sync.Mutex
+	guild map[string]string
+	order []string
+}{guild: map[string]string{}}
+
+func trackStatusMessage(messageID, guildID string) {
+	statusMessages.Lock()
+	defer statusMessages.Unlock()
+	if _, ok := statusMessages.guild[messageID]; !ok {
+		statusMessages.order = append(statusMessages.order, messageID)
+	}
+	statusMessages.guild[messageID] = guildID
+	for len(statusMessages.order) > maxTrackedStatusMessages {
+		delete(statusMessages.guild, statusMessages.order[0])
+		statusMessages.order = statusMessages.order[1:]
+	}
+}
+
+func trackedStatusGuild(messageID string) (string, bool) {
+	statusMessages.Lock()
+	defer statusMessages.Unlock()
+	gid, ok := statusMessages.guild[messageID]
+	return gid, ok
+}
+
+// postStatusWithReactions posts the status publicly in the current channel with
+// quick-toggle reactions, since ephemeral replies can't carry reactions.
+func postStatusWithReactions(s *discordgo.Session, ic *discordgo.InteractionCreate, text string) {
+	if !requireManageOrAdmin(s, ic, ic.ChannelID, "You need Manage Channels permission to post quick toggles.") {
+		return
+	}
+	msg, err := sendChannelMessageComplex(s, ic.ChannelID, &discordgo.MessageSend{Content: text + "\n\n" + statusReactionLegend(), AllowedMentions: allowedMentions()})
+	if err != nil || msg == nil {
+		logx.Warn("status post failed", "guild_id", ic.GuildID, "channel_id", ic.ChannelID, "err", err)
+		replyEphemeral(s, ic, "Could not post the status message here.")
+		return
+	}
+	trackStatusMessage(msg.ID, ic.GuildID)
+	for _, r := range statusReactions {
+		if err := addMessageReaction(s, ic.ChannelID, msg.ID, r.Emoji); err != nil {
+			logx.Warn("status reaction add failed", "guild_id", ic.GuildID, "message_id", msg.ID, "emoji", r.Emoji, "err", err)
+		}
+	}
+	replyEphemeral(s, ic, "Status posted with quick toggles.")
+}
+
+// maxResultsReactions caps /settings embed results-reactions.
+const maxResultsReactions = 5
+
+// defaultResultsReactions is the set /settings embed results-reactions on selects.
+var defaultResultsReactions = []string{"🏆", "🔥", "👏"}
+
+// parseResultsReactions parses a /settings embed results-reactions value: "on"
+// selects the default set, "off" clears it, and anything else is a list of up
+// to maxResultsReactions comma-separated emoji (custom emoji as name:id).
+func parseResultsReactions(s string) (emojis []string, ok bool) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "on":
+		return defaultResultsReactions, true
+	case "off", "":
+		return nil, true
+	}
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" || strings.ContainsAny(p, " <>") || utf8.RuneCountInString(p) > maxResultEmojiLen {
+			return nil, false
+		}
+		if !slices.Contains(emojis, p) {
+			emojis = append(emojis, p)
+		}
+	}
+	if len(emojis) > maxResultsReactions {
+		return nil, false
+	}
+	return emojis, true
+}
+
+// resultsReactionsText renders the setting for replies.
+func resultsReactionsText(emojis []string) string {
+	if len(emojis) == 0 {
+		return "off"
+	}
+	return strings.Join(emojis, " ")
+}
+
+// postResultsWithReactions posts an event's results to the guild's alert
+// channel and adds the guild's results reactions for members to react with.
+// It posts once per event (deduped like the notifier's posts) and only when
+// reactions are set and the org's provider reports results, so asking for
+// results again doesn't repost. It returns the channel posted to.
+func postResultsWithReactions(s *discordgo.Session, st *state.Store, mgr *sources.Manager, guildID, org string, loc *time.Location, evt *sources.Event, text string, emb *discordgo.MessageEmbed) (string, bool) {
+	emojis := st.GetGuildResultsReactions(guildID)
+	if len(emojis) == 0 || !mgr.Capabilities(org).HasResults {
+		return "", false
+	}
+	channelID := alertChannel(st, guildID)
+	start, err := parseAPITime(evt.Start)
+	if channelID == "" || err != nil {
+		return "", false
+	}
+	key := state.DedupKey(org, state.PostResults, start.In(loc).Format("2006-01-02"))
+	if st.HasPosted(guildID, key) {
+		return "", false
+	}
+	claimed := claimPost(st, guildID, key)
+	send := &discordgo.MessageSend{Content: text, AllowedMentions: allowedMentions()}
+	if emb != nil {
+		send.Embeds = []*discordgo.MessageEmbed{emb}
+	}
+	msg, err := sendChannelMessageComplex(s, channelID, send)
+	if err != nil || msg == nil {
+		logx.Warn("results post failed", "guild_id", guildID, "channel_id", channelID, "err", err)
+		if claimed {
+			releasePost(st, guildID, key)
+		}
+		return "", false
+	}
+	for _, e := range emojis {
+		if err := addMessageReaction(s, channelID, msg.ID, e); err != nil {
+			logx.Warn("results reaction add failed", "guild_id", guildID, "message_id", msg.ID, "emoji", e, "err", err)
+		}
+	}
+	return channelID, true
+}
+
+// handleStatusReaction applies a quick toggle when someone with Manage Channels
+// reacts to one of the bot's tracked status messages, then refreshes the message.
+func handleStatusReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd, st *state.Store, cfg config.Config) {
+	if r == nil || r.MessageReaction == nil {
+		return
+	}
+	if s.State != nil && s.State.User != nil && r.UserID == s.State.User.ID {
+		return // the bot's own reactions
+	}
+	gid, ok := trackedStatusGuild(r.MessageID)
+	if !ok || gid != r.GuildID {
+		return
+	}
+	toggle, ok := toggleForEmoji(r.Emoji.Name)
+	if !ok {
+		return
+	}
+	allowed, err := reactorHasManage(s, r.UserID, r.ChannelID)
+	if err != nil || !allowed {
+		logx.Debug("status reaction ignored; missing permission", "guild_id", gid, "user_id", r.UserID, "err", err)
+		return
+	}
+	switch toggle.Setting {
+	case "notifications":
+		// Same rule as /settings notifications: an org must be chosen first.
+		if toggle.Enabled && !st.HasGuildOrg(gid) {
+			logx.Debug("status reaction ignored; org not set", "guild_id", gid)
+			return
+		}
+		st.UpdateGuildNotifyEnabled(gid, toggle.Enabled)
+	case "events":
+		st.UpdateGuildEventsEnabled(gid, toggle.Enabled)
+	}
+	logx.Info("status reaction applied", "guild_id", gid, "user_id", r.UserID, "setting", toggle.Setting, "enabled", toggle.Enabled)
+	if err := editChannelMessage(s, r.ChannelID, r.MessageID, statusText(st, cfg, gid)+"\n\n"+statusReactionLegend()); err != nil {
+		logx.Warn("status refresh failed", "guild_id", gid, "message_id", r.MessageID, "err", err)
+	}
+}
@@ -0,0 +1,154 @@
+package discord
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+func TestToggleForEmoji_Mapping(t *testing.T) {
+	tests := []struct {
+		emoji   string
+		setting string
+		enabled bool
+	}{
+		{"✅", "notifications", true},
+		{"🔕", "notifications", false},
+		{"📅", "events", true},
+		{"🚫", "events", false},
+	}
+	for _, tc := range tests {
+		got, ok := toggleForEmoji(tc.emoji)
+		if !ok || got.Setting != tc.setting || got.Enabled != tc.enabled {
+			t.Fatalf("%s: got %+v ok=%v", tc.emoji, got, ok)
+		}
+	}
+	if _, ok := toggleForEmoji("👍"); ok {
+		t.Fatalf("expected unmapped emoji to be ignored")
+	}
+}
+
+func TestHandleStatusReaction_PermissionAndTracking(t *testing.T) {
+	st := state.Load(":memory:")
+	st.UpdateGuildOrg("g1", "ufc")
+	cfg := config.Config{TZ: "UTC", RunAt: "16:00"}
+
+	allowed := map[string]bool{"mod": true}
+	oldPerm, oldEdit := reactorHasManage, editChannelMessage
+	reactorHasManage = func(_ *discordgo.Session, userID, _ string) (bool, error) { return allowed[userID], nil }
+	var edited string
+	editChannelMessage = func(_ *discordgo.Session, _, _, content string) error {
+		edited = content
+		return nil
+	}
+	defer func() { reactorHasManage, editChannelMessage = oldPerm, oldEdit }()
+
+	trackStatusMessage("m1", "g1")
+	react := func(userID, messageID, emoji string) {
+		handleStatusReaction(&discordgo.Session{}, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+			UserID: userID, MessageID: messageID, ChannelID: "c1", GuildID: "g1", Emoji: discordgo.Emoji{Name: emoji},
+		}}, st, cfg)
+	}
+
+	// Without Manage Channels: no change.
+	react("member", "m1", "✅")
+	if st.GetGuildNotifyEnabled("g1") || edited != "" {
+		t.Fatalf("expected reaction without permission to be ignored")
+	}
+	// Untracked message: no change.
+	react("mod", "other", "✅")
+	if st.GetGuildNotifyEnabled("g1") {
+		t.Fatalf("expected reaction on untracked message to be ignored")
+	}
+	// With permission: applied and the status refreshed.
+	react("mod", "m1", "✅")
+	if !st.GetGuildNotifyEnabled("g1") {
+		t.Fatalf("expected notifications enabled")
+	}
+	if !strings.Contains(edited, "Notifications: on") {
+		t.Fatalf("expected refreshed status, got %q", edited)
+	}
+	react("mod", "m1", "📅")
+	if !st.GetGuildEventsEnabled("g1") {
+		t.Fatalf("expected scheduled events enabled")
+	}
+}
+
+// resultsProv is a fakeProv that declares results support.
+type resultsProv struct{ fakeProv }
+
+func (*resultsProv) Capabilities() sources.Capabilities {
+	return sources.Capabilities{HasCards: true, HasResults: true}
+}
+
+func TestPostResultsWithReactions_OncePerEvent(t *testing.T) {
+	st := state.Load(":memory:")
+	st.UpdateGuildChannel("g1", "main")
+	st.UpdateGuildAlertChannel("g1", "alerts")
+	mgr := sources.NewManager()
+	mgr.Register("ufc", &resultsProv{})
+	mgr.Register("pfl", &fakeProv{})
+	evt := &sources.Event{Org: "ufc", Name: "UFC 300", Start: "2024-04-14T02:00:00Z"}
+
+	var posted []string
+	var reactions []string
+	oldSend, oldReact := sendChannelMessageComplex, addMessageReaction
+	sendChannelMessageComplex = func(_ *discordgo.Session, channelID string, m *discordgo.MessageSend) (*discordgo.Message, error) {
+		posted = append(posted, channelID+": "+m.Content)
+		return &discordgo.Message{ID: "m1", ChannelID: channelID}, nil
+	}
+	addMessageReaction = func(_ *discordgo.Session, channelID, messageID, emoji string) error {
+		reactions = append(reactions, channelID+"/"+messageID+" "+emoji)
+		return nil
+	}
+	defer func() { sendChannelMessageComplex, addMessageReaction = oldSend, oldReact }()
+	post := func(org string) bool {
+		_, ok := postResultsWithReactions(&discordgo.Session{}, st, mgr, "g1", org, time.UTC, evt, "Latest UFC results: UFC 300", nil)
+		return ok
+	}
+
+	if post("ufc") || len(posted) != 0 {
+		t.Fatalf("expected no post while reactions are off, got %q", posted)
+	}
+	st.UpdateGuildResultsReactions("g1", []string{"🏆", "🔥"})
+	if post("pfl") || len(posted) != 0 {
+		t.Fatalf("expected no post for an org without results, got %q", posted)
+	}
+	if !post("ufc") || post("ufc") {
+		t.Fatalf("expected exactly one post for the event, got %q", posted)
+	}
+	if !reflect.DeepEqual(posted, []string{"alerts: Latest UFC results: UFC 300"}) {
+		t.Fatalf("got posts %q", posted)
+	}
+	if !reflect.DeepEqual(reactions, []string{"alerts/m1 🏆", "alerts/m1 🔥"}) {
+		t.Fatalf("got reactions %q", reactions)
+	}
+}
+
+func TestParseResultsReactions(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+		ok   bool
+	}{
+		{"on", defaultResultsReactions, true},
+		{"off", nil, true},
+		{"🏆, 🔥,🏆", []string{"🏆", "🔥"}, true},
+		{"party:123456", []string{"party:123456"}, true},
+		{"🏆,,🔥", nil, false},
+		{"<a:x:1>", nil, false},
+		{"1,2,3,4,5,6", nil, false},
+	}
+	for _, tc := range tests {
+		got, ok := parseResultsReactions(tc.in)
+		if ok != tc.ok || !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("parseResultsReactions(%q) = %q, %v want %q, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
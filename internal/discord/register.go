@@ -1,8 +1,20 @@
 package discord
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 
@@ -12,7 +24,7 @@ import (
 	"github.com/zodakzach/fight-night-discord-bot/internal/state"
 )
 
-func RegisterCommands(s *discordgo.Session, devGuild string, mgr *sources.Manager) {
+func RegisterCommands(s *discordgo.Session, devGuilds []string, mgr *sources.Manager) {
 	// Rebuild specs with dynamic org choices from the manager
 	orgs := []string{"ufc"}
 	if mgr != nil {
@@ -24,48 +36,31 @@ func RegisterCommands(s *discordgo.Session, devGuild string, mgr *sources.Manage
 	// Define top-level commands from centralized specs
 	cmds := applicationCommands()
 
-	// Dev-only parent command with subcommands
-	devTest := &discordgo.ApplicationCommand{
-		Name:        "dev-test",
-		Description: "[dev] Tools for testing",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionSubCommand,
-				Name:        "create-event",
-				Description: "Create a scheduled event for the next org event",
-			},
-			{
-				Type:        discordgo.ApplicationCommandOptionSubCommand,
-				Name:        "create-announcement",
-				Description: "Post the next event message+embed now",
-			},
-		},
-	}
-
 	appID := s.State.User.ID
 	// Log the intent to register commands with context
 	names := make([]string, 0, len(cmds))
 	for _, c := range cmds {
 		names = append(names, c.Name)
 	}
-	if devGuild != "" {
-		// Include the dev-only command only for the dev guild registration.
-		cmdsWithDev := make([]*discordgo.ApplicationCommand, 0, len(cmds)+1)
-		cmdsWithDev = append(cmdsWithDev, cmds...)
-		cmdsWithDev = append(cmdsWithDev, devTest)
-		logx.Info("registering slash commands", "target", "guild", "app_id", appID, "guild_id", devGuild, "count", len(cmds), "names", names)
-		res, err := s.ApplicationCommandBulkOverwrite(appID, devGuild, cmdsWithDev)
-		if err != nil {
-			logx.Error("bulk overwrite commands", "err", err, "target", "guild", "app_id", appID, "guild_id", devGuild)
-			return
-		}
-		registered := make([]string, 0, len(res))
-		for _, c := range res {
-			registered = append(registered, c.Name)
+	if len(devGuilds) > 0 {
+		// Include the dev-only command only for the dev guild registrations.
+		cmdsWithDev := devGuildCommands(cmds)
+		for _, devGuild := range devGuilds {
+			logx.Info("registering slash commands", "target", "guild", "app_id", appID, "guild_id", devGuild, "count", len(cmds), "names", names)
+			res, err := s.ApplicationCommandBulkOverwrite(appID, devGuild, cmdsWithDev)
+			if err != nil {
+				// Keep going so one bad guild ID doesn't block the others.
+				logx.Error("bulk overwrite commands", "err", err, "target", "guild", "app_id", appID, "guild_id", devGuild)
+				continue
+			}
+			registered := make([]string, 0, len(res))
+			for _, c := range res {
+				registered = append(registered, c.Name)
+			}
+			logx.Info("commands registered", "target", "guild", "guild_id", devGuild, "count", len(res), "names", registered)
 		}
-		logx.Info("commands registered", "target", "guild", "count", len(res), "names", registered)
 
-		// Clear global commands to avoid duplicates while developing with a dev guild.
+		// Clear global commands to avoid duplicates while developing with dev guilds.
 		logx.Info("clearing global commands due to dev guild configuration", "app_id", appID)
 		if _, err := s.ApplicationCommandBulkOverwrite(appID, "", []*discordgo.ApplicationCommand{}); err != nil {
 			logx.Warn("failed clearing global commands", "err", err, "app_id", appID)
@@ -88,50 +83,591 @@ func RegisterCommands(s *discordgo.Session, devGuild string, mgr *sources.Manage
 	}
 	logx.Info("commands registered", "target", "global", "count", len(res), "names", registered)
 
-	// Clear guild-scoped commands to avoid guild+global duplicates.
-	if strings.TrimSpace(devGuild) != "" {
-		logx.Info("clearing dev guild commands due to global registration", "app_id", appID, "guild_id", devGuild)
-		if _, err := s.ApplicationCommandBulkOverwrite(appID, devGuild, []*discordgo.ApplicationCommand{}); err != nil {
-			logx.Warn("failed clearing dev guild commands", "err", err, "app_id", appID, "guild_id", devGuild)
+	// No dev guild configured; sweep all guilds to ensure no leftover guild-scoped
+	// commands remain that would duplicate the newly-registered global commands.
+	clearAllGuildCommands(s, appID)
+}
+
+// devTestCommand is the dev-only parent command with subcommands.
+func devTestCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "dev-test",
+		Description: "[dev] Tools for testing",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "create-event",
+				Description: "Create a scheduled event for the next org event",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "create-announcement",
+				Description: "Post the next event message+embed now",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "sync-commands",
+				Description: "Re-register slash commands and report what changed (admin)",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reload-config",
+				Description: "Re-read RUN_AT, TZ, and MAINTENANCE from the environment (owner)",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "info",
+				Description: "Show the bot's runtime config, including maintenance mode",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "copy-settings",
+				Description: "Copy another server's settings into this one (owner or admin)",
+				Options: []*discordgo.ApplicationCommandOption{{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "from",
+					Description: "Source server (guild) ID",
+					Required:    true,
+				}},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "export-history",
+				Description: "Download every server's post history as CSV (owner)",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "broadcast",
+				Description: "Post an operator notice to every server's alert channel (owner)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "message",
+						Description: "Notice text",
+						Required:    true,
+						MaxLength:   maxBroadcastLen,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "critical",
+						Description: "Also post to servers that opted out of broadcasts (default false)",
+						Required:    false,
+					},
+				},
+			},
+		},
+	}
+}
+
+// devGuildCommands returns cmds plus the dev-only commands registered in the dev guild.
+func devGuildCommands(cmds []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	out := make([]*discordgo.ApplicationCommand, 0, len(cmds)+1)
+	out = append(out, cmds...)
+	return append(out, devTestCommand())
+}
+
+// commandDiff lists command names that an overwrite would create, update, or delete.
+type commandDiff struct {
+	Created []string
+	Updated []string
+	Deleted []string
+}
+
+// Empty reports whether the overwrite would change nothing.
+func (d commandDiff) Empty() bool {
+	return len(d.Created) == 0 && len(d.Updated) == 0 && len(d.Deleted) == 0
+}
+
+// String renders the diff for a chat reply.
+func (d commandDiff) String() string {
+	if d.Empty() {
+		return "No changes; commands already in sync."
+	}
+	var b strings.Builder
+	for _, part := range []struct {
+		label string
+		names []string
+	}{{"Created", d.Created}, {"Updated", d.Updated}, {"Deleted", d.Deleted}} {
+		if len(part.names) > 0 {
+			fmt.Fprintf(&b, "%s: %s\n", part.label, strings.Join(part.names, ", "))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// diffCommands compares the commands currently registered with Discord against the
+// desired set, matching by name and comparing signatures (type, description,
+// options, and default permissions). Names are sorted for stable output.
+func diffCommands(current, desired []*discordgo.ApplicationCommand) commandDiff {
+	cur := make(map[string]string, len(current))
+	for _, c := range current {
+		cur[c.Name] = commandSignature(c)
+	}
+	var d commandDiff
+	seen := make(map[string]bool, len(desired))
+	for _, c := range desired {
+		seen[c.Name] = true
+		sig, ok := cur[c.Name]
+		switch {
+		case !ok:
+			d.Created = append(d.Created, c.Name)
+		case sig != commandSignature(c):
+			d.Updated = append(d.Updated, c.Name)
+		}
+	}
+	for _, c := range current {
+		if !seen[c.Name] {
+			d.Deleted = append(d.Deleted, c.Name)
+		}
+	}
+	sort.Strings(d.Created)
+	sort.Strings(d.Updated)
+	sort.Strings(d.Deleted)
+	return d
+}
+
+// commandSignature renders the user-visible shape of a command as canonical JSON.
+// Server-assigned fields (IDs, version) are excluded, and empty/zero values are
+// pruned so a locally built definition matches what Discord echoes back.
+func commandSignature(c *discordgo.ApplicationCommand) string {
+	typ := c.Type
+	if typ == 0 {
+		typ = discordgo.ChatApplicationCommand
+	}
+	raw, err := json.Marshal(struct {
+		Type                     discordgo.ApplicationCommandType      `json:"type"`
+		Description              string                                `json:"description"`
+		Options                  []*discordgo.ApplicationCommandOption `json:"options"`
+		DefaultMemberPermissions *int64                                `json:"default_member_permissions,string,omitempty"`
+	}{typ, c.Description, c.Options, c.DefaultMemberPermissions})
+	if err != nil {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return ""
+	}
+	out, _ := json.Marshal(pruneEmpty(v))
+	return string(out)
+}
+
+// pruneEmpty drops null, false, zero, and empty values from decoded JSON objects.
+func pruneEmpty(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			val = pruneEmpty(val)
+			switch x := val.(type) {
+			case nil:
+				continue
+			case bool:
+				if !x {
+					continue
+				}
+			case string:
+				if x == "" {
+					continue
+				}
+			case float64:
+				if x == 0 {
+					continue
+				}
+			case []any:
+				if len(x) == 0 {
+					continue
+				}
+			case map[string]any:
+				if len(x) == 0 {
+					continue
+				}
+			}
+			out[k] = val
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, val := range t {
+			out[i] = pruneEmpty(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// handleSyncCommands re-registers the invoking dev guild's commands and replies
+// with what changed compared to Discord's current set. Administrator only.
+func handleSyncCommands(s *discordgo.Session, ic *discordgo.InteractionCreate, cfg config.Config, mgr *sources.Manager) {
+	if ic.Member == nil || (ic.Member.Permissions&discordgo.PermissionAdministrator) == 0 {
+		replyEphemeral(s, ic, "You need Administrator to use this (dev).")
+		return
+	}
+	if len(cfg.DevGuilds) == 0 {
+		replyEphemeral(s, ic, "Sync is only available when GUILD_ID is set.")
+		return
+	}
+	if !slices.Contains(cfg.DevGuilds, ic.GuildID) {
+		replyEphemeral(s, ic, "This server is not listed in GUILD_ID.")
+		return
+	}
+	devGuild := ic.GuildID
+	_ = deferInteractionResponse(s, ic)
+
+	orgs := []string{"ufc"}
+	if mgr != nil {
+		if o := mgr.Orgs(); len(o) > 0 {
+			orgs = o
+		}
+	}
+	currentSpecs = commandSpecs(orgs)
+	desired := devGuildCommands(applicationCommands())
+
+	appID := s.State.User.ID
+	current, err := s.ApplicationCommands(appID, devGuild)
+	if err != nil {
+		logx.Warn("sync commands: list failed", "guild_id", devGuild, "err", err)
+		_ = editInteractionResponse(s, ic, "Could not fetch current commands: "+err.Error())
+		return
+	}
+	diff := diffCommands(current, desired)
+	res, err := s.ApplicationCommandBulkOverwrite(appID, devGuild, desired)
+	if err != nil {
+		logx.Error("bulk overwrite commands", "err", err, "target", "guild", "app_id", appID, "guild_id", devGuild)
+		_ = editInteractionResponse(s, ic, diff.String()+"\n\nOverwrite failed: "+err.Error())
+		return
+	}
+	logx.Info("commands synced", "guild_id", devGuild, "count", len(res), "created", diff.Created, "updated", diff.Updated, "deleted", diff.Deleted)
+	_ = editInteractionResponse(s, ic, fmt.Sprintf("%s\n\nOverwrite complete (%d commands).", diff, len(res)))
+}
+
+// isBotOwner reports whether the interaction was sent by the OWNER_ID user, from
+// a server (ic.Member) or a DM (ic.User). It is false when OWNER_ID is unset.
+func isBotOwner(ic *discordgo.InteractionCreate, cfg config.Config) bool {
+	if cfg.OwnerID == "" || ic == nil || ic.Interaction == nil {
+		return false
+	}
+	if ic.Member != nil && ic.Member.User != nil {
+		return ic.Member.User.ID == cfg.OwnerID
+	}
+	return ic.User != nil && ic.User.ID == cfg.OwnerID
+}
+
+// handleReloadConfig re-reads the hot-reloadable config fields into the running
+// config and replies with what changed. Restricted to the OWNER_ID user.
+func handleReloadConfig(s *discordgo.Session, ic *discordgo.InteractionCreate, cfg config.Config, holder *config.Holder) {
+	if cfg.OwnerID == "" {
+		replyEphemeral(s, ic, "Config reload is disabled; set OWNER_ID to enable it.")
+		return
+	}
+	if !isBotOwner(ic, cfg) {
+		replyEphemeral(s, ic, "Only the bot owner can reload config.")
+		return
+	}
+	if holder == nil {
+		replyEphemeral(s, ic, "Config reload is not available.")
+		return
+	}
+	prev, next, err := holder.Reload()
+	if err != nil {
+		replyEphemeral(s, ic, "Config not reloaded: "+err.Error())
+		return
+	}
+	var b strings.Builder
+	b.WriteString("Config reloaded.")
+	for _, f := range []struct{ name, prev, next string }{
+		{"RUN_AT", prev.RunAt, next.RunAt},
+		{"TZ", prev.TZ, next.TZ},
+		{"MAINTENANCE", onOff(prev.Maintenance), onOff(next.Maintenance)},
+	} {
+		if f.prev == f.next {
+			fmt.Fprintf(&b, "\n%s: %s (unchanged)", f.name, f.next)
 		} else {
-			logx.Info("dev guild commands cleared", "guild_id", devGuild)
+			fmt.Fprintf(&b, "\n%s: %s -> %s", f.name, f.prev, f.next)
 		}
-	} else {
-		// No dev guild configured; sweep all guilds to ensure no leftover guild-scoped
-		// commands remain that would duplicate the newly-registered global commands.
-		clearAllGuildCommands(s, appID)
 	}
+	b.WriteString("\nToken, DB_FILE, GUILD_ID, USER_AGENT, and backup settings require a restart.")
+	replyEphemeral(s, ic, b.String())
+}
+
+// handleExportHistory replies with every guild's retained post history as a
+// CSV attachment, for offline analysis. Restricted to the OWNER_ID user.
+func handleExportHistory(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
+	if cfg.OwnerID == "" {
+		replyEphemeral(s, ic, "History export is disabled; set OWNER_ID to enable it.")
+		return
+	}
+	if !isBotOwner(ic, cfg) {
+		replyEphemeral(s, ic, "Only the bot owner can export post history.")
+		return
+	}
+	var buf bytes.Buffer
+	n, err := writeHistoryCSV(&buf, st)
+	if err != nil {
+		logx.Error("export history", "err", err)
+		replyEphemeral(s, ic, "Could not export post history: "+err.Error())
+		return
+	}
+	name := "post-history-" + time.Now().UTC().Format("20060102") + ".csv"
+	if err := sendInteractionFile(s, ic, fmt.Sprintf("Post history: %d rows.", n), name, "text/csv", &buf); err != nil {
+		logx.Warn("export history: send failed", "err", err)
+	}
+}
+
+// historyCSVHeader is the first row of /dev-test export-history files.
+var historyCSVHeader = []string{"guild_id", "org", "kind", "date", "message_id", "posted_at"}
+
+// writeHistoryCSV streams all retained post history to w as CSV, one row per
+// post; kind is "alert" for fight-night alerts. It returns the row count.
+func writeHistoryCSV(w io.Writer, st *state.Store) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(historyCSVHeader); err != nil {
+		return 0, err
+	}
+	n := 0
+	err := st.EachPostRecord(func(guildID string, r state.PostRecord) error {
+		org, kind, _ := strings.Cut(r.Org, ":")
+		if kind == "" {
+			kind = "alert"
+		}
+		postedAt := ""
+		if !r.PostedAt.IsZero() {
+			postedAt = r.PostedAt.UTC().Format(time.RFC3339)
+		}
+		n++
+		return cw.Write([]string{guildID, org, kind, r.Date, r.MessageID, postedAt})
+	})
+	if err != nil {
+		return n, err
+	}
+	cw.Flush()
+	return n, cw.Error()
+}
+
+// HistoryExportHandler serves the post history CSV (as /dev-test export-history
+// builds it) for download from the metrics server. Requests must send
+// "Authorization: Bearer <token>" with the OWNER_TOKEN value; an empty token
+// refuses every request.
+func HistoryExportHandler(st *state.Store, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		name := "post-history-" + time.Now().UTC().Format("20060102") + ".csv"
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+		// Rows stream straight to the client; a failure midway can only be logged.
+		if n, err := writeHistoryCSV(w, st); err != nil {
+			logx.Error("export history over http", "rows", n, "err", err)
+		}
+	})
+}
+
+// maxBroadcastLen caps /dev-test broadcast text.
+const maxBroadcastLen = 1500
+
+// broadcastTooLong reports whether text exceeds maxBroadcastLen, counting
+// characters as Discord does rather than bytes.
+func broadcastTooLong(text string) bool {
+	return utf8.RuneCountInString(text) > maxBroadcastLen
+}
+
+// handleBroadcast posts an operator notice to every configured guild and
+// reports how it went. Restricted to the OWNER_ID user.
+func handleBroadcast(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
+	if cfg.OwnerID == "" {
+		replyEphemeral(s, ic, "Broadcasts are disabled; set OWNER_ID to enable them.")
+		return
+	}
+	if !isBotOwner(ic, cfg) {
+		replyEphemeral(s, ic, "Only the bot owner can broadcast.")
+		return
+	}
+	text, critical := "", false
+	if opts := ic.ApplicationCommandData().Options; len(opts) > 0 {
+		for _, o := range opts[0].Options {
+			switch o.Name {
+			case "message":
+				text = strings.TrimSpace(o.StringValue())
+			case "critical":
+				critical = o.BoolValue()
+			}
+		}
+	}
+	if text == "" || broadcastTooLong(text) {
+		replyEphemeral(s, ic, fmt.Sprintf("Broadcast text must be 1-%d characters.", maxBroadcastLen))
+		return
+	}
+	// One send per guild can outlast the 3 second reply window.
+	if err := deferInteractionResponse(s, ic); err != nil {
+		logx.Warn("broadcast: defer failed", "err", err)
+		return
+	}
+	sent, skipped, failed := broadcastMessage(s, st, text, critical)
+	_ = editInteractionResponse(s, ic, fmt.Sprintf("Broadcast sent to %d servers (%d skipped: opted out or no channel, %d failed).", sent, skipped, failed))
+}
+
+// broadcastMessage posts an operator notice to each guild's alert channel.
+// Guilds without a channel are skipped, as are guilds that opted out with
+// /settings extra-posts broadcast-opt-out unless the notice is critical.
+func broadcastMessage(s *discordgo.Session, st *state.Store, text string, critical bool) (sent, skipped, failed int) {
+	cfgs, err := st.ListAllGuildConfigs()
+	if err != nil {
+		logx.Error("broadcast: list guilds", "err", err)
+		return 0, 0, 0
+	}
+	msg := "📢 Bot notice: " + text
+	if critical {
+		msg = "⚠️ Important bot notice: " + text
+	}
+	for _, c := range cfgs {
+		channelID := c.AlertChannelID
+		if channelID == "" {
+			channelID = c.ChannelID
+		}
+		if channelID == "" || (c.BroadcastOptOut && !critical) {
+			skipped++
+			continue
+		}
+		if _, err := sendChannelMessageComplex(s, channelID, &discordgo.MessageSend{Content: msg, AllowedMentions: allowedMentions()}); err != nil {
+			logx.Warn("broadcast send failed", "guild_id", c.GuildID, "channel_id", channelID, "err", err)
+			failed++
+			continue
+		}
+		sent++
+	}
+	logx.Info("broadcast sent", "critical", critical, "sent", sent, "skipped", skipped, "failed", failed)
+	return sent, skipped, failed
+}
+
+// handleCopySettings copies settings from the guild given by the "from" option
+// into the invoking guild, for server migrations. Restricted to the OWNER_ID
+// user or members with Administrator.
+func handleCopySettings(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config) {
+	isOwner := isBotOwner(ic, cfg)
+	if !isOwner && (ic.Member == nil || ic.Member.User == nil || (ic.Member.Permissions&discordgo.PermissionAdministrator) == 0) {
+		replyEphemeral(s, ic, "Only the bot owner or an Administrator can copy settings.")
+		return
+	}
+	var src string
+	if opts := ic.ApplicationCommandData().Options; len(opts) > 0 && len(opts[0].Options) > 0 {
+		src = strings.TrimSpace(opts[0].Options[0].StringValue())
+	}
+	if src == "" {
+		replyEphemeral(s, ic, "Usage: /dev-test copy-settings from:<guild_id>")
+		return
+	}
+	if src == ic.GuildID {
+		replyEphemeral(s, ic, "The source server is this server; nothing to copy.")
+		return
+	}
+	// Settings are only readable by whoever administers the source server too.
+	if !isOwner {
+		ok, err := isGuildAdmin(s, src, ic.Member.User.ID)
+		if err != nil {
+			logx.Warn("copy settings: source permission check failed", "src", src, "guild_id", ic.GuildID, "err", err)
+		}
+		if !ok {
+			replyEphemeral(s, ic, "You need Administrator in server "+src+" to copy its settings.")
+			return
+		}
+	}
+	if err := st.CopyGuild(src, ic.GuildID); err != nil {
+		if errors.Is(err, state.ErrGuildNotFound) {
+			replyEphemeral(s, ic, "No settings found for server "+src+".")
+			return
+		}
+		logx.Error("copy settings", "src", src, "guild_id", ic.GuildID, "err", err)
+		replyEphemeral(s, ic, "Could not copy settings: "+err.Error())
+		return
+	}
+	logx.Info("settings copied", "src", src, "guild_id", ic.GuildID)
+	replyEphemeral(s, ic, "Copied settings from server "+src+". Channels and the subscriber role are server-specific; set them with /settings channel, alert-channel, event-channel, and subscriber-role.")
+}
+
+// handleDevInfo reports the running config that affects posting.
+func handleDevInfo(s *discordgo.Session, ic *discordgo.InteractionCreate, cfg config.Config) {
+	var b strings.Builder
+	b.WriteString("Bot info:")
+	fmt.Fprintf(&b, "\nMaintenance: %s", onOff(cfg.Maintenance))
+	if cfg.Maintenance {
+		b.WriteString(" (notifier ticks run but nothing is posted)")
+	}
+	fmt.Fprintf(&b, "\nRUN_AT: %s", cfg.RunAt)
+	fmt.Fprintf(&b, "\nTZ: %s", cfg.TZ)
+	fmt.Fprintf(&b, "\nDefault delivery: %s", cfg.DefaultDelivery)
+	fmt.Fprintf(&b, "\nDev guilds: %d", len(cfg.DevGuilds))
+	replyEphemeral(s, ic, b.String())
 }
 
 // clearAllGuildCommands clears guild-scoped application commands for all guilds
 // in the current session state. Safe to call in prod after registering global commands.
 func clearAllGuildCommands(s *discordgo.Session, appID string) {
 	for _, g := range s.State.Guilds {
-		gid := g.ID
-		// Best-effort: list commands to log names; proceed even if list fails.
-		names := []string{}
-		if cmds, err := s.ApplicationCommands(appID, gid); err == nil {
-			for _, c := range cmds {
-				names = append(names, c.Name)
-			}
-		}
-		logx.Info("clearing guild commands", "guild_id", gid, "names", names)
-		if _, err := s.ApplicationCommandBulkOverwrite(appID, gid, []*discordgo.ApplicationCommand{}); err != nil {
-			logx.Warn("failed clearing guild commands", "guild_id", gid, "err", err)
-		} else {
-			logx.Info("guild commands cleared", "guild_id", gid)
+		clearCommands(s, appID, g.ID)
+	}
+}
+
+// UnregisterAllCommands overwrites the global and each dev guild's command set
+// with nothing, so no stale commands linger after a development run exits.
+func UnregisterAllCommands(s *discordgo.Session, devGuilds []string) {
+	if s.State == nil || s.State.User == nil {
+		logx.Warn("cannot unregister commands: session has no user")
+		return
+	}
+	appID := s.State.User.ID
+	clearCommands(s, appID, "")
+	for _, gid := range devGuilds {
+		clearCommands(s, appID, gid)
+	}
+}
+
+// clearCommands bulk-overwrites the command set for a guild (or globally when
+// guildID is empty) with nothing, logging the names it removes.
+func clearCommands(s *discordgo.Session, appID, guildID string) {
+	target := "guild"
+	if guildID == "" {
+		target = "global"
+	}
+	// Best-effort: list commands to log names; proceed even if list fails.
+	names := []string{}
+	if cmds, err := s.ApplicationCommands(appID, guildID); err == nil {
+		for _, c := range cmds {
+			names = append(names, c.Name)
 		}
 	}
+	logx.Info("clearing commands", "target", target, "guild_id", guildID, "names", names)
+	if _, err := s.ApplicationCommandBulkOverwrite(appID, guildID, []*discordgo.ApplicationCommand{}); err != nil {
+		logx.Warn("failed clearing commands", "target", target, "guild_id", guildID, "err", err)
+	} else {
+		logx.Info("commands cleared", "target", target, "guild_id", guildID)
+	}
 }
 
-func BindHandlers(s *discordgo.Session, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+// liveConfig is the shared config set by BindHandlers; /dev-test reload-config
+// swaps its hot-reloadable fields in place.
+var liveConfig *config.Holder
+
+func BindHandlers(s *discordgo.Session, st *state.Store, cfg *config.Holder, mgr *sources.Manager) {
+	liveConfig = cfg
 	var registerOnce sync.Once
 	s.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
 		logx.Info("discord ready", "user", r.User.Username, "discriminator", r.User.Discriminator)
 		// Ensure commands are registered after Ready when application/user ID is available.
-		registerOnce.Do(func() { RegisterCommands(s, cfg.DevGuild, mgr) })
+		registerOnce.Do(func() { RegisterCommands(s, cfg.Get().DevGuilds, mgr) })
+	})
+	s.AddHandler(func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		handleStatusReaction(s, r, st, cfg.Get())
 	})
 	s.AddHandler(func(s *discordgo.Session, ic *discordgo.InteractionCreate) {
-		handleInteraction(s, ic, st, cfg, mgr)
+		// Snapshot per interaction so a reload never changes config mid-command.
+		handleInteraction(s, ic, st, cfg.Get(), mgr)
 	})
 }
@@ -0,0 +1,379 @@
+package discord
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zodakzach/fight-night-discord-bot/internal/config"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+func TestDiffCommands_CreatedUpdatedDeleted(t *testing.T) {
+	stateOpt := func(desc string) []*discordgo.ApplicationCommandOption {
+		return []*discordgo.ApplicationCommandOption{{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "state",
+			Description: desc,
+			Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+		}}
+	}
+	// As echoed back by Discord: server-assigned fields and explicit types set.
+	current := []*discordgo.ApplicationCommand{
+		{ID: "1", Version: "9", Type: discordgo.ChatApplicationCommand, Name: "help", Description: "Show help"},
+		{ID: "2", Version: "9", Type: discordgo.ChatApplicationCommand, Name: "status", Description: "Show status", Options: stateOpt("old")},
+		{ID: "3", Version: "9", Type: discordgo.ChatApplicationCommand, Name: "legacy", Description: "Gone soon"},
+	}
+	desired := []*discordgo.ApplicationCommand{
+		{Name: "help", Description: "Show help", Options: []*discordgo.ApplicationCommandOption{}},
+		{Name: "status", Description: "Show status", Options: stateOpt("new")},
+		{Name: "ping", Description: "Pong"},
+	}
+
+	got := diffCommands(current, desired)
+	want := commandDiff{Created: []string{"ping"}, Updated: []string{"status"}, Deleted: []string{"legacy"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diff: got %+v want %+v", got, want)
+	}
+	out := got.String()
+	for _, line := range []string{"Created: ping", "Updated: status", "Deleted: legacy"} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("expected %q in %q", line, out)
+		}
+	}
+}
+
+func TestDiffCommands_NoChanges(t *testing.T) {
+	cmds := devGuildCommands(applicationCommands())
+	if d := diffCommands(cmds, cmds); !d.Empty() || !strings.Contains(d.String(), "No changes") {
+		t.Fatalf("expected empty diff, got %+v", d)
+	}
+}
+
+// TestApplicationCommands_WithinDiscordLimits guards Discord's registration
+// limits: at most 25 options per command, group, or subcommand, and names of
+// at most 32 characters. Exceeding them fails the whole bulk registration.
+func TestApplicationCommands_WithinDiscordLimits(t *testing.T) {
+	var check func(path string, opts []*discordgo.ApplicationCommandOption)
+	check = func(path string, opts []*discordgo.ApplicationCommandOption) {
+		if len(opts) > 25 {
+			t.Errorf("%s has %d options; Discord allows 25", path, len(opts))
+		}
+		for _, o := range opts {
+			if len(o.Name) > 32 {
+				t.Errorf("%s %s: name longer than 32 characters", path, o.Name)
+			}
+			check(path+" "+o.Name, o.Options)
+		}
+	}
+	for _, c := range applicationCommands() {
+		check("/"+c.Name, c.Options)
+	}
+}
+
+func TestHandleReloadConfig_OwnerOnly(t *testing.T) {
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	cfg := config.Config{RunAt: "16:00", TZ: "UTC", UserAgent: "agent/1.0", OwnerID: "owner"}
+	holder := config.NewHolder(cfg)
+	t.Setenv("RUN_AT", "18:00")
+	t.Setenv("TZ", "UTC")
+	ic := func(userID string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: userID}},
+		}}
+	}
+
+	handleReloadConfig(&discordgo.Session{}, ic("someone"), cfg, holder)
+	if !strings.Contains(got, "Only the bot owner") || holder.Get().RunAt != "16:00" {
+		t.Fatalf("expected non-owner to be refused, got %q (run_at=%q)", got, holder.Get().RunAt)
+	}
+
+	handleReloadConfig(&discordgo.Session{}, ic("owner"), cfg, holder)
+	if holder.Get().RunAt != "18:00" {
+		t.Fatalf("expected RUN_AT reloaded to 18:00, got %q", holder.Get().RunAt)
+	}
+	if !strings.Contains(got, "RUN_AT: 16:00 -> 18:00") || !strings.Contains(got, "TZ: UTC (unchanged)") {
+		t.Fatalf("unexpected reply: %q", got)
+	}
+	// The notifier reads the reloaded hour on its next tick.
+	st := state.Load(":memory:")
+	if h := guildRunHour(st, holder.Get(), "g1"); h != 18 {
+		t.Fatalf("expected run hour 18 after reload, got %d", h)
+	}
+}
+
+func TestHandleCopySettings(t *testing.T) {
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+
+	st := state.Load(":memory:")
+	st.UpdateGuildChannel("old", "c1")
+	st.UpdateGuildRunHour("old", 20)
+	st.UpdateGuildTZ("old", "Europe/London")
+	cfg := config.Config{OwnerID: "owner"}
+	ic := func(userID string, perms int64, from string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "new",
+			Type:    discordgo.InteractionApplicationCommand,
+			Member:  &discordgo.Member{User: &discordgo.User{ID: userID}, Permissions: perms},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "dev-test",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "copy-settings",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+						Type: discordgo.ApplicationCommandOptionString, Name: "from", Value: from,
+					}},
+				}},
+			},
+		}}
+	}
+
+	handleCopySettings(&discordgo.Session{}, ic("someone", 0, "old"), st, cfg)
+	if !strings.Contains(got, "Only the bot owner or an Administrator") || st.GetGuildRunHour("new") != -1 {
+		t.Fatalf("expected refusal, got %q", got)
+	}
+
+	handleCopySettings(&discordgo.Session{}, ic("owner", 0, "missing"), st, cfg)
+	if got != "No settings found for server missing." {
+		t.Fatalf("expected missing source reply, got %q", got)
+	}
+
+	// Administrator here but not in the source server.
+	oldGuild, oldMember := fetchGuild, fetchGuildMember
+	defer func() { fetchGuild, fetchGuildMember = oldGuild, oldMember }()
+	fetchGuild = func(_ *discordgo.Session, guildID string) (*discordgo.Guild, error) {
+		return &discordgo.Guild{ID: guildID, OwnerID: "src-owner", Roles: []*discordgo.Role{
+			{ID: guildID},
+			{ID: "mods", Permissions: discordgo.PermissionManageChannels},
+			{ID: "admins", Permissions: discordgo.PermissionAdministrator},
+		}}, nil
+	}
+	srcRoles := []string{"mods"}
+	fetchGuildMember = func(_ *discordgo.Session, _, userID string) (*discordgo.Member, error) {
+		return &discordgo.Member{User: &discordgo.User{ID: userID}, Roles: srcRoles}, nil
+	}
+	handleCopySettings(&discordgo.Session{}, ic("admin", discordgo.PermissionAdministrator, "old"), st, cfg)
+	if got != "You need Administrator in server old to copy its settings." || st.GetGuildRunHour("new") != -1 {
+		t.Fatalf("expected refusal without source admin, got %q", got)
+	}
+
+	srcRoles = []string{"mods", "admins"}
+	handleCopySettings(&discordgo.Session{}, ic("admin", discordgo.PermissionAdministrator, "old"), st, cfg)
+	if !strings.HasPrefix(got, "Copied settings from server old.") {
+		t.Fatalf("unexpected reply: %q", got)
+	}
+	if st.GetGuildRunHour("new") != 20 {
+		t.Fatalf("expected run hour copied, got %d", st.GetGuildRunHour("new"))
+	}
+	if ch, tz, _ := st.GetGuildSettings("new"); ch != "" || tz != "Europe/London" {
+		t.Fatalf("expected timezone copied and channel left unset, got channel=%q tz=%q", ch, tz)
+	}
+}
+
+func TestWriteHistoryCSV(t *testing.T) {
+	st := state.Load(":memory:")
+	st.RecordPost("g2", "ufc", "20250412", "m3")
+	st.RecordPost("g1", "ufc:weighin", "20250411", "m2")
+	st.RecordPost("g1", "ufc", "20250412", "m1")
+
+	var buf strings.Builder
+	n, err := writeHistoryCSV(&buf, st)
+	if err != nil || n != 3 {
+		t.Fatalf("writeHistoryCSV: n=%d err=%v", n, err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected header + 3 rows, got %d:\n%s", len(rows), buf.String())
+	}
+	if got := strings.Join(rows[0], ","); got != "guild_id,org,kind,date,message_id,posted_at" {
+		t.Fatalf("header: %q", got)
+	}
+	want := [][]string{
+		{"g1", "ufc", "weighin", "20250411", "m2"},
+		{"g1", "ufc", "alert", "20250412", "m1"},
+		{"g2", "ufc", "alert", "20250412", "m3"},
+	}
+	for i, w := range want {
+		row := rows[i+1]
+		if !reflect.DeepEqual(row[:5], w) {
+			t.Fatalf("row %d: got %q want %q", i+1, row[:5], w)
+		}
+		if _, err := time.Parse(time.RFC3339, row[5]); err != nil {
+			t.Fatalf("row %d posted_at %q: %v", i+1, row[5], err)
+		}
+	}
+}
+
+func TestHandleExportHistory_OwnerOnly(t *testing.T) {
+	var got string
+	old := sendInteractionResponse
+	sendInteractionResponse = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content string) error {
+		got = content
+		return nil
+	}
+	defer func() { sendInteractionResponse = old }()
+	var fileName, body string
+	oldFile := sendInteractionFile
+	sendInteractionFile = func(_ *discordgo.Session, _ *discordgo.InteractionCreate, content, name, _ string, r io.Reader) error {
+		got, fileName = content, name
+		b, _ := io.ReadAll(r)
+		body = string(b)
+		return nil
+	}
+	defer func() { sendInteractionFile = oldFile }()
+
+	st := state.Load(":memory:")
+	st.RecordPost("g1", "ufc", "20250412", "m1")
+	ic := func(userID string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: userID}},
+		}}
+	}
+
+	handleExportHistory(&discordgo.Session{}, ic("owner"), st, config.Config{})
+	if !strings.Contains(got, "set OWNER_ID") {
+		t.Fatalf("expected disabled reply, got %q", got)
+	}
+	handleExportHistory(&discordgo.Session{}, ic("someone"), st, config.Config{OwnerID: "owner"})
+	if !strings.Contains(got, "Only the bot owner") || body != "" {
+		t.Fatalf("expected non-owner refusal, got %q", got)
+	}
+	handleExportHistory(&discordgo.Session{}, ic("owner"), st, config.Config{OwnerID: "owner"})
+	if got != "Post history: 1 rows." || !strings.HasSuffix(fileName, ".csv") {
+		t.Fatalf("unexpected reply %q file %q", got, fileName)
+	}
+	if !strings.HasPrefix(body, "guild_id,org,kind,date,message_id,posted_at\ng1,ufc,alert,20250412,m1,") {
+		t.Fatalf("unexpected csv:\n%s", body)
+	}
+
+	// From a DM the user arrives on ic.User instead of ic.Member.
+	body = ""
+	dm := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{User: &discordgo.User{ID: "owner"}}}
+	handleExportHistory(&discordgo.Session{}, dm, st, config.Config{OwnerID: "owner"})
+	if got != "Post history: 1 rows." || body == "" {
+		t.Fatalf("expected the owner to export from a DM, got %q", got)
+	}
+	dm.User.ID = "someone"
+	handleExportHistory(&discordgo.Session{}, dm, st, config.Config{OwnerID: "owner"})
+	if !strings.Contains(got, "Only the bot owner") {
+		t.Fatalf("expected non-owner refusal in a DM, got %q", got)
+	}
+}
+
+func TestHistoryExportHandler(t *testing.T) {
+	st := state.Load(":memory:")
+	st.RecordPost("g1", "ufc:weighin", "20250411", "m2")
+	st.RecordPost("g1", "ufc", "20250412", "m1")
+	srv := httptest.NewServer(HistoryExportHandler(st, "secret"))
+	defer srv.Close()
+
+	get := func(auth string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		return resp
+	}
+	for _, auth := range []string{"", "Bearer wrong", "secret"} {
+		resp := get(auth)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("auth %q: expected 401, got %d", auth, resp.StatusCode)
+		}
+	}
+
+	resp := get("Bearer secret")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/csv" ||
+		!strings.HasPrefix(resp.Header.Get("Content-Disposition"), `attachment; filename="post-history-`) {
+		t.Fatalf("unexpected response %d %v", resp.StatusCode, resp.Header)
+	}
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(rows) != 3 || !reflect.DeepEqual(rows[0], historyCSVHeader) {
+		t.Fatalf("expected header + 2 rows, got %q", rows)
+	}
+	if !reflect.DeepEqual(rows[1][:5], []string{"g1", "ufc", "weighin", "20250411", "m2"}) ||
+		!reflect.DeepEqual(rows[2][:5], []string{"g1", "ufc", "alert", "20250412", "m1"}) {
+		t.Fatalf("unexpected rows %q", rows[1:])
+	}
+
+	// No token configured: every request is refused.
+	off := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/export/post-history", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	HistoryExportHandler(st, "").ServeHTTP(off, req)
+	if off.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", off.Code)
+	}
+}
+
+func TestBroadcastTooLong(t *testing.T) {
+	// Multi-byte characters count once, matching the option's MaxLength.
+	if broadcastTooLong(strings.Repeat("é", maxBroadcastLen)) {
+		t.Fatalf("expected %d characters to fit", maxBroadcastLen)
+	}
+	if !broadcastTooLong(strings.Repeat("a", maxBroadcastLen+1)) {
+		t.Fatalf("expected %d characters to be too long", maxBroadcastLen+1)
+	}
+}
+
+func TestBroadcastMessage_SkipsOptedOut(t *testing.T) {
+	st := state.Load(":memory:")
+	st.UpdateGuildChannel("g1", "chan1")
+	st.UpdateGuildChannel("g2", "chan2")
+	st.UpdateGuildBroadcastOptOut("g2", true)
+	st.UpdateGuildTZ("g3", "UTC") // no channel
+
+	var channels []string
+	old := sendChannelMessageComplex
+	sendChannelMessageComplex = func(_ *discordgo.Session, channelID string, _ *discordgo.MessageSend) (*discordgo.Message, error) {
+		channels = append(channels, channelID)
+		return &discordgo.Message{ID: "m1"}, nil
+	}
+	defer func() { sendChannelMessageComplex = old }()
+
+	sent, skipped, failed := broadcastMessage(&discordgo.Session{}, st, "Maintenance tonight", false)
+	if sent != 1 || skipped != 2 || failed != 0 || !slices.Equal(channels, []string{"chan1"}) {
+		t.Fatalf("informational: sent=%d skipped=%d failed=%d channels=%v", sent, skipped, failed, channels)
+	}
+
+	channels = nil
+	sent, skipped, _ = broadcastMessage(&discordgo.Session{}, st, "Commands are changing", true)
+	slices.Sort(channels)
+	if sent != 2 || skipped != 1 || !slices.Equal(channels, []string{"chan1", "chan2"}) {
+		t.Fatalf("critical: sent=%d skipped=%d channels=%v", sent, skipped, channels)
+	}
+}
@@ -1,6 +1,11 @@
 package discord
 
 import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -21,6 +26,15 @@ var sendInteractionResponse = func(s *discordgo.Session, ic *discordgo.Interacti
 	})
 }
 
+// sendInteractionData replies with caller-built response data, for replies
+// whose visibility is chosen per guild; tests override it to inspect the flags.
+var sendInteractionData = func(s *discordgo.Session, ic *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	return s.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
 // editInteractionResponse allows tests to capture the final content when using deferred responses.
 var editInteractionResponse = func(s *discordgo.Session, ic *discordgo.InteractionCreate, content string) error {
 	_, err := s.InteractionResponseEdit(ic.Interaction, &discordgo.WebhookEdit{Content: &content})
@@ -35,6 +49,19 @@ var deferInteractionResponse = func(s *discordgo.Session, ic *discordgo.Interact
 	})
 }
 
+// sendInteractionFile replies ephemerally with content and one attached file;
+// tests override it to capture the attachment.
+var sendInteractionFile = func(s *discordgo.Session, ic *discordgo.InteractionCreate, content, name, contentType string, r io.Reader) error {
+	return s.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Files:   []*discordgo.File{{Name: name, ContentType: contentType, Reader: r}},
+		},
+	})
+}
+
 // editInteractionEmbeds allows tests to capture embed edits without real HTTP calls.
 var editInteractionEmbeds = func(s *discordgo.Session, ic *discordgo.InteractionCreate, embeds []*discordgo.MessageEmbed) error {
 	_, err := s.InteractionResponseEdit(ic.Interaction, &discordgo.WebhookEdit{Embeds: &embeds})
@@ -45,3 +72,155 @@ var editInteractionEmbeds = func(s *discordgo.Session, ic *discordgo.Interaction
 var sendChannelMessageComplex = func(s *discordgo.Session, channelID string, msg *discordgo.MessageSend) (*discordgo.Message, error) {
 	return s.ChannelMessageSendComplex(channelID, msg)
 }
+
+// fetchChannel looks up a channel, preferring the gateway state cache; tests
+// override it since a bare Session cannot reach Discord.
+var fetchChannel = func(s *discordgo.Session, channelID string) (*discordgo.Channel, error) {
+	if s.State != nil {
+		if ch, err := s.State.Channel(channelID); err == nil {
+			return ch, nil
+		}
+	}
+	return s.Channel(channelID)
+}
+
+// fetchGuild looks up a guild, preferring the gateway state cache; tests
+// override it.
+var fetchGuild = func(s *discordgo.Session, guildID string) (*discordgo.Guild, error) {
+	if s.State != nil {
+		if g, err := s.State.Guild(guildID); err == nil {
+			return g, nil
+		}
+	}
+	return s.Guild(guildID)
+}
+
+// fetchGuildMember looks up a guild member, preferring the gateway state cache;
+// tests override it.
+var fetchGuildMember = func(s *discordgo.Session, guildID, userID string) (*discordgo.Member, error) {
+	if s.State != nil {
+		if m, err := s.State.Member(guildID, userID); err == nil {
+			return m, nil
+		}
+	}
+	return s.GuildMember(guildID, userID)
+}
+
+// sendDirectMessage DMs a user; tests override it to capture the message.
+var sendDirectMessage = func(s *discordgo.Session, userID, content string) error {
+	ch, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.ChannelMessageSendComplex(ch.ID, &discordgo.MessageSend{Content: content, AllowedMentions: allowedMentions()})
+	return err
+}
+
+// editChannelMessageComplex is an indirection so tests can capture message edits.
+var editChannelMessageComplex = func(s *discordgo.Session, m *discordgo.MessageEdit) (*discordgo.Message, error) {
+	return s.ChannelMessageEditComplex(m)
+}
+
+// allowedMentions restricts what a bot post may ping to the given role IDs.
+// Event and fighter names come from upstream data, so @everyone/@here and any
+// user or role mentions inside them must never ping.
+func allowedMentions(roleIDs ...string) *discordgo.MessageAllowedMentions {
+	return &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}, Roles: roleIDs}
+}
+
+// createGuildScheduledEvent is an indirection so tests can capture scheduled event creation.
+var createGuildScheduledEvent = func(s *discordgo.Session, guildID string, params *discordgo.GuildScheduledEventParams) (*discordgo.GuildScheduledEvent, error) {
+	return s.GuildScheduledEventCreate(guildID, params)
+}
+
+// fetchGuildScheduledEvent is an indirection so tests can fake whether a tracked
+// scheduled event still exists.
+var fetchGuildScheduledEvent = func(s *discordgo.Session, guildID, eventID string) (*discordgo.GuildScheduledEvent, error) {
+	return s.GuildScheduledEvent(guildID, eventID, false)
+}
+
+// deleteGuildScheduledEvent is an indirection so tests can capture scheduled
+// event removal.
+var deleteGuildScheduledEvent = func(s *discordgo.Session, guildID, eventID string) error {
+	return s.GuildScheduledEventDelete(guildID, eventID)
+}
+
+// pinChannelMessage and unpinChannelMessage are indirections so tests can capture pins.
+var (
+	pinChannelMessage = func(s *discordgo.Session, channelID, messageID string) error {
+		return s.ChannelMessagePin(channelID, messageID)
+	}
+	unpinChannelMessage = func(s *discordgo.Session, channelID, messageID string) error {
+		return s.ChannelMessageUnpin(channelID, messageID)
+	}
+)
+
+// addMessageReaction, editChannelMessage, and reactorHasManage are indirections
+// so tests can exercise status quick toggles without real HTTP calls.
+var (
+	addMessageReaction = func(s *discordgo.Session, channelID, messageID, emoji string) error {
+		return s.MessageReactionAdd(channelID, messageID, emoji)
+	}
+	editChannelMessage = func(s *discordgo.Session, channelID, messageID, content string) error {
+		_, err := s.ChannelMessageEdit(channelID, messageID, content)
+		return err
+	}
+	reactorHasManage = hasManageOrAdmin
+)
+
+// addMemberRole and removeMemberRole are indirections so tests can capture
+// /subscribe-role changes without real HTTP calls.
+var (
+	addMemberRole = func(s *discordgo.Session, guildID, userID, roleID string) error {
+		return s.GuildMemberRoleAdd(guildID, userID, roleID)
+	}
+	removeMemberRole = func(s *discordgo.Session, guildID, userID, roleID string) error {
+		return s.GuildMemberRoleRemove(guildID, userID, roleID)
+	}
+)
+
+// discordMessageLimit is the maximum number of characters in a message's content.
+const discordMessageLimit = 2000
+
+// splitForDiscord splits content into chunks that each fit in a single Discord
+// message, breaking on line boundaries where possible and hard-splitting lines
+// that are longer than the limit on their own. Content within the limit is
+// returned as a single chunk.
+func splitForDiscord(content string) []string {
+	if utf8.RuneCountInString(content) <= discordMessageLimit {
+		return []string{content}
+	}
+	var chunks []string
+	var cur strings.Builder
+	curLen := 0
+	flush := func() {
+		if curLen > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+	}
+	for _, line := range strings.SplitAfter(content, "\n") {
+		n := utf8.RuneCountInString(line)
+		if curLen+n > discordMessageLimit {
+			flush()
+		}
+		// A single line longer than the limit: cut it into limit-sized pieces.
+		for n > discordMessageLimit {
+			r := []rune(line)
+			chunks = append(chunks, string(r[:discordMessageLimit]))
+			line = string(r[discordMessageLimit:])
+			n -= discordMessageLimit
+		}
+		cur.WriteString(line)
+		curLen += n
+	}
+	flush()
+	return chunks
+}
+
+// heartbeatLatency reports the gateway heartbeat latency; tests may override it
+// since a bare Session has no heartbeat data.
+var heartbeatLatency = func(s *discordgo.Session) time.Duration {
+	return s.HeartbeatLatency()
+}
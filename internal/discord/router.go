@@ -21,12 +21,39 @@ var routes = map[string]handlerFunc{
 	"status": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, _ *sources.Manager) {
 		handleStatus(s, ic, st, cfg)
 	},
-	"help": func(s *discordgo.Session, ic *discordgo.InteractionCreate, _ *state.Store, _ config.Config, _ *sources.Manager) {
-		handleHelp(s, ic)
+	"next-check": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, _ *sources.Manager) {
+		handleNextCheck(s, ic, st, cfg)
+	},
+	"history": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, _ config.Config, _ *sources.Manager) {
+		handleHistory(s, ic, st)
+	},
+	"subscribe-role": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, _ config.Config, _ *sources.Manager) {
+		handleSubscribeRole(s, ic, st)
+	},
+	"create-event": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+		handleCreateEvent(s, ic, st, cfg, mgr)
+	},
+	"ping": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, _ config.Config, _ *sources.Manager) {
+		handlePing(s, ic, st)
+	},
+	"help": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, _ config.Config, _ *sources.Manager) {
+		handleHelp(s, ic, st)
 	},
 	"next-event": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 		handleNextEvent(s, ic, st, cfg, mgr)
 	},
+	"tz-convert": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, _ *sources.Manager) {
+		handleTZConvert(s, ic, st, cfg)
+	},
+	"results": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+		handleResults(s, ic, st, cfg, mgr)
+	},
+	"card": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
+		handleCard(s, ic, st, cfg, mgr)
+	},
+	"h2h": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, _ config.Config, mgr *sources.Manager) {
+		handleH2H(s, ic, st, mgr)
+	},
 	// Dev helpers grouped under /dev-test
 	"dev-test": func(s *discordgo.Session, ic *discordgo.InteractionCreate, st *state.Store, cfg config.Config, mgr *sources.Manager) {
 		handleDevTest(s, ic, st, cfg, mgr)
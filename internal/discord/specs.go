@@ -18,6 +18,21 @@ type commandSpec struct {
 // currentSpecs stores the active command specs built during registration.
 var currentSpecs []commandSpec
 
+// minAnnounceDays is addressable for the max-announce-days option's MinValue.
+var minAnnounceDays float64 = 0
+
+// minMainCardSize is addressable for the main-card-size option's MinValue.
+var minMainCardSize float64 = 1
+
+// minFightWeekDays is addressable for the fight-week option's MinValue.
+var minFightWeekDays float64 = 0
+
+// minLiveSoonMinutes is addressable for the live-soon-reminder option's MinValue.
+var minLiveSoonMinutes float64 = 0
+
+// minScheduledEventLeadDays is addressable for the scheduled-event-lead option's MinValue.
+var minScheduledEventLeadDays float64 = 1
+
 // commandSpecs builds the list of commands the bot supports using the
 // provided org choices for the /set-org command.
 func commandSpecs(orgs []string) []commandSpec {
@@ -56,6 +71,30 @@ func commandSpecs(orgs []string) []commandSpec {
 							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
 						}},
 					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "alert-channel",
+						Description: "Send fight-night alerts somewhere other than the main channel",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "channel",
+							Description:  "Channel for alerts, reminders and promos (omit to use the main channel)",
+							Required:     false,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "event-channel",
+						Description: "Announce scheduled event links somewhere other than the main channel",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "channel",
+							Description:  "Channel for scheduled event links (omit to use the main channel)",
+							Required:     false,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+						}},
+					},
 					{
 						Type:        discordgo.ApplicationCommandOptionSubCommand,
 						Name:        "delivery",
@@ -63,8 +102,8 @@ func commandSpecs(orgs []string) []commandSpec {
 						Options: []*discordgo.ApplicationCommandOption{{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "mode",
-							Description: "Delivery mode",
-							Required:    true,
+							Description: "Delivery mode (omit to show the current mode)",
+							Required:    false,
 							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "message", Value: "message"}, {Name: "announcement", Value: "announcement"}},
 						}},
 					},
@@ -79,14 +118,48 @@ func commandSpecs(orgs []string) []commandSpec {
 							Required:    true,
 						}},
 					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "run-time-reference",
+						Description: "Read the run hour in the guild's timezone or in UTC",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "reference",
+							Description: "local (guild timezone, default) or utc; omit to show the current reference",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "local", Value: runTimeLocal},
+								{Name: "utc", Value: runTimeUTC},
+							},
+						}},
+					},
 					{
 						Type:        discordgo.ApplicationCommandOptionSubCommand,
 						Name:        "timezone",
 						Description: "Set the guild's timezone (IANA name)",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "tz",
+								Description: "Timezone, e.g., America/Los_Angeles",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "fallback",
+								Description: "Used if tz stops being recognized (e.g. a renamed zone); none clears it",
+								Required:    false,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "timezone-help",
+						Description: "List IANA timezones matching a region",
 						Options: []*discordgo.ApplicationCommandOption{{
 							Type:        discordgo.ApplicationCommandOptionString,
-							Name:        "tz",
-							Description: "Timezone, e.g., America/Los_Angeles",
+							Name:        "region",
+							Description: "Part of a zone name, e.g., America or Europe/L",
 							Required:    true,
 						}},
 					},
@@ -97,8 +170,20 @@ func commandSpecs(orgs []string) []commandSpec {
 						Options: []*discordgo.ApplicationCommandOption{{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "state",
-							Description: "Enable or disable notifications",
-							Required:    true,
+							Description: "Enable or disable notifications (omit to show the current state)",
+							Required:    false,
+							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "skip-until-ppv",
+						Description: "Skip alerts for Fight Nights until the next UFC PPV posts (turns itself off)",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "state",
+							Description: "Start or cancel skipping (omit to show the current state)",
+							Required:    false,
 							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
 						}},
 					},
@@ -109,11 +194,525 @@ func commandSpecs(orgs []string) []commandSpec {
 						Options: []*discordgo.ApplicationCommandOption{{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "state",
-							Description: "Enable or disable scheduled events",
-							Required:    true,
+							Description: "Enable or disable scheduled events (omit to show the current state)",
+							Required:    false,
+							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "pin",
+						Description: "Pin each fight-night alert (unpins the previous one)",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "state",
+							Description: "Enable or disable pinning (omit to show the current state)",
+							Required:    false,
+							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "next-event-teaser",
+						Description: "Also name the event after the next one in /next-event (off by default)",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "state",
+							Description: "Enable or disable the teaser (omit to show the current state)",
+							Required:    false,
 							Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
 						}},
 					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+						Name:        "replies",
+						Description: "How the bot's command replies look",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "debug-ids",
+								Description: "Show the ESPN event ID in /next-event to managers, for support (off by default)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable event IDs (omit to show the current state)",
+									Required:    false,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "help-visibility",
+								Description: "Reply to /help only to the caller or in the channel (ephemeral by default)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "visibility",
+									Description: "Who sees /help replies (omit to show the current setting)",
+									Required:    false,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "ephemeral", Value: helpEphemeral},
+										{Name: "public", Value: helpPublic},
+									},
+								}},
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "card-update-mode",
+						Description: "Edit the alert or post an update when the card fills in after posting",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "mode",
+							Description: "edit (quiet, default) or new (follow-up message); omit to show the current mode",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "edit", Value: cardUpdateEdit},
+								{Name: "new", Value: cardUpdateNew},
+							},
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+						Name:        "alert",
+						Description: "What the fight-night alert message says",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "content",
+								Description: "Choose which parts of the fight-night alert are sent (omit all to show them)",
+								Options: []*discordgo.ApplicationCommandOption{
+									{
+										Type:        discordgo.ApplicationCommandOptionString,
+										Name:        "header",
+										Description: "The \"Fight Night Alert:\" line (default on)",
+										Required:    false,
+										Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+									},
+									{
+										Type:        discordgo.ApplicationCommandOptionString,
+										Name:        "trailer",
+										Description: "A closing \"Enjoy the fights!\" line (default off)",
+										Required:    false,
+										Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+									},
+									{
+										Type:        discordgo.ApplicationCommandOptionString,
+										Name:        "embed",
+										Description: "The card embed (default on)",
+										Required:    false,
+										Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+									},
+								},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "message-template",
+								Description: "Replace the alert text with a template using {org} {event} {time} {count}",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "template",
+									Description: "Alert text (omit to reset to the default format)",
+									Required:    false,
+								}},
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "subscriber-role",
+						Description: "Set the opt-in role that fight-night alerts ping",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionRole,
+							Name:        "role",
+							Description: "Role members self-assign with /subscribe-role (omit to clear)",
+							Required:    false,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "ping-role",
+						Description: "Set a role that every fight-night alert pings",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionRole,
+							Name:        "role",
+							Description: "Role to mention in fight-night alerts (omit to clear)",
+							Required:    false,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+						Name:        "extra-posts",
+						Description: "Opt-in posts and callbacks besides the fight-night alert",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "weigh-in-reminder",
+								Description: "Post a weigh-in reminder the day before each event",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable the reminder (omit to show the current state)",
+									Required:    false,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "weigh-in-message",
+								Description: "Customize the weigh-in reminder ({event} is replaced with the event name)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "text",
+									Description: "Message to post (omit to reset to default)",
+									Required:    false,
+									MaxLength:   maxCustomMessageLen,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "fight-week",
+								Description: "Post a fight-week promo with the card this many days before each event",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "days",
+									Description: "Days before the event (0 turns it off; omit to show the current value)",
+									Required:    false,
+									MinValue:    &minFightWeekDays,
+									MaxValue:    maxFightWeekDays,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "reminders",
+								Description: "Post reminders this many hours before each event starts",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "offsets",
+									Description: "Comma-separated hours, e.g. 24,1, or off (omit to show the current value)",
+									Required:    false,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "live-soon-reminder",
+								Description: "Post a reminder once when an event is this many minutes from starting",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "minutes",
+									Description: "Minutes before the start (0 turns it off; omit to show the current value)",
+									Required:    false,
+									MinValue:    &minLiveSoonMinutes,
+									MaxValue:    maxLiveSoonMinutes,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "fight-week-message",
+								Description: "Customize the fight-week promo ({event} and {days} are filled in)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "text",
+									Description: "Message to post (omit to reset to default)",
+									Required:    false,
+									MaxLength:   maxCustomMessageLen,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "notify-webhook",
+								Description: "POST event JSON to your own https endpoint after each fight-night alert",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "url",
+									Description: "https URL to call (omit to clear)",
+									Required:    false,
+									MaxLength:   maxWebhookURLLen,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "postpone-notice",
+								Description: "Post a notice when an event on the server calendar is postponed or cancelled",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable the notice (omit to show the current state)",
+									Required:    false,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "broadcast-opt-out",
+								Description: "Skip the bot operator's non-critical notices",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "on skips notices, off (default) receives them; omit to show the current state",
+									Required:    false,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "no-event-message",
+						Description: "Customize the /next-event reply when nothing is scheduled",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "text",
+							Description: "Message to show (omit to reset to default)",
+							Required:    false,
+							MaxLength:   maxCustomMessageLen,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "event-name-prefix",
+						Description: "Prefix for scheduled event names, e.g. \"🥊 Watch Party:\" (default: the org)",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "text",
+							Description: "Prefix to use, or \"default\" to reset (omit to show the current one)",
+							Required:    false,
+							MaxLength:   maxEventNamePrefixLen,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "max-announce-days",
+						Description: "Only show /next-event results within this many days",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "days",
+							Description: "Days ahead (0 removes the limit; omit to show the current window)",
+							Required:    false,
+							MinValue:    &minAnnounceDays,
+							MaxValue:    maxAnnounceDaysLimit,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "scheduled-event-lead",
+						Description: "Create the Discord scheduled event up to this many days ahead",
+						Options: []*discordgo.ApplicationCommandOption{{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "days",
+							Description: "Days ahead (1 = the day before; omit to show the current value)",
+							Required:    false,
+							MinValue:    &minScheduledEventLeadDays,
+							MaxValue:    maxScheduledEventLeadDays,
+						}},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+						Name:        "embed",
+						Description: "Customize the event embed",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "preview",
+								Description: "Show the ESPN preview link and headline (extra request)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable the preview",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "headshots",
+								Description: "Show a main-event fighter headshot as the thumbnail",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable headshots",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "starts-format",
+								Description: "Choose how the embed shows the start time",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "format",
+									Description: "long (date+time), short (date only), relative (e.g., in 3 days)",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "long", Value: startsFormatLong},
+										{Name: "short", Value: startsFormatShort},
+										{Name: "relative", Value: startsFormatRelative},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "date-format",
+								Description: "Date style for embeds, replies and reminders",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "format",
+									Description: "us (Mon Jan 2, 3:04 PM, default) or intl (2 Jan 2006, 15:04)",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "us", Value: dateFormatUS},
+										{Name: "intl", Value: dateFormatIntl},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "layout",
+								Description: "Stack the card sections or show them side by side",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "layout",
+									Description: "stacked (default) or inline (more compact)",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "stacked", Value: embedLayoutStacked},
+										{Name: "inline", Value: embedLayoutInline},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "section-order",
+								Description: "Show the main card or the prelims first",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "order",
+									Description: "headline (main card first, default) or chrono (prelims first, as broadcast)",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "headline", Value: sectionOrderHeadline},
+										{Name: "chrono", Value: sectionOrderChrono},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "main-card-size",
+								Description: "How many bouts from the top of the card are the main card",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "size",
+									Description: "Main card bouts (default 5; omit to show the current value)",
+									Required:    false,
+									MinValue:    &minMainCardSize,
+									MaxValue:    maxMainCardSize,
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "link-preference",
+								Description: "Choose which link the embed title opens",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "preference",
+									Description: "auto (default pick), espn, official (org website), or first",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "auto", Value: "auto"},
+										{Name: "espn", Value: linkPrefESPN},
+										{Name: "official", Value: linkPrefOfficial},
+										{Name: "first", Value: linkPrefFirst},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "show-rankings",
+								Description: "Show division rankings/champion status next to fighter names",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable rankings",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "show-flags",
+								Description: "Show fighter country flags next to names",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable flags",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "show-notes",
+								Description: "Show bout notes such as Rematch or Title Eliminator",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable notes",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "show-end",
+								Description: "Show the event's end time when it is known",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "state",
+									Description: "Enable or disable the end time line",
+									Required:    true,
+									Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "result-method",
+								Description: "How finish methods appear next to winners once bouts are decided",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "mode",
+									Description: "emoji (default), text, or off (winner only)",
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "emoji", Value: resultMethodEmoji},
+										{Name: "text", Value: resultMethodText},
+										{Name: "off", Value: resultMethodOff},
+									},
+								}},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "result-emojis",
+								Description: "Pick the finish method emojis (omit all to reset)",
+								Options: []*discordgo.ApplicationCommandOption{
+									{Type: discordgo.ApplicationCommandOptionString, Name: "ko", Description: "KO/TKO emoji (default 💥)", Required: false},
+									{Type: discordgo.ApplicationCommandOptionString, Name: "sub", Description: "Submission emoji (default 🔒)", Required: false},
+									{Type: discordgo.ApplicationCommandOptionString, Name: "dec", Description: "Decision emoji (default 📋)", Required: false},
+								},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionSubCommand,
+								Name:        "results-reactions",
+								Description: "Reactions added to results posts (off by default)",
+								Options: []*discordgo.ApplicationCommandOption{{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "emojis",
+									Description: "on, off, or comma-separated emoji, e.g. 🏆,🔥 (omit to show the current value)",
+									Required:    false,
+								}},
+							},
+						},
+					},
 				},
 			},
 			Note: "Settings require Manage Channels permission (except timezone).",
@@ -137,15 +736,64 @@ func commandSpecs(orgs []string) []commandSpec {
 							Name:        "contender-include",
 							Description: "Include UFC Contender Series events",
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "events",
+							Description: "Create Discord scheduled events for UFC (omit state to view)",
+							Options: []*discordgo.ApplicationCommandOption{{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "state",
+								Description: "on (default) or off",
+								Required:    false,
+								Choices:     []*discordgo.ApplicationCommandOptionChoice{{Name: "on", Value: "on"}, {Name: "off", Value: "off"}},
+							}},
+						},
 					},
 				}},
 			},
-			Note: "Use: /org-settings ufc contender-ignore|contender-include",
+			Note: "Use: /org-settings ufc contender-ignore|contender-include|events",
 		},
 		{
 			Def: &discordgo.ApplicationCommand{
 				Name:        "status",
 				Description: "Show current bot settings for this guild",
+				Options: []*discordgo.ApplicationCommandOption{{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reactions",
+					Description: "Post publicly with reaction quick toggles (Manage Channels)",
+					Required:    false,
+				}},
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "next-check",
+				Description: "Show when the bot will next check for events in this server",
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "history",
+				Description: "Show recent dates the bot posted in this server",
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "subscribe-role",
+				Description: "Opt in to (or out of) fight-night pings via the server's subscriber role",
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "create-event",
+				Description: "Create a Discord Scheduled Event for the next event now (Manage Events)",
+			},
+			Note: "Works even when /settings events is off; that toggle only controls automatic creation.",
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "ping",
+				Description: "Check bot responsiveness (gateway and database latency)",
 			},
 		},
 		{
@@ -158,6 +806,50 @@ func commandSpecs(orgs []string) []commandSpec {
 			Def: &discordgo.ApplicationCommand{
 				Name:        "next-event",
 				Description: "Show the next event for the selected org",
+				Options: []*discordgo.ApplicationCommandOption{{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "tz",
+					Description: "Show times in this IANA timezone instead (this reply only)",
+					Required:    false,
+				}},
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "tz-convert",
+				Description: "Convert a UTC time into a timezone and the server's timezone",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "time",
+						Description: "RFC3339 time, or HH:MM in UTC for today",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "tz",
+						Description: "IANA timezone, e.g., America/New_York",
+						Required:    true,
+					},
+				},
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "results",
+				Description: "Show the winners from the last completed event for the selected org",
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "card",
+				Description: "Show the full fight card for the next event, however far out",
+			},
+		},
+		{
+			Def: &discordgo.ApplicationCommand{
+				Name:        "h2h",
+				Description: "Show whether the next main event's fighters have met before",
 			},
 		},
 	}
@@ -311,6 +1003,8 @@ func optionUsage(opt *discordgo.ApplicationCommandOption) string {
 		return "<true|false>"
 	case discordgo.ApplicationCommandOptionUser:
 		return "@user"
+	case discordgo.ApplicationCommandOptionRole:
+		return "@role"
 	default:
 		return "<value>"
 	}
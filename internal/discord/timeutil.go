@@ -2,6 +2,7 @@ package discord
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,3 +22,42 @@ func parseAPITime(s string) (time.Time, error) {
 	}
 	return time.Time{}, fmt.Errorf("unsupported time %q", s)
 }
+
+// runHourText states the daily check time in the guild's timezone and, when
+// that isn't UTC, its UTC equivalent on the next run (so DST is reflected),
+// e.g. "I'll check daily at 16:00 America/New_York, which is 20:00 UTC."
+// Checks run at the top of each UTC hour, so half-hour zones show :30.
+func runHourText(hour int, loc *time.Location, tzName string, now time.Time) string {
+	next := nextRunAt(now, loc, hour).In(loc)
+	msg := fmt.Sprintf("I'll check daily at %s %s", next.Format("15:04"), tzName)
+	if _, offset := next.Zone(); offset != 0 {
+		msg += ", which is " + next.UTC().Format("15:04") + " UTC"
+	}
+	return msg + "."
+}
+
+// parseConvertTime reads a /tz-convert time: a full RFC3339 timestamp, or HH:MM
+// taken as UTC on now's UTC date.
+func parseConvertTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := parseAPITime(s); err == nil {
+		return t.UTC(), nil
+	}
+	h, m, err := parseHHMM(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unsupported time %q", s)
+	}
+	y, mo, d := now.UTC().Date()
+	return time.Date(y, mo, d, h, m, 0, 0, time.UTC), nil
+}
+
+// tzConvertText renders t (UTC) in the requested zone and in the guild's zone,
+// one line each, e.g. "Sat Apr 13 18:00 EDT (America/New_York)".
+func tzConvertText(t time.Time, loc *time.Location, tzName string, guildLoc *time.Location, guildTZ string) string {
+	const layout = "Mon Jan 2 15:04 MST"
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is:\n", t.UTC().Format("Mon Jan 2 15:04")+" UTC")
+	fmt.Fprintf(&b, "- %s (%s)\n", t.In(loc).Format(layout), tzName)
+	fmt.Fprintf(&b, "- %s (%s, server timezone)", t.In(guildLoc).Format(layout), guildTZ)
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sources"
+	"github.com/zodakzach/fight-night-discord-bot/internal/state"
+)
+
+// webhookTimeout bounds each outbound notify webhook POST.
+const webhookTimeout = 5 * time.Second
+
+// maxWebhookURLLen caps the stored notify webhook URL.
+const maxWebhookURLLen = 512
+
+// webhookPayload is the JSON body POSTed to a guild's notify webhook after a
+// fight-night alert is sent.
+type webhookPayload struct {
+	GuildID  string       `json:"guild_id"`
+	Org      string       `json:"org"`
+	PostedAt string       `json:"posted_at"` // RFC3339 UTC
+	Event    webhookEvent `json:"event"`
+}
+
+// webhookEvent is the event as sent to notify webhooks; times are RFC3339 UTC.
+type webhookEvent struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	ShortName string `json:"short_name,omitempty"`
+	Start     string `json:"start"`
+	End       string `json:"end,omitempty"`
+	Venue     string `json:"venue,omitempty"`
+	Location  string `json:"location,omitempty"`
+}
+
+// errWebhookAddressBlocked is returned when a notify webhook would connect to a
+// loopback, private, or link-local address.
+var errWebhookAddressBlocked = errors.New("webhook address not allowed")
+
+// webhookHTTPClient sends notify webhooks; its timeout backs up the per-request
+// context. validWebhookURL only sees the URL, so the client re-checks the IP
+// each connection actually dials (after DNS) and never follows redirects: a
+// 30x is reported as a failed status instead of reaching another host. It
+// ignores HTTP(S)_PROXY, since a proxy would dial the target on its behalf.
+var webhookHTTPClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: webhookTimeout,
+			Control: webhookDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// webhookDialControl refuses connections to addresses webhookIPAllowed rejects.
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !webhookIPAllowed(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddressBlocked, host)
+	}
+	return nil
+}
+
+// webhookBlockedNets are ranges IsGlobalUnicast and IsPrivate let through:
+// "this network" (0.0.0.0/8) and the carrier-grade NAT shared space (RFC 6598).
+var webhookBlockedNets = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+// webhookIPAllowed reports whether a notify webhook may reach ip. Only global
+// unicast addresses outside the private, "this network", and shared (CGNAT)
+// ranges are allowed, so the setting can't reach the bot's own network; this
+// also refuses loopback, link-local, multicast, broadcast, and unspecified
+// addresses.
+func webhookIPAllowed(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, n := range webhookBlockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// postNotifyWebhook POSTs a JSON body to a notify webhook and treats any
+// non-2xx answer as an error; tests override it.
+var postNotifyWebhook = func(ctx context.Context, webhookURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notifyWebhook POSTs the sent event to the guild's notify webhook, if set, in
+// the background. It is best-effort: failures are logged and never affect the
+// Discord post.
+func notifyWebhook(st *state.Store, guildID, org string, evt *sources.Event, postedAt time.Time) {
+	webhookURL := st.GetGuildNotifyWebhookURL(guildID)
+	if webhookURL == "" || evt == nil {
+		return
+	}
+	body, err := json.Marshal(webhookPayload{
+		GuildID:  guildID,
+		Org:      org,
+		PostedAt: postedAt.UTC().Format(time.RFC3339),
+		Event: webhookEvent{
+			ID:        evt.ID,
+			Name:      evt.Name,
+			ShortName: evt.ShortName,
+			Start:     evt.Start,
+			End:       evt.End,
+			Venue:     evt.Venue,
+			Location:  evt.Location,
+		},
+	})
+	if err != nil {
+		logx.Warn("notify webhook encode failed", "guild_id", guildID, "err", err)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+		defer cancel()
+		if err := postNotifyWebhook(ctx, webhookURL, body); err != nil {
+			logx.Warn("notify webhook failed", "guild_id", guildID, "org", org, "err", err)
+		}
+	}()
+}
+
+// validWebhookURL reports whether s is an https URL the bot may POST to. Hosts
+// given as IPs webhookIPAllowed rejects (and localhost) are refused up front;
+// names resolving to them are caught when webhookHTTPClient dials.
+func validWebhookURL(s string) bool {
+	if len(s) > maxWebhookURLLen {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return webhookIPAllowed(ip)
+	}
+	return true
+}
@@ -1,22 +1,63 @@
 package espn
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/metrics"
 )
 
-const ufcEventsURL = "https://site.api.espn.com/apis/site/v2/sports/mma/ufc/scoreboard?dates=%s"
+// scoreboardURL is the ESPN MMA scoreboard for a league slug (e.g., "ufc",
+// "pfl") and a 'dates' parameter.
+const scoreboardURL = "https://site.api.espn.com/apis/site/v2/sports/mma/%s/scoreboard?dates=%s"
 
-// ESPN Core API: list competitions (bouts) for a specific event id
-const ufcCoreEventCompetitionsURL = "https://sports.core.api.espn.com/v2/sports/mma/leagues/ufc/events/%s/competitions"
+// DefaultLeague is the ESPN league slug used by NewClient.
+const DefaultLeague = "ufc"
+
+// ErrBlocked is returned when ESPN answers an API request with an HTML page
+// (a block or captcha page, typically with status 200) instead of JSON.
+var ErrBlocked = errors.New("ESPN returned HTML instead of JSON (request blocked)")
+
+// decodeJSON decodes an API response body into v, reporting ErrBlocked for HTML
+// responses rather than a cryptic JSON syntax error.
+func decodeJSON(resp *http.Response, v any) error {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html") {
+		return ErrBlocked
+	}
+	br := bufio.NewReader(resp.Body)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			break // let the decoder report empty/short bodies
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		case '<':
+			return ErrBlocked
+		}
+		break
+	}
+	return json.NewDecoder(br).Decode(v)
+}
+
+// ESPN Core API: list competitions (bouts) for a league slug and event id
+const coreEventCompetitionsURL = "https://sports.core.api.espn.com/v2/sports/mma/leagues/%s/events/%s/competitions"
 
 type Event struct {
 	ID   string `json:"id"`
@@ -74,13 +115,121 @@ type Competition struct {
 	EndDate     string       `json:"endDate"`
 	Type        CompType     `json:"type"`
 	Competitors []Competitor `json:"competitors"`
-	Status      struct {
+	Venue       Venue        `json:"venue"`
+	// Notes carry bout context such as "Rematch" or "Title Eliminator"; most
+	// bouts have none.
+	Notes []CompNote `json:"notes"`
+	// Broadcasts list the TV/streaming outlets per market, e.g. ESPN+.
+	Broadcasts []Broadcast `json:"broadcasts"`
+	Status     struct {
 		Type struct {
 			State string `json:"state"`
 		} `json:"type"`
+		// Period is the round the bout ended in once it's over.
+		Period int          `json:"period"`
+		Result StatusResult `json:"result"`
 	} `json:"status"`
 }
 
+// Broadcast is one market's outlets for a competition, e.g. {"national",
+// ["ESPN+", "ABC"]}.
+type Broadcast struct {
+	Market string   `json:"market"`
+	Names  []string `json:"names"`
+}
+
+// EventBroadcasts returns the distinct outlets across an event's
+// competitions in first-seen order, or nil when ESPN lists none.
+func EventBroadcasts(ev *Event) []string {
+	if ev == nil {
+		return nil
+	}
+	var out []string
+	seen := make(map[string]bool)
+	for _, c := range ev.Competitions {
+		for _, b := range c.Broadcasts {
+			for _, n := range b.Names {
+				n = strings.TrimSpace(n)
+				if n == "" || seen[strings.ToLower(n)] {
+					continue
+				}
+				seen[strings.ToLower(n)] = true
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// CompNote is a free-form annotation ESPN attaches to a competition.
+type CompNote struct {
+	Type     string `json:"type"`
+	Headline string `json:"headline"`
+}
+
+// noteText joins a competition's note headlines, skipping blanks and repeats.
+func noteText(notes []CompNote) string {
+	var parts []string
+	for _, n := range notes {
+		h := strings.TrimSpace(n.Headline)
+		if h == "" || slices.Contains(parts, h) {
+			continue
+		}
+		parts = append(parts, h)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Venue is where a competition takes place, when ESPN provides it.
+type Venue struct {
+	FullName string `json:"fullName"`
+	Address  struct {
+		City    string `json:"city"`
+		State   string `json:"state"`
+		Country string `json:"country"`
+	} `json:"address"`
+}
+
+// Location renders the venue's address as "City, ST" when a state is given
+// (US venues), else "City, Country", or whichever part is known.
+func (v Venue) Location() string {
+	a := v.Address
+	region := strings.TrimSpace(a.State)
+	if region == "" {
+		region = strings.TrimSpace(a.Country)
+	}
+	parts := make([]string, 0, 2)
+	for _, p := range []string{strings.TrimSpace(a.City), region} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EventVenue returns the venue name and location of the event's first
+// competition that has venue data; both are empty when ESPN omits it.
+func EventVenue(ev *Event) (name, location string) {
+	if ev == nil {
+		return "", ""
+	}
+	for _, c := range ev.Competitions {
+		name, location = strings.TrimSpace(c.Venue.FullName), c.Venue.Location()
+		if name != "" || location != "" {
+			return name, location
+		}
+	}
+	return "", ""
+}
+
+// StatusResult describes how a finished bout ended (e.g., "KO/TKO",
+// "Submission", "Decision - Unanimous").
+type StatusResult struct {
+	Name             string `json:"name"`
+	DisplayName      string `json:"displayName"`
+	ShortDisplayName string `json:"shortDisplayName"`
+}
+
 type CompType struct {
 	ID           string `json:"id"`
 	Abbreviation string `json:"abbreviation"`
@@ -91,12 +240,103 @@ type Competitor struct {
 	Winner  bool     `json:"winner"`
 	Athlete Athlete  `json:"athlete"`
 	Records []Record `json:"records"`
+	// Optional division ranking; only present on some payloads.
+	Rank        Rank `json:"rank"`
+	CuratedRank struct {
+		Current int `json:"current"`
+	} `json:"curatedRank"`
+}
+
+// Rank is a competitor's division ranking. ESPN sends either a number or a string
+// such as "3" or "C" (champion) depending on the endpoint.
+type Rank struct {
+	Number   int
+	Champion bool
+}
+
+func (r *Rank) UnmarshalJSON(b []byte) error {
+	var n int
+	if err := json.Unmarshal(b, &n); err == nil {
+		r.Number = n
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		// Unknown shape; treat as unranked rather than failing the whole payload.
+		return nil
+	}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if strings.EqualFold(s, "c") || strings.EqualFold(s, "champion") {
+		r.Champion = true
+		return nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		r.Number = n
+	}
+	return nil
+}
+
+// rankLabel renders the competitor's ranking as "C" or "#N", or "" when unranked.
+// ESPN uses large sentinel values (e.g., 99) for unranked competitors.
+func (c Competitor) rankLabel() string {
+	if c.Rank.Champion {
+		return "C"
+	}
+	n := c.Rank.Number
+	if n == 0 {
+		n = c.CuratedRank.Current
+	}
+	if n >= 1 && n <= 15 {
+		return fmt.Sprintf("#%d", n)
+	}
+	return ""
 }
 
 type Athlete struct {
-	FullName  string `json:"fullName"`
-	Display   string `json:"displayName"`
-	ShortName string `json:"shortName"`
+	ID        string   `json:"id"`
+	FullName  string   `json:"fullName"`
+	Display   string   `json:"displayName"`
+	ShortName string   `json:"shortName"`
+	Headshot  Headshot `json:"headshot"`
+	// Nationality, when present: a country name or code such as "USA" or
+	// "Brazil", depending on the endpoint.
+	Citizenship        string `json:"citizenship"`
+	CitizenshipCountry struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"citizenshipCountry"`
+	Flag struct {
+		Href string `json:"href"`
+		Alt  string `json:"alt"`
+	} `json:"flag"`
+}
+
+// Country returns the athlete's nationality as ESPN reports it (a country name
+// or code), or "" when unknown.
+func (a Athlete) Country() string {
+	return strings.TrimSpace(firstNonEmpty(a.CitizenshipCountry.Abbreviation, a.Citizenship, a.Flag.Alt))
+}
+
+// Headshot is an athlete image URL. ESPN returns either a bare string or an
+// object with an href depending on the endpoint; both decode into Href.
+type Headshot struct {
+	Href string
+}
+
+func (h *Headshot) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		h.Href = s
+		return nil
+	}
+	var obj struct {
+		Href string `json:"href"`
+	}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		// Unknown shape; treat as absent rather than failing the whole payload.
+		return nil
+	}
+	h.Href = obj.Href
+	return nil
 }
 
 type Record struct {
@@ -105,13 +345,29 @@ type Record struct {
 
 // Fight is a simplified view of a bout for output and downstream use.
 type Fight struct {
-	WeightClass string
-	RedName     string
-	RedRecord   string
-	BlueName    string
-	BlueRecord  string
+	WeightClass  string
+	RedName      string
+	RedRecord    string
+	RedHeadshot  string
+	BlueName     string
+	BlueRecord   string
+	BlueHeadshot string
+	// Nationality as reported by ESPN (see Athlete.Country), empty when unknown
+	RedCountry  string
+	BlueCountry string
 	Winner      string
-	Scheduled   time.Time
+	// Method and Round describe the finish once Winner is known; empty/0 otherwise.
+	Method    string
+	Round     int
+	Scheduled time.Time
+	// Division ranking labels ("C", "#3"), empty when unranked/unknown
+	RedRank  string
+	BlueRank string
+	// Note is bout context from ESPN (e.g., "Rematch"), empty when none
+	Note string
+	// ESPN athlete IDs, empty when unknown
+	RedID  string
+	BlueID string
 }
 
 // Note: legacy date-range fetcher interface removed in favor of a TZ-aware
@@ -120,13 +376,188 @@ type Fight struct {
 type HTTPClient struct {
 	HTTP      *http.Client
 	UserAgent string
+	// League is the ESPN league slug requests target; empty means DefaultLeague.
+	League string
+	// ScoreboardTTL is how long a fetched scoreboard root is reused before
+	// ESPN is asked again; zero or negative disables the cache. NewClient
+	// defaults it to DefaultScoreboardTTL.
+	ScoreboardTTL time.Duration
+
+	// scoreboards caches decoded scoreboard roots by league slug and dates so
+	// every guild's tick and each /next-event don't refetch three years of data.
+	scoreboardMu sync.RWMutex
+	scoreboards  map[string]cachedRoot
+
+	// athletes caches resolved athlete details by ESPN athlete id so repeated
+	// card lookups don't refetch every fighter.
+	athleteMu sync.RWMutex
+	athletes  map[string]athleteInfo
+}
+
+// retryDelays are the backoff waits before each retry of a failed ESPN GET;
+// its length is the retry limit. Tests shorten it.
+var retryDelays = []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}
+
+// maxRetryAfter caps how long a 429's Retry-After header can delay a retry.
+const maxRetryAfter = 10 * time.Second
+
+// do sends an idempotent GET, retrying transport errors and 429/5xx responses
+// with exponential backoff. A 429's Retry-After replaces the backoff wait.
+// Retries stop once the wait would outlive the request context; the last
+// response or error is returned to the caller as-is.
+func (c *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTP.Do(req)
+		if attempt >= len(retryDelays) || !shouldRetry(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		wait := retryDelays[attempt]
+		if d, ok := retryAfter(resp); ok {
+			wait = d
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+		logx.Debug("espn.retry", "url", req.URL.String(), "attempt", attempt+1, "status", status, "wait", wait.String())
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a GET outcome is transient: a transport error
+// (other than the context ending), 429, or any 5xx.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5
+}
+
+// retryAfter parses a 429's Retry-After header, given as seconds or an HTTP
+// date, capped at maxRetryAfter.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(v); err == nil {
+		d = time.Until(at)
+	} else {
+		return 0, false
+	}
+	if d < 0 {
+		d = 0
+	}
+	return min(d, maxRetryAfter), true
+}
+
+// DefaultScoreboardTTL is the scoreboard cache lifetime set by NewClient.
+const DefaultScoreboardTTL = 15 * time.Minute
+
+// cachedRoot is a decoded scoreboard root and when it was fetched.
+type cachedRoot struct {
+	root    Root
+	fetched time.Time
+}
+
+// athleteInfo is the cached subset of an ESPN athlete resource.
+type athleteInfo struct {
+	DisplayName string
+	Headshot    string
+	Country     string
 }
 
 func NewClient(httpc *http.Client, userAgent string) *HTTPClient {
+	return NewLeagueClient(httpc, userAgent, DefaultLeague)
+}
+
+// NewLeagueClient returns a client for the given ESPN MMA league slug (e.g., "pfl").
+func NewLeagueClient(httpc *http.Client, userAgent, league string) *HTTPClient {
 	if httpc == nil {
 		httpc = http.DefaultClient
 	}
-	return &HTTPClient{HTTP: httpc, UserAgent: userAgent}
+	return &HTTPClient{
+		HTTP:          httpc,
+		UserAgent:     userAgent,
+		League:        league,
+		ScoreboardTTL: DefaultScoreboardTTL,
+		athletes:      make(map[string]athleteInfo),
+		scoreboards:   make(map[string]cachedRoot),
+	}
+}
+
+// league returns the client's ESPN league slug, defaulting to DefaultLeague.
+func (c *HTTPClient) league() string {
+	if l := strings.TrimSpace(c.League); l != "" {
+		return l
+	}
+	return DefaultLeague
+}
+
+func (c *HTTPClient) cachedAthlete(id string) (athleteInfo, bool) {
+	if id == "" {
+		return athleteInfo{}, false
+	}
+	c.athleteMu.RLock()
+	defer c.athleteMu.RUnlock()
+	a, ok := c.athletes[id]
+	return a, ok
+}
+
+// cachedScoreboard returns the cached root for key if it is younger than the TTL.
+func (c *HTTPClient) cachedScoreboard(key string, now time.Time) (Root, bool) {
+	if c.ScoreboardTTL <= 0 {
+		return Root{}, false
+	}
+	c.scoreboardMu.RLock()
+	defer c.scoreboardMu.RUnlock()
+	e, ok := c.scoreboards[key]
+	if !ok || now.Sub(e.fetched) >= c.ScoreboardTTL {
+		return Root{}, false
+	}
+	return e.root, true
+}
+
+func (c *HTTPClient) cacheScoreboard(key string, root Root, now time.Time) {
+	if c.ScoreboardTTL <= 0 {
+		return
+	}
+	c.scoreboardMu.Lock()
+	defer c.scoreboardMu.Unlock()
+	if c.scoreboards == nil {
+		c.scoreboards = make(map[string]cachedRoot)
+	}
+	c.scoreboards[key] = cachedRoot{root: root, fetched: now}
+}
+
+func (c *HTTPClient) cacheAthlete(id string, a athleteInfo) {
+	if id == "" {
+		return
+	}
+	c.athleteMu.Lock()
+	defer c.athleteMu.Unlock()
+	if c.athletes == nil {
+		c.athletes = make(map[string]athleteInfo)
+	}
+	c.athletes[id] = a
 }
 
 // Removed legacy FetchUFCEvents/Range and internal fetchByDates; use
@@ -137,6 +568,12 @@ type Bout struct {
 	Fighter1    string
 	Fighter2    string
 	WeightClass string // e.g., "Lightweight"; may be empty
+	// Headshot image URLs for each fighter when ESPN provides them
+	Fighter1Headshot string
+	Fighter2Headshot string
+	// Nationalities (see Athlete.Country), empty when unknown
+	Fighter1Country string
+	Fighter2Country string
 }
 
 // FetchUFCCardForEvent retrieves the fight card for a given event ID.
@@ -153,7 +590,7 @@ func (c *HTTPClient) FetchUFCCardForEvent(ctx context.Context, eventID string) (
 	}
 
 	// Step 1: list competitions (individual fights) for the event
-	listURL := fmt.Sprintf(ufcCoreEventCompetitionsURL, eventID)
+	listURL := fmt.Sprintf(coreEventCompetitionsURL, c.league(), eventID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
 	if err != nil {
 		return nil, err
@@ -162,7 +599,7 @@ func (c *HTTPClient) FetchUFCCardForEvent(ctx context.Context, eventID string) (
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		done("step", "list_competitions", "error", err.Error())
 		return nil, err
@@ -179,7 +616,7 @@ func (c *HTTPClient) FetchUFCCardForEvent(ctx context.Context, eventID string) (
 			Ref string `json:"$ref"`
 		} `json:"items"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&compList); err != nil {
+	if err := decodeJSON(resp, &compList); err != nil {
 		done("step", "decode_competitions", "error", err.Error())
 		return nil, err
 	}
@@ -188,90 +625,304 @@ func (c *HTTPClient) FetchUFCCardForEvent(ctx context.Context, eventID string) (
 		return nil, nil
 	}
 
-	// Helper to GET JSON into v
-	doGet := func(url string, v any) error {
-		r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			return err
-		}
-		if c.UserAgent != "" {
-			r.Header.Set("User-Agent", c.UserAgent)
+	// Step 2: fetch every competition concurrently; results are indexed so bout
+	// order matches the competition list.
+	type competition struct {
+		Type struct {
+			Text string `json:"text"`
+		} `json:"type"`
+		Competitors []struct {
+			Athlete struct {
+				Ref string `json:"$ref"`
+			} `json:"athlete"`
+		} `json:"competitors"`
+	}
+	comps := make([]competition, len(compList.Items))
+	if err := forEachLimit(ctx, len(comps), cardFetchConcurrency, func(ctx context.Context, i int) error {
+		return c.getJSON(ctx, compList.Items[i].Ref, &comps[i])
+	}); err != nil {
+		done("step", "fetch_competition", "error", err.Error())
+		return nil, err
+	}
+
+	// Step 3: resolve each distinct uncached athlete concurrently.
+	var refs []string
+	seen := make(map[string]bool)
+	for _, comp := range comps {
+		for _, cpt := range comp.Competitors {
+			ref := cpt.Athlete.Ref
+			if ref == "" || seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			if id, _ := athleteIDFromRef(ref); id != "" {
+				if _, ok := c.cachedAthlete(id); ok {
+					continue
+				}
+			}
+			refs = append(refs, ref)
 		}
-		r.Header.Set("Accept", "application/json")
-		rs, err := c.HTTP.Do(r)
-		if err != nil {
+	}
+	fetched := make([]athleteInfo, len(refs))
+	if err := forEachLimit(ctx, len(refs), cardFetchConcurrency, func(ctx context.Context, i int) error {
+		var raw Athlete
+		if err := c.getJSON(ctx, refs[i], &raw); err != nil {
 			return err
 		}
-		defer rs.Body.Close()
-		if rs.StatusCode/100 != 2 {
-			body, _ := io.ReadAll(io.LimitReader(rs.Body, 1024))
-			return fmt.Errorf("ESPN %d: %s", rs.StatusCode, string(body))
-		}
-		return json.NewDecoder(rs.Body).Decode(v)
-	}
-
-	// Step 2: fetch each competition and resolve athlete names
-	bouts := make([]Bout, 0, len(compList.Items))
-	athleteFetches := 0
-	for _, it := range compList.Items {
-		var comp struct {
-			Type struct {
-				Text string `json:"text"`
-			} `json:"type"`
-			Competitors []struct {
-				Athlete struct {
-					Ref string `json:"$ref"`
-				} `json:"athlete"`
-			} `json:"competitors"`
-		}
-		if err := doGet(it.Ref, &comp); err != nil {
-			done("step", "fetch_competition", "error", err.Error())
-			return nil, err
-		}
-		names := make([]string, 0, 2)
+		fetched[i] = athleteInfo{DisplayName: raw.Display, Headshot: raw.Headshot.Href, Country: raw.Country()}
+		id, _ := athleteIDFromRef(refs[i])
+		c.cacheAthlete(id, fetched[i])
+		return nil
+	}); err != nil {
+		done("step", "fetch_athlete", "error", err.Error())
+		return nil, err
+	}
+	athleteFetches := len(refs)
+	byRef := make(map[string]athleteInfo, len(refs))
+	for i, ref := range refs {
+		byRef[ref] = fetched[i]
+	}
+
+	bouts := make([]Bout, 0, len(comps))
+	for _, comp := range comps {
+		athletes := make([]athleteInfo, 0, 2)
 		for _, cpt := range comp.Competitors {
 			if cpt.Athlete.Ref == "" {
 				continue
 			}
-			var ath struct {
-				DisplayName string `json:"displayName"`
+			ath, ok := byRef[cpt.Athlete.Ref]
+			if !ok {
+				id, _ := athleteIDFromRef(cpt.Athlete.Ref)
+				ath, _ = c.cachedAthlete(id)
 			}
-			if err := doGet(cpt.Athlete.Ref, &ath); err != nil {
-				done("step", "fetch_athlete", "error", err.Error())
-				return nil, err
-			}
-			athleteFetches++
 			if ath.DisplayName != "" {
-				names = append(names, ath.DisplayName)
+				athletes = append(athletes, ath)
 			}
 		}
 		// Ensure we always have two slots
-		f1, f2 := "", ""
-		if len(names) > 0 {
-			f1 = names[0]
+		var a1, a2 athleteInfo
+		if len(athletes) > 0 {
+			a1 = athletes[0]
 		}
-		if len(names) > 1 {
-			f2 = names[1]
+		if len(athletes) > 1 {
+			a2 = athletes[1]
 		}
-		bouts = append(bouts, Bout{Fighter1: f1, Fighter2: f2, WeightClass: comp.Type.Text})
+		bouts = append(bouts, Bout{
+			Fighter1:         a1.DisplayName,
+			Fighter2:         a2.DisplayName,
+			WeightClass:      comp.Type.Text,
+			Fighter1Headshot: a1.Headshot,
+			Fighter2Headshot: a2.Headshot,
+			Fighter1Country:  a1.Country,
+			Fighter2Country:  a2.Country,
+		})
 	}
 	done("competitions", len(compList.Items), "athlete_fetches", athleteFetches, "bouts", len(bouts))
 	return bouts, nil
 }
 
+// getJSON GETs an ESPN API URL and decodes the JSON response into v.
+func (c *HTTPClient) getJSON(ctx context.Context, url string, v any) error {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if c.UserAgent != "" {
+		r.Header.Set("User-Agent", c.UserAgent)
+	}
+	r.Header.Set("Accept", "application/json")
+	rs, err := c.do(r)
+	if err != nil {
+		return err
+	}
+	defer rs.Body.Close()
+	if rs.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(rs.Body, 1024))
+		return fmt.Errorf("ESPN %d: %s", rs.StatusCode, string(body))
+	}
+	return decodeJSON(rs, v)
+}
+
+// ESPN Core API: an athlete's event log (every competition they were booked in)
+const coreAthleteEventLogURL = "https://sports.core.api.espn.com/v2/sports/mma/athletes/%s/eventlog?limit=200"
+
+// Meeting is a completed bout between two athletes.
+type Meeting struct {
+	EventID  string
+	Date     time.Time // zero when ESPN omits it
+	WinnerID string    // ESPN athlete ID; empty for a draw or no contest
+}
+
+// FetchPreviousMeetings returns the completed bouts between athletes a and b,
+// newest first. Both event logs are fetched and intersected by competition, so
+// only the shared competitions need another request.
+func (c *HTTPClient) FetchPreviousMeetings(ctx context.Context, a, b string) ([]Meeting, error) {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" || b == "" || a == b {
+		return nil, fmt.Errorf("two athlete IDs are required")
+	}
+	done := logx.Measure("espn.fetch.meetings", "athlete_a", a, "athlete_b", b)
+	type logItem struct {
+		Event struct {
+			Ref string `json:"$ref"`
+		} `json:"event"`
+		Competition struct {
+			Ref string `json:"$ref"`
+		} `json:"competition"`
+		Played bool `json:"played"`
+	}
+	var logs [2]struct {
+		Events struct {
+			Items []logItem `json:"items"`
+		} `json:"events"`
+	}
+	ids := [2]string{a, b}
+	if err := forEachLimit(ctx, 2, 2, func(ctx context.Context, i int) error {
+		return c.getJSON(ctx, fmt.Sprintf(coreAthleteEventLogURL, ids[i]), &logs[i])
+	}); err != nil {
+		done("step", "event_log", "error", err.Error())
+		return nil, err
+	}
+	// Refs differ in query strings between logs, so match on competition IDs.
+	inB := make(map[string]bool)
+	for _, it := range logs[1].Events.Items {
+		if id, ok := competitionIDFromRef(it.Competition.Ref); ok && it.Played {
+			inB[id] = true
+		}
+	}
+	var shared []logItem
+	for _, it := range logs[0].Events.Items {
+		if id, ok := competitionIDFromRef(it.Competition.Ref); ok && it.Played && inB[id] {
+			shared = append(shared, it)
+			delete(inB, id)
+		}
+	}
+	type competition struct {
+		Date        string `json:"date"`
+		Competitors []struct {
+			ID     string `json:"id"`
+			Winner bool   `json:"winner"`
+		} `json:"competitors"`
+	}
+	comps := make([]competition, len(shared))
+	if err := forEachLimit(ctx, len(shared), cardFetchConcurrency, func(ctx context.Context, i int) error {
+		return c.getJSON(ctx, shared[i].Competition.Ref, &comps[i])
+	}); err != nil {
+		done("step", "fetch_competition", "error", err.Error())
+		return nil, err
+	}
+	out := make([]Meeting, 0, len(shared))
+	for i, comp := range comps {
+		m := Meeting{}
+		m.EventID, _ = eventIDFromRef(shared[i].Event.Ref)
+		if t, err := parseISOUTC(comp.Date); err == nil {
+			m.Date = t
+		}
+		for _, cpt := range comp.Competitors {
+			if cpt.Winner {
+				m.WinnerID = cpt.ID
+			}
+		}
+		out = append(out, m)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.After(out[j].Date) })
+	done("meetings", len(out))
+	return out, nil
+}
+
+// cardFetchConcurrency bounds the concurrent ESPN requests made while
+// resolving a fight card.
+const cardFetchConcurrency = 6
+
+// forEachLimit calls fn(ctx, i) for i in [0, n) with at most limit calls in
+// flight and returns the first error. The first failure cancels the context
+// passed to the remaining calls, and indexes not yet started are skipped.
+func forEachLimit(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, limit)
+	stopped := false
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			stopped = true
+			break loop
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, i); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	if stopped {
+		// The parent context was canceled before every call started.
+		return context.Cause(ctx)
+	}
+	return nil
+}
+
 // FetchNextOrOngoingEventAndCard fetches the UFC scoreboard root for the surrounding years,
 // selects the ongoing event (if now ∈ [start,end) in UTC) or the next event (minimal start > now),
 // resolves the full event (using embedded or fetched $ref), and returns the full card.
 // It returns the event, fights, start/end in UTC, ok=false when not found, or an error.
 func (c *HTTPClient) FetchNextOrOngoingEventAndCard(ctx context.Context, ignoreLabels []string, clock func() time.Time) (*Event, []Fight, time.Time, time.Time, bool, error) {
+	return c.fetchSelectedEventAndCard(ctx, ignoreLabels, clock, findNextOrOngoingEventUTC)
+}
+
+// FetchLastCompletedEventAndCard is like FetchNextOrOngoingEventAndCard but
+// selects the event whose end is most recently before now, so its card carries
+// the decided winners.
+func (c *HTTPClient) FetchLastCompletedEventAndCard(ctx context.Context, ignoreLabels []string, clock func() time.Time) (*Event, []Fight, time.Time, time.Time, bool, error) {
+	return c.fetchSelectedEventAndCard(ctx, ignoreLabels, clock, findLastCompletedEventUTC)
+}
+
+// UpcomingEntry is a calendar listing without its card, for previews of what
+// comes after the next event.
+type UpcomingEntry struct {
+	ID    string // ESPN event ID, "" when the entry has no event ref
+	Name  string
+	Start time.Time
+	End   time.Time // zero when unknown
+}
+
+// FetchUpcomingEntries returns up to n calendar entries that are ongoing or
+// upcoming, soonest first. Cards are not resolved, so this costs no requests
+// beyond the (cached) scoreboards.
+func (c *HTTPClient) FetchUpcomingEntries(ctx context.Context, ignoreLabels []string, clock func() time.Time, n int) ([]UpcomingEntry, error) {
 	nowUTC := clock().UTC()
-	// Fetch scoreboard roots for prev, current, next year to cover boundaries
+	combined, err := c.fetchCombinedRoot(ctx, nowUTC)
+	if err != nil {
+		return nil, err
+	}
+	return upcomingEntriesUTC(combined, ignoreLabels, nowUTC, n), nil
+}
+
+// fetchCombinedRoot merges the scoreboards for the years around now, so
+// selection works across year boundaries.
+func (c *HTTPClient) fetchCombinedRoot(ctx context.Context, nowUTC time.Time) (Root, error) {
 	years := []int{nowUTC.Year() - 1, nowUTC.Year(), nowUTC.Year() + 1}
 	var combined Root
 	for _, y := range years {
 		root, err := c.FetchUFCScoreboardRoot(ctx, fmt.Sprintf("%d", y))
 		if err != nil {
-			return nil, nil, time.Time{}, time.Time{}, false, err
+			return Root{}, err
 		}
 		// Merge calendars into a single league
 		if len(root.Leagues) > 0 {
@@ -282,41 +933,104 @@ func (c *HTTPClient) FetchNextOrOngoingEventAndCard(ctx context.Context, ignoreL
 		}
 		combined.Events = append(combined.Events, root.Events...)
 	}
+	return combined, nil
+}
 
-	// Select calendar entry using UTC logic
-	pick, stUTC, enUTC, selErr := findNextOrOngoingEventUTC(combined, ignoreLabels, clock)
-	if selErr != nil {
-		if selErr == errNoEventSelected {
-			return nil, nil, time.Time{}, time.Time{}, false, nil
-		}
-		return nil, nil, time.Time{}, time.Time{}, false, selErr
-	}
+// eventSelector picks a calendar entry from root, returning errNoEventSelected
+// when none qualifies.
+type eventSelector func(root Root, ignoreLabels []string, clock func() time.Time) (*CalEntry, time.Time, time.Time, error)
 
-	// Resolve full event
-	ev, err := resolveFullEvent(combined, pick, true, c.HTTP)
+func (c *HTTPClient) fetchSelectedEventAndCard(ctx context.Context, ignoreLabels []string, clock func() time.Time, selectEvent eventSelector) (*Event, []Fight, time.Time, time.Time, bool, error) {
+	nowUTC := clock().UTC()
+	combined, err := c.fetchCombinedRoot(ctx, nowUTC)
 	if err != nil {
 		return nil, nil, time.Time{}, time.Time{}, false, err
 	}
 
+	// Select calendar entry using UTC logic. Entries that resolve to non-MMA
+	// events are dropped from the calendar and selection is retried.
+	var (
+		ev           *Event
+		stUTC, enUTC time.Time
+	)
+	for skips := 0; ; skips++ {
+		pick, st, en, selErr := selectEvent(combined, ignoreLabels, clock)
+		if selErr != nil {
+			if selErr == errNoEventSelected {
+				return nil, nil, time.Time{}, time.Time{}, false, nil
+			}
+			return nil, nil, time.Time{}, time.Time{}, false, selErr
+		}
+
+		// Resolve full event
+		full, err := resolveFullEvent(combined, pick, true, c.HTTP)
+		if err != nil {
+			return nil, nil, time.Time{}, time.Time{}, false, err
+		}
+		if isNonMMAEvent(full) && skips < maxNonMMASkips {
+			logx.Info("espn: skipping non-MMA event", "label", pick.Label, "event_id", full.ID)
+			combined = withoutCalEntry(combined, pick)
+			continue
+		}
+		// A calendar end time can run hours past the last bout; once every
+		// bout is over, the event is no longer ongoing and the next one is due.
+		if !en.IsZero() && !nowUTC.Before(st) && nowUTC.Before(en) && isFinishedEvent(full) && skips < maxNonMMASkips {
+			logx.Info("espn: skipping finished event inside its calendar window", "label", pick.Label, "event_id", full.ID)
+			combined = withoutCalEntry(combined, pick)
+			continue
+		}
+		ev, stUTC, enUTC = full, st, en
+		break
+	}
+	logx.Debug("espn.select.resolved", "event_id", ev.ID, "name", ev.Name, "start", stUTC, "end", enUTC, "competitions", len(ev.Competitions))
+
 	fights := listFullCard(ev, time.UTC)
 	// Fallback: if no competitions present, try fetching via core API and adapt
 	if len(fights) == 0 && ev != nil && ev.ID != "" {
 		if bouts, err := c.FetchUFCCardForEvent(ctx, ev.ID); err == nil && len(bouts) > 0 {
 			for _, b := range bouts {
-				fights = append(fights, Fight{WeightClass: b.WeightClass, RedName: b.Fighter1, BlueName: b.Fighter2})
+				fights = append(fights, Fight{
+					WeightClass:  b.WeightClass,
+					RedName:      b.Fighter1,
+					RedHeadshot:  b.Fighter1Headshot,
+					RedCountry:   b.Fighter1Country,
+					BlueName:     b.Fighter2,
+					BlueHeadshot: b.Fighter2Headshot,
+					BlueCountry:  b.Fighter2Country,
+				})
 			}
 		}
 	}
 	return ev, fights, stUTC, enUTC, true, nil
 }
 
-// FetchUFCScoreboardRoot fetches the UFC scoreboard document for a given ESPN 'dates'
-// parameter (usually a year like "2025") and decodes into Root.
+// FetchUFCScoreboardRoot fetches the scoreboard document of the client's league
+// (UFC by default) for a given ESPN 'dates' parameter (usually a year like
+// "2025") and decodes into Root. Successful results are reused for
+// ScoreboardTTL; the returned Root is shared and must not be modified.
 func (c *HTTPClient) FetchUFCScoreboardRoot(ctx context.Context, dates string) (Root, error) {
+	key := c.league() + ":" + dates
+	if root, ok := c.cachedScoreboard(key, time.Now()); ok {
+		logx.Debug("espn.fetch.scoreboard.cached", "key", key)
+		return root, nil
+	}
+	start := time.Now()
+	root, err := c.fetchScoreboardRoot(ctx, dates)
+	metrics.ESPNFetchDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.ESPNFetchErrors.Inc()
+		return Root{}, err
+	}
+	c.cacheScoreboard(key, root, time.Now())
+	return root, nil
+}
+
+// fetchScoreboardRoot requests one scoreboard from ESPN, bypassing the cache.
+func (c *HTTPClient) fetchScoreboardRoot(ctx context.Context, dates string) (Root, error) {
 	done := logx.Measure("espn.fetch.scoreboard", "dates", dates)
 	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(ufcEventsURL, dates), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(scoreboardURL, c.league(), dates), nil)
 	if err != nil {
 		done("error", err.Error())
 		return Root{}, err
@@ -325,7 +1039,7 @@ func (c *HTTPClient) FetchUFCScoreboardRoot(ctx context.Context, dates string) (
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		done("error", err.Error())
 		return Root{}, err
@@ -336,7 +1050,7 @@ func (c *HTTPClient) FetchUFCScoreboardRoot(ctx context.Context, dates string) (
 		return Root{}, fmt.Errorf("ESPN %d", resp.StatusCode)
 	}
 	var root Root
-	if err := json.NewDecoder(resp.Body).Decode(&root); err != nil {
+	if err := decodeJSON(resp, &root); err != nil {
 		done("error", err.Error())
 		return Root{}, err
 	}
@@ -348,6 +1062,42 @@ func (c *HTTPClient) FetchUFCScoreboardRoot(ctx context.Context, dates string) (
 	return root, nil
 }
 
+// FetchPageHeadline fetches an ESPN article/preview page and extracts its headline
+// from the og:title meta tag, falling back to the document <title>. Only the first
+// part of the page is read since the head carries the metadata.
+func (c *HTTPClient) FetchPageHeadline(ctx context.Context, pageURL string) (string, error) {
+	done := logx.MeasureDebug("espn.fetch.headline", "url", pageURL)
+	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		done("error", err.Error())
+		return "", err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Accept", "text/html")
+	resp, err := c.do(req)
+	if err != nil {
+		done("error", err.Error())
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		done("status", resp.StatusCode)
+		return "", fmt.Errorf("ESPN %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256*1024))
+	if err != nil {
+		done("error", err.Error())
+		return "", err
+	}
+	headline := extractHeadline(string(body))
+	done("found", headline != "")
+	return headline, nil
+}
+
 // ---- Internal helpers (tz-aware selection, event resolution, card building) ----
 
 var errNoEventSelected = fmt.Errorf("no matching calendar entry")
@@ -391,25 +1141,93 @@ func containsAnyIgnore(label string, ignores []string) bool {
 	return false
 }
 
+// maxNonMMASkips bounds how many non-MMA calendar entries are skipped while
+// selecting the next event.
+const maxNonMMASkips = 5
+
+// isFinishedEvent reports whether ev lists competitions and all of them are in
+// ESPN's "post" state.
+func isFinishedEvent(ev *Event) bool {
+	if ev == nil || len(ev.Competitions) == 0 {
+		return false
+	}
+	for _, c := range ev.Competitions {
+		if !strings.EqualFold(c.Status.Type.State, "post") {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutCalEntry returns a copy of root's calendars without ce, leaving root
+// itself (which may be a shared cached scoreboard) untouched.
+func withoutCalEntry(root Root, ce *CalEntry) Root {
+	out := root
+	out.Leagues = make([]League, len(root.Leagues))
+	for i, lg := range root.Leagues {
+		out.Leagues[i] = lg
+		out.Leagues[i].Calendar = make([]CalEntry, 0, len(lg.Calendar))
+		for j := range lg.Calendar {
+			if &lg.Calendar[j] != ce {
+				out.Leagues[i].Calendar = append(out.Leagues[i].Calendar, lg.Calendar[j])
+			}
+		}
+	}
+	return out
+}
+
+// isNonMMAEvent reports whether ev lists competitions but none of them is a bout
+// between two competitors (e.g., ceremonies or media days). Events without
+// competitions are not considered non-MMA: their card simply isn't announced yet.
+// A bout whose athletes are still TBA counts as a bout.
+func isNonMMAEvent(ev *Event) bool {
+	if ev == nil || len(ev.Competitions) == 0 {
+		return false
+	}
+	for _, c := range ev.Competitions {
+		if len(c.Competitors) >= 2 {
+			return false
+		}
+	}
+	return true
+}
+
 func findNextOrOngoingEventUTC(root Root, ignoreLabels []string, clock func() time.Time) (*CalEntry, time.Time, time.Time, error) {
 	nowUTC := clock().UTC()
+	// Per-candidate tracing is high volume; only emit it at debug level.
+	debug := logx.DebugEnabled()
+	trace := func(ce *CalEntry, decision string) {
+		if debug {
+			logx.Debug("espn.select.candidate", "label", ce.Label, "start", ce.StartDate, "end", ce.EndDate, "decision", decision)
+		}
+	}
 
 	var ongoing *CalEntry
 	var ongoingST, ongoingEN time.Time
 	var next *CalEntry
 	var nextST, nextEN time.Time
 
+	// ESPN sometimes lists the same event twice with slightly different labels;
+	// keep the first entry per event ref and per start+label so selection is stable.
+	seen := map[string]bool{}
 	for _, lg := range root.Leagues {
 		for i := range lg.Calendar {
 			ce := &lg.Calendar[i]
+			if isDuplicateCalEntry(ce, seen) {
+				trace(ce, "duplicate")
+				continue
+			}
 			if containsAnyIgnore(ce.Label, ignoreLabels) {
+				trace(ce, "ignored")
 				continue
 			}
 			if strings.TrimSpace(ce.StartDate) == "" {
+				trace(ce, "no_start")
 				continue
 			}
 			stUTC, err := parseISOUTC(ce.StartDate)
 			if err != nil {
+				trace(ce, "bad_start")
 				continue
 			}
 			var enUTC time.Time
@@ -420,6 +1238,7 @@ func findNextOrOngoingEventUTC(root Root, ignoreLabels []string, clock func() ti
 			}
 			// ONGOING when end exists and now ∈ [start, end)
 			if !enUTC.IsZero() && (nowUTC.Equal(stUTC) || (nowUTC.After(stUTC) && nowUTC.Before(enUTC))) {
+				trace(ce, "ongoing")
 				if ongoing == nil || stUTC.Before(ongoingST) {
 					ongoing, ongoingST, ongoingEN = ce, stUTC, enUTC
 				}
@@ -427,22 +1246,154 @@ func findNextOrOngoingEventUTC(root Root, ignoreLabels []string, clock func() ti
 			}
 			// NEXT when start > now
 			if stUTC.After(nowUTC) {
+				trace(ce, "upcoming")
 				if next == nil || stUTC.Before(nextST) {
 					next, nextST, nextEN = ce, stUTC, enUTC
 				}
+				continue
 			}
+			trace(ce, "past")
 		}
 	}
 	if ongoing != nil {
+		logx.Debug("espn.select.pick", "label", ongoing.Label, "kind", "ongoing", "start", ongoingST, "end", ongoingEN, "now", nowUTC)
 		return ongoing, ongoingST, ongoingEN, nil
 	}
 	if next != nil {
+		logx.Debug("espn.select.pick", "label", next.Label, "kind", "next", "start", nextST, "end", nextEN, "now", nowUTC)
 		return next, nextST, nextEN, nil
 	}
+	logx.Debug("espn.select.none", "now", nowUTC, "ignore_labels", ignoreLabels)
 	return nil, time.Time{}, time.Time{}, errNoEventSelected
 }
 
-var eventIDFromRefRe = regexp.MustCompile(`/events/(\d+)`)
+// findLastCompletedEventUTC picks the calendar entry whose end is the latest
+// one at or before now. Entries without an end date are skipped, since there is
+// no telling whether they have finished.
+func findLastCompletedEventUTC(root Root, ignoreLabels []string, clock func() time.Time) (*CalEntry, time.Time, time.Time, error) {
+	nowUTC := clock().UTC()
+	var last *CalEntry
+	var lastST, lastEN time.Time
+	seen := map[string]bool{}
+	for _, lg := range root.Leagues {
+		for i := range lg.Calendar {
+			ce := &lg.Calendar[i]
+			if isDuplicateCalEntry(ce, seen) || containsAnyIgnore(ce.Label, ignoreLabels) {
+				continue
+			}
+			stUTC, err := parseISOUTC(ce.StartDate)
+			if err != nil {
+				continue
+			}
+			enUTC, err := parseISOUTC(ce.EndDate)
+			if err != nil || enUTC.After(nowUTC) {
+				continue
+			}
+			if last == nil || enUTC.After(lastEN) {
+				last, lastST, lastEN = ce, stUTC, enUTC
+			}
+		}
+	}
+	if last == nil {
+		logx.Debug("espn.select.none", "kind", "completed", "now", nowUTC, "ignore_labels", ignoreLabels)
+		return nil, time.Time{}, time.Time{}, errNoEventSelected
+	}
+	logx.Debug("espn.select.pick", "label", last.Label, "kind", "completed", "start", lastST, "end", lastEN, "now", nowUTC)
+	return last, lastST, lastEN, nil
+}
+
+// upcomingEntriesUTC lists up to n entries that are ongoing (now within
+// [start, end)) or start after now, ordered by start.
+func upcomingEntriesUTC(root Root, ignoreLabels []string, nowUTC time.Time, n int) []UpcomingEntry {
+	var out []UpcomingEntry
+	seen := map[string]bool{}
+	for _, lg := range root.Leagues {
+		for i := range lg.Calendar {
+			ce := &lg.Calendar[i]
+			if isDuplicateCalEntry(ce, seen) || containsAnyIgnore(ce.Label, ignoreLabels) {
+				continue
+			}
+			stUTC, err := parseISOUTC(ce.StartDate)
+			if err != nil {
+				continue
+			}
+			var enUTC time.Time
+			if t, err := parseISOUTC(ce.EndDate); err == nil {
+				enUTC = t
+			}
+			ongoing := !enUTC.IsZero() && !nowUTC.Before(stUTC) && nowUTC.Before(enUTC)
+			if !ongoing && !stUTC.After(nowUTC) {
+				continue
+			}
+			id, _ := eventIDFromRef(ce.Event.Ref)
+			out = append(out, UpcomingEntry{ID: id, Name: strings.TrimSpace(ce.Label), Start: stUTC, End: enUTC})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	if n >= 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// isDuplicateCalEntry reports whether an entry with the same event ref, or the
+// same start and label, has already been seen, and records this entry's keys.
+func isDuplicateCalEntry(ce *CalEntry, seen map[string]bool) bool {
+	var keys []string
+	if id, ok := eventIDFromRef(ce.Event.Ref); ok {
+		keys = append(keys, "ref:"+id)
+	} else if ref := strings.TrimSpace(ce.Event.Ref); ref != "" {
+		keys = append(keys, "ref:"+ref)
+	}
+	keys = append(keys, "start_label:"+strings.TrimSpace(ce.StartDate)+"|"+strings.ToLower(strings.TrimSpace(ce.Label)))
+	dup := false
+	for _, k := range keys {
+		if seen[k] {
+			dup = true
+		}
+		seen[k] = true
+	}
+	return dup
+}
+
+var (
+	ogTitleRe = regexp.MustCompile(`(?is)<meta[^>]+property=["']og:title["'][^>]+content=["']([^"']+)["']`)
+	titleRe   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// extractHeadline returns the og:title or <title> text of an HTML document.
+func extractHeadline(doc string) string {
+	for _, re := range []*regexp.Regexp{ogTitleRe, titleRe} {
+		if m := re.FindStringSubmatch(doc); len(m) == 2 {
+			if h := strings.TrimSpace(html.UnescapeString(m[1])); h != "" {
+				return h
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	eventIDFromRefRe       = regexp.MustCompile(`/events/(\d+)`)
+	athleteIDFromRefRe     = regexp.MustCompile(`/athletes/(\d+)`)
+	competitionIDFromRefRe = regexp.MustCompile(`/competitions/(\d+)`)
+)
+
+func athleteIDFromRef(ref string) (string, bool) {
+	m := athleteIDFromRefRe.FindStringSubmatch(ref)
+	if len(m) == 2 {
+		return m[1], true
+	}
+	return "", false
+}
+
+func competitionIDFromRef(ref string) (string, bool) {
+	m := competitionIDFromRefRe.FindStringSubmatch(ref)
+	if len(m) == 2 {
+		return m[1], true
+	}
+	return "", false
+}
 
 func eventIDFromRef(ref string) (string, bool) {
 	if ref == "" {
@@ -513,7 +1464,7 @@ func resolveFullEvent(root Root, pick *CalEntry, allowFetch bool, httpClient *ht
 			return nil, fmt.Errorf("fetch event %q: status %d", pick.Event.Ref, resp.StatusCode)
 		}
 		var ev Event
-		if err := json.NewDecoder(resp.Body).Decode(&ev); err != nil {
+		if err := decodeJSON(resp, &ev); err != nil {
 			return nil, err
 		}
 		return &ev, nil
@@ -527,12 +1478,22 @@ func listFullCard(ev *Event, loc *time.Location) []Fight {
 	}
 	fights := make([]Fight, 0, len(ev.Competitions))
 	for _, c := range ev.Competitions {
+		if _, _, ordered := corners(c.Competitors); !ordered && len(c.Competitors) > 1 {
+			logx.Debug("espn: competitor order missing or ambiguous; using list position", "event_id", ev.ID, "competition_id", c.ID)
+		}
 		red, blue := extractNames(c.Competitors)
 		redRec, blueRec := extractRecords(c.Competitors)
-		winner := ""
+		redImg, blueImg := extractHeadshots(c.Competitors)
+		redRank, blueRank := extractRanks(c.Competitors)
+		redCountry, blueCountry := extractCountries(c.Competitors)
+		redID, blueID := extractIDs(c.Competitors)
+		winner, method, round := "", "", 0
 		if strings.EqualFold(c.Status.Type.State, "post") {
 			if w := winnerName(c.Competitors, red, blue); w != "" {
 				winner = w
+				r := c.Status.Result
+				method = strings.TrimSpace(firstNonEmpty(r.DisplayName, r.ShortDisplayName, r.Name))
+				round = c.Status.Period
 			}
 		}
 		sched := time.Time{}
@@ -550,62 +1511,141 @@ func listFullCard(ev *Event, loc *time.Location) []Fight {
 			wc = c.Type.ID
 		}
 		fights = append(fights, Fight{
-			WeightClass: wc,
-			RedName:     red,
-			RedRecord:   redRec,
-			BlueName:    blue,
-			BlueRecord:  blueRec,
-			Winner:      winner,
-			Scheduled:   sched,
+			WeightClass:  wc,
+			RedName:      red,
+			RedRecord:    redRec,
+			RedHeadshot:  redImg,
+			BlueName:     blue,
+			BlueRecord:   blueRec,
+			BlueHeadshot: blueImg,
+			RedCountry:   redCountry,
+			BlueCountry:  blueCountry,
+			Winner:       winner,
+			Method:       method,
+			Round:        round,
+			Scheduled:    sched,
+			RedRank:      redRank,
+			BlueRank:     blueRank,
+			Note:         noteText(c.Notes),
+			RedID:        redID,
+			BlueID:       blueID,
 		})
 	}
 	return fights
 }
 
-func extractNames(cs []Competitor) (red, blue string) {
-	var rOK, bOK bool
-	for _, c := range cs {
-		name := firstNonEmpty(c.Athlete.FullName, c.Athlete.Display, c.Athlete.ShortName)
-		if c.Order == 1 && !rOK {
-			red, rOK = name, true
-		} else if c.Order == 2 && !bOK {
-			blue, bOK = name, true
+// corners returns the red (order 1) and blue (order 2) competitors. When the
+// orders don't name exactly one of each (ESPN sometimes omits them, leaving 0
+// for both), it falls back to list position so both corners are still filled;
+// ordered reports which way it went. Either corner is nil when absent.
+func corners(cs []Competitor) (red, blue *Competitor, ordered bool) {
+	reds, blues := 0, 0
+	for i := range cs {
+		switch cs[i].Order {
+		case 1:
+			reds++
+			red = &cs[i]
+		case 2:
+			blues++
+			blue = &cs[i]
 		}
 	}
-	if !rOK && len(cs) > 0 {
-		red = firstNonEmpty(cs[0].Athlete.FullName, cs[0].Athlete.Display, cs[0].Athlete.ShortName)
+	if reds == 1 && blues == 1 {
+		return red, blue, true
 	}
-	if !bOK && len(cs) > 1 {
-		blue = firstNonEmpty(cs[1].Athlete.FullName, cs[1].Athlete.Display, cs[1].Athlete.ShortName)
+	// A lone competitor keeps its side when its order names one.
+	if len(cs) == 1 && reds+blues == 1 {
+		return red, blue, true
 	}
-	return
+	red, blue = nil, nil
+	if len(cs) > 0 {
+		red = &cs[0]
+	}
+	if len(cs) > 1 {
+		blue = &cs[1]
+	}
+	return red, blue, false
+}
+
+// competitorName returns the best available display name for c ("" when nil).
+func competitorName(c *Competitor) string {
+	if c == nil {
+		return ""
+	}
+	return firstNonEmpty(c.Athlete.FullName, c.Athlete.Display, c.Athlete.ShortName)
+}
+
+func extractNames(cs []Competitor) (red, blue string) {
+	r, b, _ := corners(cs)
+	return competitorName(r), competitorName(b)
 }
 
 func extractRecords(cs []Competitor) (redRec, blueRec string) {
-	for _, c := range cs {
-		rec := ""
-		if len(c.Records) > 0 {
-			rec = c.Records[0].Summary
-		}
-		if c.Order == 1 && redRec == "" {
-			redRec = rec
-		} else if c.Order == 2 && blueRec == "" {
-			blueRec = rec
+	rec := func(c *Competitor) string {
+		if c == nil || len(c.Records) == 0 {
+			return ""
 		}
+		return c.Records[0].Summary
+	}
+	r, b, _ := corners(cs)
+	return rec(r), rec(b)
+}
+
+func extractHeadshots(cs []Competitor) (redImg, blueImg string) {
+	r, b, _ := corners(cs)
+	if r != nil {
+		redImg = r.Athlete.Headshot.Href
+	}
+	if b != nil {
+		blueImg = b.Athlete.Headshot.Href
+	}
+	return
+}
+
+func extractCountries(cs []Competitor) (redCountry, blueCountry string) {
+	r, b, _ := corners(cs)
+	if r != nil {
+		redCountry = r.Athlete.Country()
+	}
+	if b != nil {
+		blueCountry = b.Athlete.Country()
+	}
+	return
+}
+
+func extractIDs(cs []Competitor) (redID, blueID string) {
+	r, b, _ := corners(cs)
+	if r != nil {
+		redID = r.Athlete.ID
+	}
+	if b != nil {
+		blueID = b.Athlete.ID
+	}
+	return
+}
+
+func extractRanks(cs []Competitor) (redRank, blueRank string) {
+	r, b, _ := corners(cs)
+	if r != nil {
+		redRank = r.rankLabel()
+	}
+	if b != nil {
+		blueRank = b.rankLabel()
 	}
 	return
 }
 
 func winnerName(cs []Competitor, red, blue string) string {
-	for _, c := range cs {
-		if c.Winner {
-			if c.Order == 1 {
-				return red
-			}
-			if c.Order == 2 {
-				return blue
-			}
-			return firstNonEmpty(c.Athlete.FullName, c.Athlete.Display, c.Athlete.ShortName)
+	r, b, _ := corners(cs)
+	switch {
+	case r != nil && r.Winner:
+		return red
+	case b != nil && b.Winner:
+		return blue
+	}
+	for i := range cs {
+		if cs[i].Winner {
+			return competitorName(&cs[i])
 		}
 	}
 	return ""
@@ -3,13 +3,17 @@ package espn
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // rewriteTransport redirects all requests to a given base URL, preserving the query.
@@ -72,7 +76,184 @@ func TestFetchUFCScoreboardRoot_UsesYearAndHeaders(t *testing.T) {
 	}
 }
 
+func TestClient_UsesLeagueSlugInPaths(t *testing.T) {
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/competitions") {
+			json.NewEncoder(w).Encode(map[string]any{"items": []any{}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"events": []any{}})
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	httpc := &http.Client{Transport: &rewriteTransport{base: base}}
+
+	tests := []struct {
+		name   string
+		client *HTTPClient
+		slug   string
+	}{
+		{"default", NewClient(httpc, "ua"), "ufc"},
+		{"pfl", NewLeagueClient(httpc, "ua", "pfl"), "pfl"},
+		{"bellator", NewLeagueClient(httpc, "ua", "bellator"), "bellator"},
+		{"empty slug", NewLeagueClient(httpc, "ua", " "), "ufc"},
+	}
+	for _, tc := range tests {
+		gotPaths = nil
+		if _, err := tc.client.FetchUFCScoreboardRoot(context.Background(), "2025"); err != nil {
+			t.Fatalf("%s: scoreboard error: %v", tc.name, err)
+		}
+		if _, err := tc.client.FetchUFCCardForEvent(context.Background(), "600039"); err != nil {
+			t.Fatalf("%s: card error: %v", tc.name, err)
+		}
+		want := []string{
+			"/apis/site/v2/sports/mma/" + tc.slug + "/scoreboard",
+			"/v2/sports/mma/leagues/" + tc.slug + "/events/600039/competitions",
+		}
+		if !reflect.DeepEqual(gotPaths, want) {
+			t.Fatalf("%s: paths got %v want %v", tc.name, gotPaths, want)
+		}
+	}
+}
+
+func TestFetchUFCScoreboardRoot_CachesWithinTTL(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"events": []map[string]any{{"id": r.URL.Query().Get("dates"), "name": "UFC Test"}},
+		})
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+	if c.ScoreboardTTL != DefaultScoreboardTTL {
+		t.Fatalf("expected default TTL %v, got %v", DefaultScoreboardTTL, c.ScoreboardTTL)
+	}
+
+	// Concurrent callers (notifier tick and command handlers) share the cache.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.FetchUFCScoreboardRoot(context.Background(), "2024"); err != nil {
+				t.Errorf("fetch: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	before := hits.Load()
+	root, err := c.FetchUFCScoreboardRoot(context.Background(), "2024")
+	if err != nil || len(root.Events) != 1 || root.Events[0].ID != "2024" {
+		t.Fatalf("cached fetch: root=%+v err=%v", root, err)
+	}
+	if hits.Load() != before {
+		t.Fatalf("expected the second fetch within the TTL to skip the network, hits %d -> %d", before, hits.Load())
+	}
+
+	// Other years (and leagues) are cached separately.
+	if _, err := c.FetchUFCScoreboardRoot(context.Background(), "2025"); err != nil {
+		t.Fatalf("fetch 2025: %v", err)
+	}
+	if hits.Load() != before+1 {
+		t.Fatalf("expected a new year to hit the network, hits %d", hits.Load())
+	}
+
+	// Disabling the cache always refetches.
+	c.ScoreboardTTL = 0
+	if _, err := c.FetchUFCScoreboardRoot(context.Background(), "2024"); err != nil {
+		t.Fatalf("uncached fetch: %v", err)
+	}
+	if hits.Load() != before+2 {
+		t.Fatalf("expected the uncached fetch to hit the network, hits %d", hits.Load())
+	}
+}
+
+// fastRetries shrinks the retry backoff for the duration of a test.
+func fastRetries(t *testing.T) {
+	t.Helper()
+	old := retryDelays
+	retryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { retryDelays = old })
+}
+
+func TestFetchUFCScoreboardRoot_RetriesTransientErrors(t *testing.T) {
+	fastRetries(t)
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"events":[{"id":"1","name":"UFC 300"}]}`))
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+	root, err := c.FetchUFCScoreboardRoot(context.Background(), "2025")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if len(root.Events) != 1 || root.Events[0].Name != "UFC 300" {
+		t.Fatalf("unexpected root: %+v", root)
+	}
+	if hits.Load() != 3 {
+		t.Fatalf("expected 3 requests, got %d", hits.Load())
+	}
+}
+
+func TestFetchUFCScoreboardRoot_RetryLimitAndRetryAfter(t *testing.T) {
+	fastRetries(t)
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+	if _, err := c.FetchUFCScoreboardRoot(context.Background(), "2025"); err == nil || !strings.Contains(err.Error(), "ESPN 429") {
+		t.Fatalf("expected ESPN 429 error, got %v", err)
+	}
+	if hits.Load() != 4 {
+		t.Fatalf("expected 1 request plus 3 retries, got %d", hits.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := func(code int, v string) *http.Response {
+		r := &http.Response{StatusCode: code, Header: http.Header{}}
+		if v != "" {
+			r.Header.Set("Retry-After", v)
+		}
+		return r
+	}
+	if d, ok := retryAfter(resp(429, "2")); !ok || d != 2*time.Second {
+		t.Fatalf("seconds: got %v %v", d, ok)
+	}
+	if d, ok := retryAfter(resp(429, "3600")); !ok || d != maxRetryAfter {
+		t.Fatalf("expected cap, got %v %v", d, ok)
+	}
+	if d, ok := retryAfter(resp(429, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))); !ok || d != 0 {
+		t.Fatalf("past date: got %v %v", d, ok)
+	}
+	if _, ok := retryAfter(resp(503, "2")); ok {
+		t.Fatalf("Retry-After should only apply to 429")
+	}
+	if _, ok := retryAfter(resp(429, "soon")); ok {
+		t.Fatalf("expected invalid header to be ignored")
+	}
+}
+
 func TestFetchUFCScoreboardRoot_Errors(t *testing.T) {
+	fastRetries(t)
 	// non-2xx
 	srvErr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
@@ -100,6 +281,31 @@ func TestFetchUFCScoreboardRoot_Errors(t *testing.T) {
 	}
 }
 
+func TestFetchUFCScoreboardRoot_HTMLBlockPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"html content type", "text/html; charset=utf-8", "<html><body>Access denied</body></html>"},
+		{"html body, json content type", "application/json", "\n  <!DOCTYPE html><title>Are you a robot?</title>"},
+	}
+	for _, tc := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", tc.contentType)
+			w.WriteHeader(200)
+			w.Write([]byte(tc.body))
+		}))
+		base, _ := url.Parse(srv.URL)
+		c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+		_, err := c.FetchUFCScoreboardRoot(context.Background(), "2025")
+		srv.Close()
+		if !errors.Is(err, ErrBlocked) {
+			t.Fatalf("%s: expected ErrBlocked, got %v", tc.name, err)
+		}
+	}
+}
+
 func TestFetchUFCCardForEvent_BuildsBouts(t *testing.T) {
 	// Test server returns competition list, then each competition with competitors,
 	// and athlete details with display names.
@@ -144,3 +350,685 @@ func TestFetchUFCCardForEvent_BuildsBouts(t *testing.T) {
 		t.Fatalf("unexpected first bout: %+v", bouts[0])
 	}
 }
+
+func TestFetchUFCCardForEvent_FetchesConcurrentlyInOrder(t *testing.T) {
+	const (
+		nComps = 13
+		delay  = 50 * time.Millisecond
+	)
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/competitions"):
+			items := make([]map[string]string, nComps)
+			for i := range items {
+				items[i] = map[string]string{"$ref": fmt.Sprintf("/comp/%d", i)}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"items": items})
+		case strings.HasPrefix(r.URL.Path, "/comp/"):
+			i := strings.TrimPrefix(r.URL.Path, "/comp/")
+			json.NewEncoder(w).Encode(map[string]any{
+				"type": map[string]any{"text": "Class " + i},
+				"competitors": []map[string]any{
+					{"athlete": map[string]string{"$ref": "/athletes/" + i + "1"}},
+					{"athlete": map[string]string{"$ref": "/athletes/" + i + "2"}},
+				},
+			})
+		case strings.HasPrefix(r.URL.Path, "/athletes/"):
+			json.NewEncoder(w).Encode(map[string]any{"displayName": "Ath" + strings.TrimPrefix(r.URL.Path, "/athletes/")})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+
+	started := time.Now()
+	bouts, err := c.FetchUFCCardForEvent(context.Background(), "600039")
+	elapsed := time.Since(started)
+	if err != nil {
+		t.Fatalf("FetchUFCCardForEvent error: %v", err)
+	}
+	serial := time.Duration(1+nComps+2*nComps) * delay
+	if elapsed > serial/2 {
+		t.Fatalf("expected concurrent fetches well under the serial %v, took %v", serial, elapsed)
+	}
+	if m := maxInFlight.Load(); m > cardFetchConcurrency {
+		t.Fatalf("expected at most %d requests in flight, saw %d", cardFetchConcurrency, m)
+	}
+	if len(bouts) != nComps {
+		t.Fatalf("expected %d bouts, got %d", nComps, len(bouts))
+	}
+	for i, b := range bouts {
+		want := Bout{Fighter1: fmt.Sprintf("Ath%d1", i), Fighter2: fmt.Sprintf("Ath%d2", i), WeightClass: fmt.Sprintf("Class %d", i)}
+		if b != want {
+			t.Fatalf("bout %d: got %+v want %+v", i, b, want)
+		}
+	}
+}
+
+func TestFetchUFCCardForEvent_AthleteErrorFails(t *testing.T) {
+	fastRetries(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/competitions"):
+			json.NewEncoder(w).Encode(map[string]any{"items": []map[string]string{{"$ref": "/comp/1"}}})
+		case r.URL.Path == "/comp/1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"competitors": []map[string]any{{"athlete": map[string]string{"$ref": "/athletes/1"}}},
+			})
+		default:
+			w.WriteHeader(500)
+		}
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+	if _, err := c.FetchUFCCardForEvent(context.Background(), "600039"); err == nil || !strings.Contains(err.Error(), "ESPN 500") {
+		t.Fatalf("expected athlete fetch error, got %v", err)
+	}
+}
+
+func TestFetchPageHeadline_ParsesOGTitleAndFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/og":
+			w.Write([]byte(`<html><head><title>ESPN</title><meta property="og:title" content="Jones vs Miocic: Preview &amp; Picks"></head></html>`))
+		case "/title":
+			w.Write([]byte(`<html><head><title> Fight Week Preview </title></head></html>`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client(), "ua")
+	got, err := c.FetchPageHeadline(context.Background(), srv.URL+"/og")
+	if err != nil || got != "Jones vs Miocic: Preview & Picks" {
+		t.Fatalf("og:title headline: got %q err=%v", got, err)
+	}
+	got, err = c.FetchPageHeadline(context.Background(), srv.URL+"/title")
+	if err != nil || got != "Fight Week Preview" {
+		t.Fatalf("title fallback headline: got %q err=%v", got, err)
+	}
+	if _, err := c.FetchPageHeadline(context.Background(), srv.URL+"/missing"); err == nil {
+		t.Fatalf("expected error for non-2xx page")
+	}
+}
+
+func TestFetchUFCCardForEvent_CapturesAndCachesHeadshots(t *testing.T) {
+	var mu sync.Mutex
+	athleteHits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/competitions"):
+			json.NewEncoder(w).Encode(map[string]any{"items": []map[string]string{{"$ref": "/comp/1"}}})
+		case r.URL.Path == "/comp/1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"type": map[string]any{"text": "Light Heavyweight"},
+				"competitors": []map[string]any{
+					{"athlete": map[string]string{"$ref": "/athletes/100"}},
+					{"athlete": map[string]string{"$ref": "/athletes/200"}},
+				},
+			})
+		case strings.HasPrefix(r.URL.Path, "/athletes/"):
+			mu.Lock()
+			athleteHits++
+			mu.Unlock()
+			id := strings.TrimPrefix(r.URL.Path, "/athletes/")
+			payload := map[string]any{"displayName": "Ath" + id}
+			if id == "100" {
+				payload["headshot"] = map[string]string{"href": "https://img.example/100.png"}
+			}
+			json.NewEncoder(w).Encode(payload)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+
+	bouts, err := c.FetchUFCCardForEvent(context.Background(), "1")
+	if err != nil || len(bouts) != 1 {
+		t.Fatalf("FetchUFCCardForEvent: bouts=%v err=%v", bouts, err)
+	}
+	if bouts[0].Fighter1Headshot != "https://img.example/100.png" || bouts[0].Fighter2Headshot != "" {
+		t.Fatalf("unexpected headshots: %+v", bouts[0])
+	}
+
+	// Second lookup reuses cached athletes
+	if _, err := c.FetchUFCCardForEvent(context.Background(), "1"); err != nil {
+		t.Fatalf("second fetch: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if athleteHits != 2 {
+		t.Fatalf("expected athletes fetched once each, got %d requests", athleteHits)
+	}
+}
+
+func TestListFullCard_HeadshotStringOrObject(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[{"competitors":[
+		{"order":1,"athlete":{"displayName":"Red","headshot":"https://img.example/red.png"}},
+		{"order":2,"athlete":{"displayName":"Blue","headshot":{"href":"https://img.example/blue.png"}}}
+	]}]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	if len(fights) != 1 || fights[0].RedHeadshot != "https://img.example/red.png" || fights[0].BlueHeadshot != "https://img.example/blue.png" {
+		t.Fatalf("unexpected fights: %+v", fights)
+	}
+}
+
+// scoreboardWithCalendar serves a scoreboard with the given calendar and embedded
+// events, and an empty core competitions list for any event.
+func scoreboardWithCalendar(t *testing.T, cal []map[string]any, evs []map[string]any) *HTTPClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/competitions") {
+			json.NewEncoder(w).Encode(map[string]any{"items": []any{}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"leagues": []map[string]any{{"calendar": cal}},
+			"events":  evs,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	base, _ := url.Parse(srv.URL)
+	return NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+}
+
+func bout(red, blue string) map[string]any {
+	return map[string]any{
+		"id": red + "-" + blue,
+		"competitors": []map[string]any{
+			{"order": 1, "athlete": map[string]any{"fullName": red}},
+			{"order": 2, "athlete": map[string]any{"fullName": blue}},
+		},
+	}
+}
+
+func TestFetchNextOrOngoingEventAndCard_SkipsNonMMAEvents(t *testing.T) {
+	cal := []map[string]any{
+		{"label": "UFC Hall of Fame Ceremony", "startDate": "2025-06-26T23:00Z", "endDate": "2025-06-27T02:00Z", "event": map[string]any{"$ref": "http://x/events/100"}},
+		{"label": "UFC 317", "startDate": "2025-06-28T22:00Z", "endDate": "2025-06-29T06:00Z", "event": map[string]any{"$ref": "http://x/events/200"}},
+	}
+	evs := []map[string]any{
+		{"id": "100", "name": "UFC Hall of Fame Ceremony", "date": "2025-06-26T23:00Z", "competitions": []map[string]any{{"id": "c1"}}},
+		{"id": "200", "name": "UFC 317", "date": "2025-06-28T22:00Z", "competitions": []map[string]any{bout("Ilia Topuria", "Charles Oliveira")}},
+	}
+	c := scoreboardWithCalendar(t, cal, evs)
+	clock := func() time.Time { return time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC) }
+
+	ev, fights, _, _, ok, err := c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok {
+		t.Fatalf("expected event, ok=%v err=%v", ok, err)
+	}
+	if ev.ID != "200" || len(fights) != 1 {
+		t.Fatalf("expected UFC 317 with one bout, got id=%q fights=%d", ev.ID, len(fights))
+	}
+}
+
+func TestFetchNextOrOngoingEventAndCard_SkipsNonMMAEventByIdentity(t *testing.T) {
+	// The ceremony's label is a substring of the real event's label; skipping
+	// it must not hide UFC 317 as well.
+	cal := []map[string]any{
+		{"label": "UFC 317", "startDate": "2025-06-26T23:00Z", "endDate": "2025-06-27T02:00Z", "event": map[string]any{"$ref": "http://x/events/100"}},
+		{"label": "UFC 317: Topuria vs. Oliveira", "startDate": "2025-06-28T22:00Z", "endDate": "2025-06-29T06:00Z", "event": map[string]any{"$ref": "http://x/events/200"}},
+	}
+	evs := []map[string]any{
+		{"id": "100", "name": "UFC 317 Press Conference", "date": "2025-06-26T23:00Z", "competitions": []map[string]any{{"id": "c1"}}},
+		{"id": "200", "name": "UFC 317: Topuria vs. Oliveira", "date": "2025-06-28T22:00Z", "competitions": []map[string]any{bout("Ilia Topuria", "Charles Oliveira")}},
+	}
+	c := scoreboardWithCalendar(t, cal, evs)
+	clock := func() time.Time { return time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC) }
+
+	ev, _, _, _, ok, err := c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok {
+		t.Fatalf("expected event, ok=%v err=%v", ok, err)
+	}
+	if ev.ID != "200" {
+		t.Fatalf("expected UFC 317 after skipping the press conference, got id=%q", ev.ID)
+	}
+}
+
+func TestFetchNextOrOngoingEventAndCard_KeepsEventWithTBACompetitors(t *testing.T) {
+	tba := map[string]any{
+		"id": "tba",
+		"competitors": []map[string]any{
+			{"order": 1, "athlete": map[string]any{}},
+			{"order": 2, "athlete": map[string]any{}},
+		},
+	}
+	cal := []map[string]any{
+		{"label": "UFC Fight Night", "startDate": "2025-06-26T23:00Z", "event": map[string]any{"$ref": "http://x/events/100"}},
+		{"label": "UFC 317", "startDate": "2025-06-28T22:00Z", "event": map[string]any{"$ref": "http://x/events/200"}},
+	}
+	evs := []map[string]any{
+		{"id": "100", "name": "UFC Fight Night", "date": "2025-06-26T23:00Z", "competitions": []map[string]any{tba}},
+		{"id": "200", "name": "UFC 317", "date": "2025-06-28T22:00Z", "competitions": []map[string]any{bout("Ilia Topuria", "Charles Oliveira")}},
+	}
+	c := scoreboardWithCalendar(t, cal, evs)
+	clock := func() time.Time { return time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC) }
+
+	ev, _, _, _, ok, err := c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok {
+		t.Fatalf("expected event, ok=%v err=%v", ok, err)
+	}
+	if ev.ID != "100" {
+		t.Fatalf("expected the card with TBA athletes to be kept, got id=%q", ev.ID)
+	}
+}
+
+func TestFetchNextOrOngoingEventAndCard_KeepsEventWithoutCardYet(t *testing.T) {
+	cal := []map[string]any{
+		{"label": "UFC Fight Night: TBA", "startDate": "2025-06-26T23:00Z", "event": map[string]any{"$ref": "http://x/events/100"}},
+		{"label": "UFC 317", "startDate": "2025-06-28T22:00Z", "event": map[string]any{"$ref": "http://x/events/200"}},
+	}
+	evs := []map[string]any{
+		{"id": "100", "name": "UFC Fight Night: TBA", "date": "2025-06-26T23:00Z"},
+		{"id": "200", "name": "UFC 317", "date": "2025-06-28T22:00Z", "competitions": []map[string]any{bout("Ilia Topuria", "Charles Oliveira")}},
+	}
+	c := scoreboardWithCalendar(t, cal, evs)
+	clock := func() time.Time { return time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC) }
+
+	ev, fights, _, _, ok, err := c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok {
+		t.Fatalf("expected event, ok=%v err=%v", ok, err)
+	}
+	if ev.ID != "100" || len(fights) != 0 {
+		t.Fatalf("expected the unannounced card to be kept, got id=%q fights=%d", ev.ID, len(fights))
+	}
+}
+
+func TestFetchNextOrOngoingEventAndCard_FinishedCardNotOngoing(t *testing.T) {
+	finished := func(red, blue, state string) map[string]any {
+		b := bout(red, blue)
+		b["status"] = map[string]any{"type": map[string]any{"state": state}}
+		return b
+	}
+	cal := []map[string]any{
+		{"label": "UFC 316", "startDate": "2025-06-07T22:00Z", "endDate": "2025-06-08T06:00Z", "event": map[string]any{"$ref": "http://x/events/100"}},
+		{"label": "UFC 317", "startDate": "2025-06-28T22:00Z", "endDate": "2025-06-29T04:00Z", "event": map[string]any{"$ref": "http://x/events/200"}},
+	}
+	evs := []map[string]any{
+		{"id": "100", "name": "UFC 316", "date": "2025-06-07T22:00Z", "competitions": []map[string]any{
+			finished("Merab Dvalishvili", "Sean O'Malley", "post"),
+			finished("Julianna Pena", "Kayla Harrison", "post"),
+		}},
+		{"id": "200", "name": "UFC 317", "date": "2025-06-28T22:00Z", "competitions": []map[string]any{bout("Ilia Topuria", "Charles Oliveira")}},
+	}
+	c := scoreboardWithCalendar(t, cal, evs)
+
+	// Inside UFC 316's calendar window, but every bout is over.
+	clock := func() time.Time { return time.Date(2025, 6, 8, 4, 0, 0, 0, time.UTC) }
+	ev, _, _, _, ok, err := c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok {
+		t.Fatalf("expected event, ok=%v err=%v", ok, err)
+	}
+	if ev.ID != "200" {
+		t.Fatalf("expected the finished card to be skipped for UFC 317, got id=%q", ev.ID)
+	}
+
+	// While a bout is still in progress the event stays ongoing.
+	evs[0]["competitions"] = []map[string]any{
+		finished("Merab Dvalishvili", "Sean O'Malley", "in"),
+		finished("Julianna Pena", "Kayla Harrison", "post"),
+	}
+	c = scoreboardWithCalendar(t, cal, evs)
+	ev, _, _, _, ok, err = c.FetchNextOrOngoingEventAndCard(context.Background(), nil, clock)
+	if err != nil || !ok || ev.ID != "100" {
+		t.Fatalf("expected the live card to stay ongoing, got ok=%v err=%v ev=%+v", ok, err, ev)
+	}
+}
+
+func TestListFullCard_CapturesRankings(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"competitors":[
+			{"order":1,"athlete":{"displayName":"Champ"},"rank":"C"},
+			{"order":2,"athlete":{"displayName":"Contender"},"rank":3}
+		]},
+		{"competitors":[
+			{"order":1,"athlete":{"displayName":"Curated"},"curatedRank":{"current":7}},
+			{"order":2,"athlete":{"displayName":"Unranked"},"curatedRank":{"current":99}}
+		]},
+		{"competitors":[
+			{"order":1,"athlete":{"displayName":"Odd"},"rank":{"unexpected":true}},
+			{"order":2,"athlete":{"displayName":"Plain"}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	want := [][2]string{{"C", "#3"}, {"#7", ""}, {"", ""}}
+	if len(fights) != len(want) {
+		t.Fatalf("expected %d fights, got %d", len(want), len(fights))
+	}
+	for i, w := range want {
+		if fights[i].RedRank != w[0] || fights[i].BlueRank != w[1] {
+			t.Fatalf("fight %d ranks: got (%q, %q) want (%q, %q)", i, fights[i].RedRank, fights[i].BlueRank, w[0], w[1])
+		}
+	}
+}
+
+func TestListFullCard_CapturesCountries(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"competitors":[
+			{"order":1,"athlete":{"displayName":"Flagged","flag":{"href":"https://a.espncdn.com/i/teamlogos/countries/500/bra.png","alt":"Brazil"}}},
+			{"order":2,"athlete":{"displayName":"Citizen","citizenship":"USA"}}
+		]},
+		{"competitors":[
+			{"order":1,"athlete":{"displayName":"Stateless"}},
+			{"order":2,"athlete":{"displayName":"Coded","citizenshipCountry":{"abbreviation":"IRL"}}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	want := [][2]string{{"Brazil", "USA"}, {"", "IRL"}}
+	if len(fights) != len(want) {
+		t.Fatalf("expected %d fights, got %d", len(want), len(fights))
+	}
+	for i, w := range want {
+		if fights[i].RedCountry != w[0] || fights[i].BlueCountry != w[1] {
+			t.Fatalf("fight %d countries: got (%q, %q) want (%q, %q)", i, fights[i].RedCountry, fights[i].BlueCountry, w[0], w[1])
+		}
+	}
+}
+
+func TestEventVenue(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"competitors":[]},
+		{"venue":{"fullName":"T-Mobile Arena","address":{"city":"Las Vegas","state":"NV","country":"USA"}}}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if name, loc := EventVenue(&ev); name != "T-Mobile Arena" || loc != "Las Vegas, NV" {
+		t.Fatalf("got (%q, %q)", name, loc)
+	}
+
+	abroad := Venue{FullName: "Etihad Arena"}
+	abroad.Address.City, abroad.Address.Country = "Abu Dhabi", "United Arab Emirates"
+	if got := abroad.Location(); got != "Abu Dhabi, United Arab Emirates" {
+		t.Fatalf("foreign location: got %q", got)
+	}
+	if name, loc := EventVenue(&Event{}); name != "" || loc != "" {
+		t.Fatalf("expected empty venue, got (%q, %q)", name, loc)
+	}
+}
+
+func TestEventBroadcasts(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"broadcasts":[{"market":"national","names":["ESPN+","ABC"]}]},
+		{"broadcasts":[{"market":"national","names":["espn+"," "]},{"market":"home","names":["TSN"]}]},
+		{"competitors":[]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if got, want := EventBroadcasts(&ev), []string{"ESPN+", "ABC", "TSN"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	if got := EventBroadcasts(&Event{}); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+}
+
+func TestUpcomingEntriesUTC(t *testing.T) {
+	var root Root
+	raw := `{"leagues":[{"calendar":[
+		{"label":"UFC 301","startDate":"2024-05-04T22:00Z","event":{"$ref":"http://x/events/600040"}},
+		{"label":"UFC 299","startDate":"2024-03-09T22:00Z","endDate":"2024-03-10T04:00Z"},
+		{"label":"UFC 300","startDate":"2024-04-13T22:00Z","endDate":"2024-04-14T04:00Z","event":{"$ref":"http://x/events/600039"}},
+		{"label":"UFC Fight Night","startDate":"2024-04-20T22:00Z"}
+	]}]}`
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// UFC 300 is ongoing, so it leads; UFC 299 is over.
+	now := time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC)
+	got := upcomingEntriesUTC(root, nil, now, 2)
+	if len(got) != 2 || got[0].Name != "UFC 300" || got[0].ID != "600039" || got[1].Name != "UFC Fight Night" {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+	if all := upcomingEntriesUTC(root, []string{"Fight Night"}, now, 5); len(all) != 2 || all[1].Name != "UFC 301" {
+		t.Fatalf("expected ignores to apply, got %+v", all)
+	}
+}
+
+func TestListFullCard_CapturesNotes(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"notes":[{"type":"event","headline":"Rematch"},{"type":"event","headline":" "},{"type":"event","headline":"Rematch"}],
+		 "competitors":[{"order":1,"athlete":{"displayName":"A"}},{"order":2,"athlete":{"displayName":"B"}}]},
+		{"competitors":[{"order":1,"athlete":{"displayName":"C"}},{"order":2,"athlete":{"displayName":"D"}}]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	if len(fights) != 2 || fights[0].Note != "Rematch" || fights[1].Note != "" {
+		t.Fatalf("unexpected notes: %+v", fights)
+	}
+}
+
+func TestFindLastCompletedEventUTC(t *testing.T) {
+	var root Root
+	raw := `{"leagues":[{"calendar":[
+		{"label":"UFC 299","startDate":"2024-03-09T22:00Z","endDate":"2024-03-10T04:00Z"},
+		{"label":"UFC 300","startDate":"2024-04-13T22:00Z","endDate":"2024-04-14T04:00Z"},
+		{"label":"Dana White's Contender Series","startDate":"2024-04-14T00:00Z","endDate":"2024-04-14T05:00Z"},
+		{"label":"UFC No End","startDate":"2024-04-15T22:00Z"},
+		{"label":"UFC Fight Night","startDate":"2024-04-20T22:00Z","endDate":"2024-04-21T04:00Z"}
+	]}]}`
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	tests := []struct {
+		now     time.Time
+		ignores []string
+		want    string
+	}{
+		// Mid-event: UFC Fight Night hasn't ended, so the previous card wins.
+		{time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC), []string{"Contender Series"}, "UFC 300"},
+		{time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC), nil, "Dana White's Contender Series"},
+		{time.Date(2024, 4, 21, 4, 0, 0, 0, time.UTC), nil, "UFC Fight Night"},
+	}
+	for i, tc := range tests {
+		ce, _, en, err := findLastCompletedEventUTC(root, tc.ignores, func() time.Time { return tc.now })
+		if err != nil {
+			t.Fatalf("case %d: select: %v", i, err)
+		}
+		if ce.Label != tc.want || en.After(tc.now) {
+			t.Fatalf("case %d: got %q ending %v, want %q", i, ce.Label, en, tc.want)
+		}
+	}
+	early := func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	if _, _, _, err := findLastCompletedEventUTC(root, nil, early); err != errNoEventSelected {
+		t.Fatalf("expected errNoEventSelected before any event ended, got %v", err)
+	}
+}
+
+func TestFindNextOrOngoingEventUTC_DedupsInYearCalendar(t *testing.T) {
+	var root Root
+	raw := `{"leagues":[{"calendar":[
+		{"label":"UFC 300","startDate":"2024-04-13T22:00Z","event":{"$ref":"http://x/events/600039?lang=en"}},
+		{"label":"UFC 300: Pereira vs. Hill","startDate":"2024-04-13T21:00Z","event":{"$ref":"http://x/events/600039"}},
+		{"label":"UFC Fight Night","startDate":"2024-04-20T22:00Z","event":{"$ref":""}},
+		{"label":"ufc fight night ","startDate":"2024-04-20T22:00Z","event":{"$ref":""}}
+	]}]}`
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	clock := func() time.Time { return time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC) }
+
+	for i := 0; i < 3; i++ {
+		ce, st, _, err := findNextOrOngoingEventUTC(root, nil, clock)
+		if err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if ce.Label != "UFC 300" || !st.Equal(time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)) {
+			t.Fatalf("expected first listing of UFC 300, got %q at %v", ce.Label, st)
+		}
+	}
+
+	seen := map[string]bool{}
+	var kept []string
+	for _, lg := range root.Leagues {
+		for i := range lg.Calendar {
+			if !isDuplicateCalEntry(&lg.Calendar[i], seen) {
+				kept = append(kept, lg.Calendar[i].Label)
+			}
+		}
+	}
+	if len(kept) != 2 || kept[0] != "UFC 300" || kept[1] != "UFC Fight Night" {
+		t.Fatalf("expected one entry per event, got %q", kept)
+	}
+}
+
+func TestListFullCard_CapturesResultMethod(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"status":{"type":{"state":"post"},"period":2,"result":{"name":"kotko","displayName":"KO/TKO"}},"competitors":[
+			{"order":1,"winner":true,"athlete":{"displayName":"Winner"}},
+			{"order":2,"athlete":{"displayName":"Loser"}}
+		]},
+		{"status":{"type":{"state":"pre"},"result":{"displayName":"KO/TKO"}},"competitors":[
+			{"order":1,"athlete":{"displayName":"Red"}},
+			{"order":2,"athlete":{"displayName":"Blue"}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	if f := fights[0]; f.Winner != "Winner" || f.Method != "KO/TKO" || f.Round != 2 {
+		t.Fatalf("decided bout: got winner=%q method=%q round=%d", f.Winner, f.Method, f.Round)
+	}
+	if f := fights[1]; f.Winner != "" || f.Method != "" || f.Round != 0 {
+		t.Fatalf("undecided bout should carry no result, got %+v", f)
+	}
+}
+
+func TestListFullCard_MissingOrFuzzyOrder(t *testing.T) {
+	var ev Event
+	payload := `{"competitions":[
+		{"competitors":[
+			{"athlete":{"id":"1","displayName":"First","headshot":"https://img.example/1.png"},"records":[{"summary":"10-1-0"}]},
+			{"athlete":{"id":"2","displayName":"Second","headshot":"https://img.example/2.png"},"records":[{"summary":"9-2-0"}]}
+		]},
+		{"competitors":[
+			{"order":1,"athlete":{"id":"3","displayName":"Also Red"}},
+			{"order":1,"athlete":{"id":"4","displayName":"Both Red"}}
+		]},
+		{"competitors":[
+			{"order":2,"athlete":{"id":"5","displayName":"Blue Listed First"}},
+			{"order":1,"athlete":{"id":"6","displayName":"Red Listed Second"}}
+		]},
+		{"status":{"type":{"state":"post"}},"competitors":[
+			{"order":0,"athlete":{"id":"7","displayName":"Zero A"}},
+			{"order":0,"winner":true,"athlete":{"id":"8","displayName":"Zero B"}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	fights := listFullCard(&ev, time.UTC)
+	want := []struct{ red, blue, redID, blueID string }{
+		{"First", "Second", "1", "2"},
+		{"Also Red", "Both Red", "3", "4"},
+		{"Red Listed Second", "Blue Listed First", "6", "5"},
+		{"Zero A", "Zero B", "7", "8"},
+	}
+	if len(fights) != len(want) {
+		t.Fatalf("expected %d fights, got %d", len(want), len(fights))
+	}
+	for i, w := range want {
+		f := fights[i]
+		if f.RedName != w.red || f.BlueName != w.blue || f.RedID != w.redID || f.BlueID != w.blueID {
+			t.Fatalf("fight %d corners: got (%q, %q, %q, %q) want %+v", i, f.RedName, f.BlueName, f.RedID, f.BlueID, w)
+		}
+	}
+	if f := fights[0]; f.RedRecord != "10-1-0" || f.BlueRecord != "9-2-0" || f.RedHeadshot != "https://img.example/1.png" || f.BlueHeadshot != "https://img.example/2.png" {
+		t.Fatalf("unordered bout details: %+v", f)
+	}
+	if fights[3].Winner != "Zero B" {
+		t.Fatalf("unordered winner: got %q", fights[3].Winner)
+	}
+}
+
+func TestFetchPreviousMeetings_IntersectsEventLogs(t *testing.T) {
+	ref := func(event, comp string) map[string]any {
+		return map[string]any{
+			"event":       map[string]string{"$ref": "http://core/v2/sports/mma/leagues/ufc/events/" + event + "?lang=en"},
+			"competition": map[string]string{"$ref": "/v2/sports/mma/leagues/ufc/events/" + event + "/competitions/" + comp},
+			"played":      comp != "903", // the upcoming rematch isn't played yet
+		}
+	}
+	var compFetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/athletes/1/eventlog"):
+			json.NewEncoder(w).Encode(map[string]any{"events": map[string]any{"items": []any{ref("501", "901"), ref("502", "902"), ref("503", "903"), ref("504", "904")}}})
+		case strings.HasSuffix(r.URL.Path, "/athletes/2/eventlog"):
+			json.NewEncoder(w).Encode(map[string]any{"events": map[string]any{"items": []any{ref("501", "901"), ref("502", "902"), ref("503", "903"), ref("505", "905")}}})
+		case strings.HasSuffix(r.URL.Path, "/competitions/901"):
+			compFetches.Add(1)
+			json.NewEncoder(w).Encode(map[string]any{"date": "2019-03-02T03:00Z", "competitors": []any{map[string]any{"id": "1", "winner": true}, map[string]any{"id": "2"}}})
+		case strings.HasSuffix(r.URL.Path, "/competitions/902"):
+			compFetches.Add(1)
+			json.NewEncoder(w).Encode(map[string]any{"date": "2021-07-11T04:00Z", "competitors": []any{map[string]any{"id": "1"}, map[string]any{"id": "2"}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&http.Client{Transport: &rewriteTransport{base: base}}, "ua")
+	got, err := c.FetchPreviousMeetings(context.Background(), "1", "2")
+	if err != nil {
+		t.Fatalf("FetchPreviousMeetings: %v", err)
+	}
+	want := []Meeting{
+		{EventID: "502", Date: time.Date(2021, 7, 11, 4, 0, 0, 0, time.UTC)},
+		{EventID: "501", Date: time.Date(2019, 3, 2, 3, 0, 0, 0, time.UTC), WinnerID: "1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("meetings:\n got %+v\nwant %+v", got, want)
+	}
+	if n := compFetches.Load(); n != 2 {
+		t.Fatalf("expected only the 2 shared competitions fetched, got %d", n)
+	}
+	if _, err := c.FetchPreviousMeetings(context.Background(), "1", ""); err == nil {
+		t.Fatal("expected an error without both athlete IDs")
+	}
+}
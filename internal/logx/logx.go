@@ -1,6 +1,7 @@
 package logx
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"strings"
@@ -58,6 +59,10 @@ func getenv(k, def string) string {
 // Debug logs at debug level with structured fields.
 func Debug(msg string, kv ...any) { defaultLogger.Debug(msg, kv...) }
 
+// DebugEnabled reports whether debug logs would be emitted, so callers can skip
+// building expensive or high-volume debug fields.
+func DebugEnabled() bool { return defaultLogger.Enabled(context.Background(), slog.LevelDebug) }
+
 // Info logs at info level with structured fields.
 func Info(msg string, kv ...any) { defaultLogger.Info(msg, kv...) }
 
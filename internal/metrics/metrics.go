@@ -0,0 +1,131 @@
+// Package metrics keeps process-wide counters and histograms and serves them
+// in the Prometheus text exposition format, without pulling in a client
+// library.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Notifier and ESPN metrics, registered in the order they are exposed.
+var (
+	NotifierTicks     = newCounter("notifier_ticks_total", "Notifier ticks run.")
+	MessagesSent      = newCounter("messages_sent_total", "Fight-night alert messages sent to Discord.")
+	MessageSendErrors = newCounter("message_send_errors_total", "Fight-night alert messages that failed to send.")
+	ESPNFetchErrors   = newCounter("espn_fetch_errors_total", "ESPN scoreboard fetches that failed.")
+	ESPNFetchDuration = newHistogram("espn_fetch_duration_seconds", "ESPN scoreboard fetch latency in seconds.",
+		[]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10})
+)
+
+// collector is a metric that can write itself in the text format.
+type collector interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []collector
+)
+
+func register(c collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// Counter is a monotonically increasing count, safe for concurrent use.
+type Counter struct {
+	name, help string
+	v          atomic.Uint64
+}
+
+func newCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	register(c)
+	return c
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() { c.v.Add(1) }
+
+// Value returns the current count.
+func (c *Counter) Value() uint64 { return c.v.Load() }
+
+func (c *Counter) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+}
+
+// Histogram counts observations into cumulative upper-bound buckets, safe for
+// concurrent use.
+type Histogram struct {
+	name, help string
+	buckets    []float64 // ascending upper bounds; +Inf is implied
+
+	mu     sync.Mutex
+	counts []uint64 // per bucket, non-cumulative
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(name, help string, buckets []float64) *Histogram {
+	h := &Histogram{name: name, help: help, buckets: buckets, counts: make([]uint64, len(buckets))}
+	register(h)
+	return h
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, ub := range h.buckets {
+		if v <= ub {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	counts := append([]uint64(nil), h.counts...)
+	sum, count := h.sum, h.count
+	h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	var cum uint64
+	for i, ub := range h.buckets {
+		cum += counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", h.name, strconv.FormatFloat(ub, 'g', -1, 64), cum)
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", h.name, count)
+	fmt.Fprintf(w, "%s_sum %s\n%s_count %d\n", h.name, strconv.FormatFloat(sum, 'g', -1, 64), h.name, count)
+}
+
+// WriteText writes every registered metric in the Prometheus text format.
+func WriteText(w io.Writer) error {
+	registryMu.Lock()
+	cs := append([]collector(nil), registry...)
+	registryMu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range cs {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler serves WriteText for scraping at /metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WriteText(w)
+	})
+}
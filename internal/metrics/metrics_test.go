@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_TextFormat(t *testing.T) {
+	c := &Counter{name: "test_total", help: "A test counter."}
+	c.Inc()
+	c.Inc()
+	h := &Histogram{name: "test_seconds", help: "A test histogram.", buckets: []float64{0.5, 1}, counts: make([]uint64, 2)}
+	h.Observe(0.2)
+	h.Observe(0.7)
+	h.Observe(3)
+
+	var b strings.Builder
+	c.write(&b)
+	h.write(&b)
+	want := `# HELP test_total A test counter.
+# TYPE test_total counter
+test_total 2
+# HELP test_seconds A test histogram.
+# TYPE test_seconds histogram
+test_seconds_bucket{le="0.5"} 1
+test_seconds_bucket{le="1"} 2
+test_seconds_bucket{le="+Inf"} 3
+test_seconds_sum 3.9
+test_seconds_count 3
+`
+	if b.String() != want {
+		t.Fatalf("text format:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("content type %q", ct)
+	}
+	for _, name := range []string{"notifier_ticks_total", "messages_sent_total", "espn_fetch_errors_total", "espn_fetch_duration_seconds_count"} {
+		if !strings.Contains(rec.Body.String(), "\n"+name+" ") {
+			t.Fatalf("expected %s in scrape:\n%s", name, rec.Body.String())
+		}
+	}
+}
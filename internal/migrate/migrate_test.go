@@ -42,21 +42,22 @@ func TestRun_AppliesInitialSchema(t *testing.T) {
 
 	// guild_settings columns
 	gs := tableInfo(t, db, "guild_settings")
-	if len(gs) != 8 {
+	if len(gs) != 9 {
 		t.Fatalf("guild_settings columns: got %d", len(gs))
 	}
 	wantGs := map[string]struct {
 		typ string
 		pk  bool
 	}{
-		"guild_id":   {typ: "TEXT", pk: true},
-		"channel_id": {typ: "TEXT", pk: false},
-		"timezone":   {typ: "TEXT", pk: false},
-		"enabled":    {typ: "INTEGER", pk: false},
-		"org":        {typ: "TEXT", pk: false},
-		"run_hour":   {typ: "INTEGER", pk: false},
-		"announce":   {typ: "INTEGER", pk: false},
-		"events":     {typ: "INTEGER", pk: false},
+		"guild_id":     {typ: "TEXT", pk: true},
+		"channel_id":   {typ: "TEXT", pk: false},
+		"timezone":     {typ: "TEXT", pk: false},
+		"enabled":      {typ: "INTEGER", pk: false},
+		"org":          {typ: "TEXT", pk: false},
+		"run_hour":     {typ: "INTEGER", pk: false},
+		"announce":     {typ: "INTEGER", pk: false},
+		"events":       {typ: "INTEGER", pk: false},
+		"ping_role_id": {typ: "TEXT", pk: false},
 	}
 	for _, c := range gs {
 		w, ok := wantGs[c.Name]
@@ -73,7 +74,7 @@ func TestRun_AppliesInitialSchema(t *testing.T) {
 
 	// last_posted columns
 	lp := tableInfo(t, db, "last_posted")
-	if len(lp) != 3 {
+	if len(lp) != 4 {
 		t.Fatalf("last_posted columns: got %d", len(lp))
 	}
 	wantLp := map[string]struct {
@@ -83,6 +84,7 @@ func TestRun_AppliesInitialSchema(t *testing.T) {
 		"guild_id":  {typ: "TEXT", pk: true},
 		"sport":     {typ: "TEXT", pk: true},
 		"last_date": {typ: "TEXT", pk: false},
+		"prev_date": {typ: "TEXT", pk: false},
 	}
 	for _, c := range lp {
 		w, ok := wantLp[c.Name]
@@ -98,6 +100,32 @@ func TestRun_AppliesInitialSchema(t *testing.T) {
 	}
 }
 
+func TestRun_CreatesPostedEvents(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	if err := Run(dbPath); err != nil {
+		t.Fatalf("migrate run: %v", err)
+	}
+	db, err := sqlx.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	pk := map[string]bool{}
+	for _, c := range tableInfo(t, db, "posted_events") {
+		pk[c.Name] = c.PK != 0
+	}
+	want := map[string]bool{"guild_id": true, "org": true, "event_id": true, "kind": true, "post_date": false}
+	if len(pk) != len(want) {
+		t.Fatalf("posted_events columns: got %v", pk)
+	}
+	for name, isPK := range want {
+		if got, ok := pk[name]; !ok || got != isPK {
+			t.Fatalf("posted_events.%s: present=%v pk=%v, want pk=%v", name, ok, got, isPK)
+		}
+	}
+}
+
 func TestRun_Idempotent(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "test.db")
@@ -0,0 +1,2 @@
+-- Remove the ping role column (DROP COLUMN needs SQLite 3.35+, which the bundled driver ships)
+ALTER TABLE guild_settings DROP COLUMN ping_role_id;
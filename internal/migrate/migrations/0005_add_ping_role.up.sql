@@ -0,0 +1,2 @@
+-- Add per-guild role pinged by fight-night alerts
+ALTER TABLE guild_settings ADD COLUMN ping_role_id TEXT;
@@ -0,0 +1,2 @@
+-- Drop per-event post tracking; last_posted keeps deduping by date
+DROP TABLE IF EXISTS posted_events;
@@ -0,0 +1,9 @@
+-- Track posts per event and kind so two events on one day are deduped separately
+CREATE TABLE IF NOT EXISTS posted_events (
+    guild_id  TEXT NOT NULL,
+    org       TEXT NOT NULL,
+    event_id  TEXT NOT NULL,
+    kind      TEXT NOT NULL,
+    post_date TEXT NOT NULL,
+    PRIMARY KEY (guild_id, org, event_id, kind)
+);
@@ -0,0 +1,2 @@
+-- Remove the previous post date (DROP COLUMN needs SQLite 3.35+, which the bundled driver ships)
+ALTER TABLE last_posted DROP COLUMN prev_date;
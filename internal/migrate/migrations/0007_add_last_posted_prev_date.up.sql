@@ -0,0 +1,2 @@
+-- Keep the date a post mark replaced so a released mark can restore it
+ALTER TABLE last_posted ADD COLUMN prev_date TEXT;
@@ -2,7 +2,10 @@ package sources
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -24,8 +27,27 @@ type Bout struct {
 	BlueName    string
 	BlueRecord  string
 	Winner      string
+	// Method and Round describe how the bout ended once Winner is set
+	// (e.g., "KO/TKO", 2); empty/0 when unknown.
+	Method string
+	Round  int
 	// Scheduled is RFC3339 UTC if known
 	Scheduled string
+	// Optional fighter headshot image URLs
+	RedHeadshot  string
+	BlueHeadshot string
+	// Optional division ranking labels ("C" for champion, "#3"), empty when unranked
+	RedRank  string
+	BlueRank string
+	// Optional fighter nationality as the provider reports it (a country name
+	// or ISO code such as "USA"), empty when unknown
+	RedCountry  string
+	BlueCountry string
+	// Optional bout context such as "Rematch" or "Title Eliminator"
+	Note string
+	// Optional provider athlete IDs, empty when unknown
+	RedID  string
+	BlueID string
 }
 
 // Event is the bot's normalized representation for an MMA event across orgs.
@@ -38,14 +60,75 @@ type Event struct {
 	Start     string // RFC3339 UTC
 	End       string // RFC3339 UTC (may be empty)
 	BannerURL string // Optional image to use in embeds
-	Links     []Link
-	Bouts     []Bout
+	Venue     string // Arena name, e.g. "T-Mobile Arena" (may be empty)
+	Location  string // "City, ST" or "City, Country" (may be empty)
+	// Broadcasts name where to watch, e.g. "ESPN+", "ABC" (may be empty)
+	Broadcasts []string
+	Links      []Link
+	Bouts      []Bout
+
+	// PreviewHeadline is the editorial preview's headline, fetched only when
+	// requested via WithPreviewHeadline (may be empty).
+	PreviewHeadline string
 }
 
+// ErrUnavailable marks provider errors where the upstream data source is
+// temporarily refusing requests (e.g., an ESPN block page); callers can show a
+// friendly "try again later" instead of the raw error.
+var ErrUnavailable = errors.New("fight data temporarily unavailable")
+
+// ErrNoFighterIDs is returned by PreviousMeetings when the provider doesn't
+// know a bout's fighters well enough to look them up, so "no meetings" can't
+// be told apart from "unknown".
+var ErrNoFighterIDs = errors.New("fighter IDs unknown for this bout")
+
 // Provider fetches events for a specific organization and exposes next-event.
 type Provider interface {
 	// NextEvent returns the next or ongoing event normalized to the Event type.
 	NextEvent(ctx context.Context) (*Event, bool, error)
+	// LastEvent returns the most recently completed event, with bout winners
+	// populated where the provider knows them.
+	LastEvent(ctx context.Context) (*Event, bool, error)
+}
+
+// Capabilities describes which event data a provider can supply, so callers
+// don't promise what a provider can't deliver.
+type Capabilities struct {
+	HasCards   bool // bout-by-bout fight cards
+	HasResults bool // bout winners once fights are decided
+	HasOdds    bool // betting odds
+	// HasHeadToHead means the provider implements HeadToHeadProvider.
+	HasHeadToHead bool
+}
+
+// CapabilityProvider is implemented by providers that describe their
+// capabilities. Providers without it are assumed to supply event names and
+// times only.
+type CapabilityProvider interface {
+	Capabilities() Capabilities
+}
+
+// UpcomingProvider is implemented by providers that can list several upcoming
+// events cheaply. Events carry names and times only (no cards).
+type UpcomingProvider interface {
+	// UpcomingEvents returns up to n ongoing or upcoming events, soonest first.
+	UpcomingEvents(ctx context.Context, n int) ([]Event, error)
+}
+
+// Meeting is a previous bout between two fighters.
+type Meeting struct {
+	EventID string
+	Date    string // RFC3339 UTC (may be empty)
+	Winner  string // winner's name; empty for a draw or no contest
+	Loser   string
+}
+
+// HeadToHeadProvider is implemented by providers that can look up previous
+// meetings between a bout's fighters (see Capabilities.HasHeadToHead).
+type HeadToHeadProvider interface {
+	// PreviousMeetings returns completed bouts between b's fighters, newest
+	// first, or ErrNoFighterIDs when the provider lacks the fighters' IDs.
+	PreviousMeetings(ctx context.Context, b Bout) ([]Meeting, error)
 }
 
 // Manager resolves a Provider for a given org key (e.g., "ufc").
@@ -56,15 +139,48 @@ type Manager struct {
 // NewManager creates an empty manager; register providers via Register.
 func NewManager() *Manager { return &Manager{providers: make(map[string]Provider)} }
 
-// Register associates an org key with a provider.
-func (m *Manager) Register(org string, p Provider) { m.providers[org] = p }
+// Register associates an org key with a provider. Keys are normalized via NormalizeOrg.
+func (m *Manager) Register(org string, p Provider) { m.providers[NormalizeOrg(org)] = p }
 
-// Provider returns the registered provider for org, if any.
+// Provider returns the registered provider for org, if any. Lookup is case-insensitive.
 func (m *Manager) Provider(org string) (Provider, bool) {
-	p, ok := m.providers[org]
+	p, ok := m.providers[NormalizeOrg(org)]
 	return p, ok
 }
 
+// Capabilities returns what the org's provider supports. Unknown orgs and
+// providers that don't implement CapabilityProvider report no capabilities.
+func (m *Manager) Capabilities(org string) Capabilities {
+	p, ok := m.Provider(org)
+	if !ok {
+		return Capabilities{}
+	}
+	if cp, ok := p.(CapabilityProvider); ok {
+		return cp.Capabilities()
+	}
+	return Capabilities{}
+}
+
+// NormalizeOrg returns the canonical (lowercase, trimmed) form of an org key.
+func NormalizeOrg(org string) string {
+	return strings.ToLower(strings.TrimSpace(org))
+}
+
+// DisplayOrg returns the user-facing form of an org key (e.g., "ufc" -> "UFC").
+func DisplayOrg(org string) string {
+	return strings.ToUpper(NormalizeOrg(org))
+}
+
+// ppvName matches UFC's numbered pay-per-view events ("UFC 300",
+// "UFC 300: Pereira vs. Hill"), as opposed to "UFC Fight Night: ..." cards.
+var ppvName = regexp.MustCompile(`(?i)^\s*UFC\s+\d+\b`)
+
+// IsPPV reports whether an event is a pay-per-view. Only UFC numbered events
+// are classified; other orgs have no PPV series and always report false.
+func IsPPV(org, eventName string) bool {
+	return NormalizeOrg(org) == "ufc" && ppvName.MatchString(eventName)
+}
+
 // Orgs returns a sorted list of registered organization keys.
 func (m *Manager) Orgs() []string {
 	keys := make([]string, 0, len(m.providers))
@@ -76,30 +192,118 @@ func (m *Manager) Orgs() []string {
 }
 
 // NewDefaultManager wires built-in providers for known orgs.
-// Today this registers UFC via the ESPN client adapter.
+// Today this registers UFC and PFL via the ESPN client adapter.
 func NewDefaultManager(httpc *http.Client, userAgent string) *Manager {
 	if httpc == nil {
 		httpc = http.DefaultClient
 	}
 	m := NewManager()
-	m.Register("ufc", &ufcProvider{c: espn.NewClient(httpc, userAgent)})
+	for _, org := range []string{"ufc", "pfl"} {
+		p := &espnProvider{org: org, c: espn.NewLeagueClient(httpc, userAgent, org)}
+		if org == "ufc" {
+			// Contender Series filtering only applies to UFC's scoreboard.
+			p.ignores = ufcIgnores
+		}
+		m.Register(org, p)
+	}
 	return m
 }
 
-// ufcProvider adapts the ESPN client to the generic Provider interface.
-type ufcProvider struct{ c *espn.HTTPClient }
+// espnProvider adapts the ESPN client for one org's league to the generic
+// Provider interface.
+type espnProvider struct {
+	org string
+	c   *espn.HTTPClient
+	// ignores returns the event name substrings to skip for ctx; nil skips none.
+	ignores func(ctx context.Context) []string
+}
 
-func (p *ufcProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
-	// Selection strictly in UTC; conversion happens in discord/eventutil.
-	// Default behavior: ignore Contender Series unless context overrides.
-	ignores := []string{"Contender Series"}
-	if ignore, ok := ufcIgnoreContenderFromContext(ctx); ok {
-		if !ignore {
-			ignores = nil
+// ufcIgnores skips Contender Series by default unless the context overrides.
+func ufcIgnores(ctx context.Context) []string {
+	if ignore, ok := ufcIgnoreContenderFromContext(ctx); ok && !ignore {
+		return nil
+	}
+	return []string{"Contender Series"}
+}
+
+// Capabilities reports ESPN's cards, winners, and fighters' previous meetings.
+// Odds are not parsed from ESPN yet, so they aren't advertised.
+func (p *espnProvider) Capabilities() Capabilities {
+	return Capabilities{HasCards: true, HasResults: true, HasHeadToHead: true}
+}
+
+// PreviousMeetings looks up b's fighters' shared ESPN event logs.
+func (p *espnProvider) PreviousMeetings(ctx context.Context, b Bout) ([]Meeting, error) {
+	if b.RedID == "" || b.BlueID == "" {
+		return nil, ErrNoFighterIDs
+	}
+	ms, err := p.c.FetchPreviousMeetings(ctx, b.RedID, b.BlueID)
+	if err != nil {
+		if errors.Is(err, espn.ErrBlocked) {
+			return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		return nil, err
+	}
+	out := make([]Meeting, 0, len(ms))
+	for _, m := range ms {
+		om := Meeting{EventID: m.EventID}
+		if !m.Date.IsZero() {
+			om.Date = m.Date.UTC().Format(time.RFC3339)
+		}
+		switch m.WinnerID {
+		case b.RedID:
+			om.Winner, om.Loser = b.RedName, b.BlueName
+		case b.BlueID:
+			om.Winner, om.Loser = b.BlueName, b.RedName
+		}
+		out = append(out, om)
+	}
+	return out, nil
+}
+
+func (p *espnProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
+	return p.event(ctx, p.c.FetchNextOrOngoingEventAndCard)
+}
+
+func (p *espnProvider) LastEvent(ctx context.Context) (*Event, bool, error) {
+	return p.event(ctx, p.c.FetchLastCompletedEventAndCard)
+}
+
+func (p *espnProvider) UpcomingEvents(ctx context.Context, n int) ([]Event, error) {
+	var ignores []string
+	if p.ignores != nil {
+		ignores = p.ignores(ctx)
+	}
+	entries, err := p.c.FetchUpcomingEntries(ctx, ignores, time.Now, n)
+	if err != nil {
+		if errors.Is(err, espn.ErrBlocked) {
+			return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		return nil, err
+	}
+	out := make([]Event, 0, len(entries))
+	for _, e := range entries {
+		ev := Event{Org: p.org, ID: e.ID, Name: e.Name, Start: e.Start.UTC().Format(time.RFC3339)}
+		if !e.End.IsZero() {
+			ev.End = e.End.UTC().Format(time.RFC3339)
 		}
+		out = append(out, ev)
 	}
-	ev, fights, stUTC, enUTC, ok, err := p.c.FetchNextOrOngoingEventAndCard(ctx, ignores, time.Now)
+	return out, nil
+}
+
+// event runs an ESPN selection (next or last event) and normalizes the result.
+func (p *espnProvider) event(ctx context.Context, fetch func(context.Context, []string, func() time.Time) (*espn.Event, []espn.Fight, time.Time, time.Time, bool, error)) (*Event, bool, error) {
+	// Selection strictly in UTC; conversion happens in discord/eventutil.
+	var ignores []string
+	if p.ignores != nil {
+		ignores = p.ignores(ctx)
+	}
+	ev, fights, stUTC, enUTC, ok, err := fetch(ctx, ignores, time.Now)
 	if err != nil || !ok || ev == nil {
+		if errors.Is(err, espn.ErrBlocked) {
+			return nil, false, fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
 		if err != nil {
 			return nil, false, err
 		}
@@ -117,13 +321,24 @@ func (p *ufcProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
 			sched = f.Scheduled.UTC().Format(time.RFC3339)
 		}
 		bouts = append(bouts, Bout{
-			WeightClass: f.WeightClass,
-			RedName:     f.RedName,
-			RedRecord:   f.RedRecord,
-			BlueName:    f.BlueName,
-			BlueRecord:  f.BlueRecord,
-			Winner:      f.Winner,
-			Scheduled:   sched,
+			WeightClass:  f.WeightClass,
+			RedName:      f.RedName,
+			RedRecord:    f.RedRecord,
+			BlueName:     f.BlueName,
+			BlueRecord:   f.BlueRecord,
+			Winner:       f.Winner,
+			Method:       f.Method,
+			Round:        f.Round,
+			Scheduled:    sched,
+			RedHeadshot:  f.RedHeadshot,
+			BlueHeadshot: f.BlueHeadshot,
+			RedRank:      f.RedRank,
+			BlueRank:     f.BlueRank,
+			RedCountry:   f.RedCountry,
+			BlueCountry:  f.BlueCountry,
+			Note:         f.Note,
+			RedID:        f.RedID,
+			BlueID:       f.BlueID,
 		})
 	}
 	// Map links where available with friendlier titles
@@ -137,6 +352,9 @@ func (p *ufcProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
 		if strings.EqualFold(strings.TrimSpace(raw), "gamecast") {
 			title = "Event Page"
 		}
+		if strings.TrimSpace(title) == "" && hasRel(l.Rel, "preview") {
+			title = "Preview"
+		}
 		if strings.TrimSpace(title) == "" {
 			title = "Link"
 		}
@@ -152,26 +370,65 @@ func (p *ufcProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
 	if !enUTC.IsZero() {
 		end = enUTC.UTC().Format(time.RFC3339)
 	}
+	venue, location := espn.EventVenue(ev)
 	out := &Event{
-		Org:       "ufc",
-		ID:        ev.ID,
-		Name:      name,
-		ShortName: ev.ShortName,
-		Start:     start,
-		End:       end,
-		BannerURL: banner,
-		Links:     links,
-		Bouts:     bouts,
+		Org:        p.org,
+		ID:         ev.ID,
+		Name:       name,
+		ShortName:  ev.ShortName,
+		Start:      start,
+		End:        end,
+		BannerURL:  banner,
+		Venue:      venue,
+		Location:   location,
+		Broadcasts: espn.EventBroadcasts(ev),
+		Links:      links,
+		Bouts:      bouts,
+	}
+	// Optionally fetch the preview headline (extra request; best-effort).
+	if want, _ := previewHeadlineFromContext(ctx); want {
+		if l, ok := PreviewLink(out); ok {
+			if h, err := p.c.FetchPageHeadline(ctx, l.URL); err == nil {
+				out.PreviewHeadline = h
+			}
+		}
 	}
 	return out, true, nil
 }
 
+// PreviewLink returns the event's editorial preview link, if any, matched by a
+// "preview" label.
+func PreviewLink(e *Event) (Link, bool) {
+	if e == nil {
+		return Link{}, false
+	}
+	for _, l := range e.Links {
+		if strings.TrimSpace(l.URL) == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(l.Title), "preview") {
+			return l, true
+		}
+	}
+	return Link{}, false
+}
+
+func hasRel(rels []string, want string) bool {
+	for _, r := range rels {
+		if strings.EqualFold(strings.TrimSpace(r), want) {
+			return true
+		}
+	}
+	return false
+}
+
 // ---- Context options for provider behavior ----
 
 type ctxKey int
 
 const (
 	ctxKeyUFCIgnoreContender ctxKey = iota
+	ctxKeyPreviewHeadline
 )
 
 // WithUFCIgnoreContender annotates ctx with whether to ignore Contender Series
@@ -189,6 +446,22 @@ func ufcIgnoreContenderFromContext(ctx context.Context) (bool, bool) {
 	return b, ok
 }
 
+// WithPreviewHeadline annotates ctx with whether providers should fetch the
+// headline of the event's preview article. Off unless set, since it costs an
+// extra upstream request.
+func WithPreviewHeadline(ctx context.Context, fetch bool) context.Context {
+	return context.WithValue(ctx, ctxKeyPreviewHeadline, fetch)
+}
+
+func previewHeadlineFromContext(ctx context.Context) (bool, bool) {
+	v := ctx.Value(ctxKeyPreviewHeadline)
+	if v == nil {
+		return false, false
+	}
+	b, ok := v.(bool)
+	return b, ok
+}
+
 // firstNonEmpty returns the first non-empty (after trimming) string.
 func firstNonEmpty(vals ...string) string {
 	for _, v := range vals {
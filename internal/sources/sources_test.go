@@ -2,7 +2,13 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/zodakzach/fight-night-discord-bot/internal/espn"
 )
 
 // fakeProvider is a minimal Provider for manager tests.
@@ -12,6 +18,10 @@ func (f *fakeProvider) NextEvent(ctx context.Context) (*Event, bool, error) {
 	return nil, false, nil
 }
 
+func (f *fakeProvider) LastEvent(ctx context.Context) (*Event, bool, error) {
+	return nil, false, nil
+}
+
 func TestManager_RegisterAndLookup(t *testing.T) {
 	m := NewManager()
 	p1 := &fakeProvider{}
@@ -36,3 +46,129 @@ func TestNewDefaultManager_RegistersUFC(t *testing.T) {
 		t.Fatalf("expected default manager to have 'ufc' provider registered")
 	}
 }
+
+func TestNewDefaultManager_RegistersPFL(t *testing.T) {
+	m := NewDefaultManager(nil, "test-agent")
+	if orgs := m.Orgs(); !reflect.DeepEqual(orgs, []string{"pfl", "ufc"}) {
+		t.Fatalf("expected orgs [pfl ufc], got %v", orgs)
+	}
+	p, ok := m.Provider("pfl")
+	if !ok {
+		t.Fatalf("expected default manager to have 'pfl' provider registered")
+	}
+	ep := p.(*espnProvider)
+	if ep.org != "pfl" || ep.c.League != "pfl" {
+		t.Fatalf("expected pfl league client, got org=%q league=%q", ep.org, ep.c.League)
+	}
+	// Contender Series filtering is UFC-only.
+	if ep.ignores != nil {
+		t.Fatalf("expected no event-name ignores for pfl")
+	}
+}
+
+func TestUFCIgnores_ContextOverride(t *testing.T) {
+	ctx := context.Background()
+	if got := ufcIgnores(ctx); !reflect.DeepEqual(got, []string{"Contender Series"}) {
+		t.Fatalf("default: got %v", got)
+	}
+	if got := ufcIgnores(WithUFCIgnoreContender(ctx, false)); got != nil {
+		t.Fatalf("include: got %v", got)
+	}
+	if got := ufcIgnores(WithUFCIgnoreContender(ctx, true)); len(got) != 1 {
+		t.Fatalf("ignore: got %v", got)
+	}
+}
+
+func TestManager_OrgKeysCaseInsensitive(t *testing.T) {
+	m := NewManager()
+	p := &fakeProvider{}
+	m.Register("UFC", p)
+
+	for _, org := range []string{"ufc", "UFC", " Ufc "} {
+		if got, ok := m.Provider(org); !ok || got != p {
+			t.Fatalf("lookup %q: ok=%v got=%p want=%p", org, ok, got, p)
+		}
+	}
+	if orgs := m.Orgs(); len(orgs) != 1 || orgs[0] != "ufc" {
+		t.Fatalf("expected normalized org keys, got %v", orgs)
+	}
+	if got := DisplayOrg(" ufc"); got != "UFC" {
+		t.Fatalf("DisplayOrg: got %q", got)
+	}
+}
+
+// cardsOnlyProvider declares card support but no results or odds.
+type cardsOnlyProvider struct{ fakeProvider }
+
+func (*cardsOnlyProvider) Capabilities() Capabilities { return Capabilities{HasCards: true} }
+
+func TestManager_CapabilitiesPerOrg(t *testing.T) {
+	m := NewDefaultManager(nil, "test-agent")
+	m.Register("pfl", &cardsOnlyProvider{})
+	m.Register("one", &fakeProvider{})
+
+	tests := []struct {
+		org  string
+		want Capabilities
+	}{
+		{"UFC", Capabilities{HasCards: true, HasResults: true, HasHeadToHead: true}},
+		{"pfl", Capabilities{HasCards: true}},
+		{"one", Capabilities{}},      // no descriptor: names and times only
+		{"bellator", Capabilities{}}, // not registered
+	}
+	for _, tc := range tests {
+		if got := m.Capabilities(tc.org); got != tc.want {
+			t.Fatalf("%s: got %+v want %+v", tc.org, got, tc.want)
+		}
+	}
+}
+
+func TestIsPPV(t *testing.T) {
+	tests := []struct {
+		org, name string
+		want      bool
+	}{
+		{"ufc", "UFC 300: Pereira vs. Hill", true},
+		{"UFC", "UFC 300", true},
+		{"ufc", "UFC Fight Night: Nicolau vs. Perez", false},
+		{"ufc", "UFC on ESPN: Namajunas vs. Cortez", false},
+		{"ufc", "Noche UFC", false},
+		{"pfl", "PFL 1", false},
+		{"ufc", "", false},
+	}
+	for _, tc := range tests {
+		if got := IsPPV(tc.org, tc.name); got != tc.want {
+			t.Fatalf("IsPPV(%q, %q) = %v, want %v", tc.org, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestESPNProviderEvent_CarriesBroadcasts(t *testing.T) {
+	var ev espn.Event
+	payload := `{"id":"600041","name":"UFC 300","competitions":[
+		{"broadcasts":[{"market":"national","names":["PPV","ESPN+"]}]}
+	]}`
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	start := time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC)
+	fetch := func(context.Context, []string, func() time.Time) (*espn.Event, []espn.Fight, time.Time, time.Time, bool, error) {
+		return &ev, nil, start, time.Time{}, true, nil
+	}
+	p := &espnProvider{org: "ufc"}
+	got, ok, err := p.event(context.Background(), fetch)
+	if err != nil || !ok {
+		t.Fatalf("event: ok=%v err=%v", ok, err)
+	}
+	if want := []string{"PPV", "ESPN+"}; !reflect.DeepEqual(got.Broadcasts, want) {
+		t.Fatalf("broadcasts: got %v want %v", got.Broadcasts, want)
+	}
+}
+
+func TestESPNProviderPreviousMeetings_NoFighterIDs(t *testing.T) {
+	p := &espnProvider{org: "ufc"}
+	_, err := p.PreviousMeetings(context.Background(), Bout{RedName: "Kayla Harrison", BlueName: "Julianna Pena", BlueID: "2"})
+	if !errors.Is(err, ErrNoFighterIDs) {
+		t.Fatalf("expected ErrNoFighterIDs, got %v", err)
+	}
+}
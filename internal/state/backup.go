@@ -0,0 +1,79 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zodakzach/fight-night-discord-bot/internal/logx"
+	"github.com/zodakzach/fight-night-discord-bot/internal/sentryx"
+)
+
+// backupPrefix names backup files as <prefix><timestamp>.db so they sort chronologically.
+const backupPrefix = "state-"
+
+// Backup writes a consistent, standalone copy of the database to destPath using
+// SQLite's VACUUM INTO, which is safe while the DB is in use. The destination
+// must not already exist.
+func (s *Store) Backup(destPath string) error {
+	if strings.TrimSpace(destPath) == "" {
+		return fmt.Errorf("backup: empty destination path")
+	}
+	if _, err := s.db.Exec("VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("backup to %q: %w", destPath, err)
+	}
+	return nil
+}
+
+// BackupRotate writes a timestamped backup into dir and removes the oldest
+// backups so that at most keep remain. It returns the path written.
+func (s *Store) BackupRotate(dir string, keep int, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("backup dir %q: %w", dir, err)
+	}
+	dest := filepath.Join(dir, backupPrefix+now.UTC().Format("20060102T150405Z")+".db")
+	if err := s.Backup(dest); err != nil {
+		return "", err
+	}
+	pruneBackups(dir, keep)
+	return dest, nil
+}
+
+// pruneBackups deletes the oldest backup files beyond keep (best-effort).
+func pruneBackups(dir string, keep int) {
+	if keep <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, backupPrefix+"*.db"))
+	if err != nil || len(matches) <= keep {
+		return
+	}
+	sort.Strings(matches)
+	for _, old := range matches[:len(matches)-keep] {
+		if err := os.Remove(old); err != nil {
+			logx.Warn("state: prune backup", "path", old, "err", err)
+		}
+	}
+}
+
+// StartBackups runs BackupRotate every interval in the background. Failures are
+// logged as warnings and never stop the bot.
+func (s *Store) StartBackups(dir string, every time.Duration, keep int) {
+	go func() {
+		defer sentryx.Recover()
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for range ticker.C {
+			done := logx.Measure("state.backup", "dir", dir)
+			path, err := s.BackupRotate(dir, keep, time.Now())
+			if err != nil {
+				logx.Warn("state: backup failed", "dir", dir, "err", err)
+				continue
+			}
+			done("path", path)
+		}
+	}()
+}
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackup_ProducesOpenableCopy(t *testing.T) {
+	st := Load(":memory:")
+	st.UpdateGuildChannel("g1", "c1")
+	st.UpdateGuildOrg("g1", "ufc")
+	st.MarkPosted("g1", DedupKey("ufc", PostAlert, "2024-08-27"))
+
+	dest := filepath.Join(t.TempDir(), "copy.db")
+	if err := st.Backup(dest); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+
+	cp := Load(dest)
+	ch, _, last := cp.GetGuildSettings("g1")
+	if ch != "c1" || last["ufc"] != "2024-08-27" || cp.GetGuildOrg("g1") != "ufc" {
+		t.Fatalf("backup copy missing data: ch=%q last=%v", ch, last)
+	}
+
+	// Existing destination is an error (VACUUM INTO never overwrites)
+	if err := st.Backup(dest); err == nil {
+		t.Fatalf("expected error when destination exists")
+	}
+}
+
+func TestBackupRotate_KeepsNewest(t *testing.T) {
+	st := Load(":memory:")
+	dir := filepath.Join(t.TempDir(), "backups")
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var last string
+	for i := 0; i < 4; i++ {
+		p, err := st.BackupRotate(dir, 2, start.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("rotate %d: %v", i, err)
+		}
+		last = p
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 retained backups, got %d", len(entries))
+	}
+	if filepath.Base(last) != entries[1].Name() {
+		t.Fatalf("expected newest backup retained, got %v", entries)
+	}
+}
@@ -2,6 +2,12 @@ package state
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
@@ -12,13 +18,74 @@ import (
 // backed by a SQLite database via sqlx.
 type Store struct {
 	db *sqlx.DB
+
+	// defaultAnnounce is the delivery mode reported for guilds that never chose
+	// one (see SetDefaultAnnounce).
+	defaultAnnounce bool
 }
 
 // GuildConfig mirrors persisted guild settings for convenience where needed.
+// Fields use the same defaults as the per-setting getters.
 type GuildConfig struct {
+	GuildID    string
 	ChannelID  string
 	Timezone   string
 	LastPosted map[string]string // sport -> YYYY-MM-DD
+
+	Org                string // "" when not explicitly set
+	NotifyEnabled      bool
+	RunHour            int // -1 when unset
+	Announce           bool
+	Events             bool
+	EventLeadDays      int
+	EventFailures      int      // consecutive scheduled event creation failures
+	ExcludedEventOrgs  []string // orgs opted out of scheduled events
+	UFCIgnoreContender bool
+	NoEventMessage     string
+	WeighIn            bool
+	WeighInMessage     string
+	FightWeekDays      int // 0 when the fight-week promo is off
+	LiveSoonMinutes    int // 0 when the live-soon reminder is off
+	FightWeekMessage   string
+	MaxAnnounceDays    int // 0 when unlimited
+	Pin                bool
+	PinnedChannelID    string
+	PinnedMessageID    string
+	SubscriberRoleID   string // "" when no opt-in ping role is set
+	PingRoleID         string // "" when alerts don't ping an admin-chosen role
+	ReminderOffsets    []int  // hours before start to post reminders; nil when off
+	CardUpdateMode     string // "" when unset (edit)
+	AlertChannelID     string // "" when alerts use ChannelID
+	EventChannelID     string // "" when event links use ChannelID
+	AlertSections      AlertSections
+	RunTimeRef         string // "" when unset (local)
+	EventNamePrefix    string // "" when unset ("ORG:")
+	DebugIDs           bool   // show provider event IDs in /next-event to managers
+	NextEventTeaser    bool   // add a "Then:" line for the following event to /next-event
+	SkipUntilPPV       bool   // suppress non-PPV notifications until the next PPV posts
+	NotifyWebhookURL   string // "" when no outbound webhook is set
+	FallbackTZ         string // "" when unset; used when TZ no longer loads
+	HelpVisibility     string // "" when unset (ephemeral)
+	MessageTemplate    string // "" when unset (built-in alert format)
+	PostponeNotice     bool   // post a notice when a tracked event is postponed or cancelled
+	BroadcastOptOut    bool   // skip non-critical operator broadcasts
+
+	// Embed presentation
+	Preview          bool
+	Headshots        bool
+	StartsFormat     string
+	LinkPreference   string
+	Rankings         bool
+	Flags            bool
+	Notes            bool
+	ShowEnd          bool
+	EmbedLayout      string
+	MainCardSize     int
+	ResultMethod     string // "" when unset (emoji)
+	ResultEmojis     string // "" when unset (built-in set)
+	ResultsReactions string // "" when off; comma-separated emoji for results posts
+	SectionOrder     string // "" when unset (headline)
+	DateFormat       string // "" when unset (us)
 }
 
 // Load opens (or creates) a SQLite DB at the given path and ensures schema.
@@ -49,21 +116,94 @@ func ensureSchema(db *sqlx.DB) error {
             run_hour   INTEGER,
             announce   INTEGER,
             events     INTEGER,
-            ufc_ignore_contender INTEGER
+            ufc_ignore_contender INTEGER,
+            preview    INTEGER,
+            no_event_message TEXT,
+            headshots  INTEGER,
+            starts_format TEXT,
+            link_preference TEXT,
+            max_announce_days INTEGER,
+            rankings   INTEGER,
+            pin        INTEGER,
+            pinned_channel_id TEXT,
+            pinned_message_id TEXT,
+            show_end   INTEGER,
+            event_lead_days INTEGER,
+            weigh_in   INTEGER,
+            weigh_in_message TEXT,
+            embed_layout TEXT,
+            fight_week_days INTEGER,
+            fight_week_message TEXT,
+            event_failures INTEGER,
+            main_card_size INTEGER,
+            subscriber_role_id TEXT,
+            card_update_mode TEXT,
+            alert_channel_id TEXT,
+            event_channel_id TEXT,
+            result_method TEXT,
+            result_emojis TEXT,
+            results_reactions TEXT,
+            alert_header INTEGER,
+            alert_trailer INTEGER,
+            alert_embed INTEGER,
+            run_time_ref TEXT,
+            flags      INTEGER,
+            event_name_prefix TEXT,
+            debug_ids  INTEGER,
+            next_event_teaser INTEGER,
+            ping_role_id TEXT,
+            notes      INTEGER,
+            reminder_offsets TEXT, -- comma list of hours before start, e.g. "24,1"
+            skip_until_ppv INTEGER,
+            notify_webhook_url TEXT,
+            fallback_tz TEXT,
+            help_visibility TEXT,
+            message_template TEXT,
+            no_channel_days INTEGER, -- run-hour ticks with notifications on but no channel
+            postpone_notice INTEGER,
+            section_order TEXT,
+            date_format TEXT,
+            broadcast_opt_out INTEGER,
+            live_soon_minutes INTEGER
         );
         CREATE TABLE IF NOT EXISTS last_posted (
             guild_id  TEXT NOT NULL,
             sport     TEXT NOT NULL,
             last_date TEXT NOT NULL,
+            prev_date TEXT,           -- last_date before the latest mark
             PRIMARY KEY (guild_id, sport)
         );
+        CREATE TABLE IF NOT EXISTS posted_events (
+            guild_id  TEXT NOT NULL,
+            org       TEXT NOT NULL,
+            event_id  TEXT NOT NULL,
+            kind      TEXT NOT NULL, -- alert|weighin|fightweek|livesoon|reminder-<h>h
+            post_date TEXT NOT NULL, -- YYYY-MM-DD in guild TZ
+            PRIMARY KEY (guild_id, org, event_id, kind)
+        );
         CREATE TABLE IF NOT EXISTS scheduled_events (
             guild_id   TEXT NOT NULL,
             sport      TEXT NOT NULL,
             event_date TEXT NOT NULL, -- YYYY-MM-DD in guild TZ
             event_id   TEXT NOT NULL,
+            recreated  INTEGER,       -- times recreated after an admin deleted it
             PRIMARY KEY (guild_id, sport, event_date)
         );
+        CREATE TABLE IF NOT EXISTS org_event_exclusions (
+            guild_id TEXT NOT NULL,
+            org      TEXT NOT NULL,
+            PRIMARY KEY (guild_id, org)
+        );
+        CREATE TABLE IF NOT EXISTS post_history (
+            guild_id   TEXT NOT NULL,
+            sport      TEXT NOT NULL,
+            post_date  TEXT NOT NULL, -- YYYY-MM-DD in guild TZ
+            message_id TEXT,
+            posted_at  TEXT,          -- RFC3339 UTC
+            card_channel_id TEXT,     -- set while the posted card is still TBA
+            card_message_id TEXT,
+            PRIMARY KEY (guild_id, sport, post_date)
+        );
     `)
 	if err != nil {
 		return err
@@ -73,6 +213,24 @@ func ensureSchema(db *sqlx.DB) error {
 	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN enabled INTEGER"); err != nil {
 		// ignore
 	}
+	if _, err := db.Exec("ALTER TABLE scheduled_events ADD COLUMN recreated INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE last_posted ADD COLUMN prev_date TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE post_history ADD COLUMN message_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE post_history ADD COLUMN posted_at TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE post_history ADD COLUMN card_channel_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE post_history ADD COLUMN card_message_id TEXT"); err != nil {
+		// ignore
+	}
 	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN org TEXT"); err != nil {
 		// ignore
 	}
@@ -88,9 +246,159 @@ func ensureSchema(db *sqlx.DB) error {
 	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN ufc_ignore_contender INTEGER"); err != nil {
 		// ignore
 	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN preview INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN no_event_message TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN headshots INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN starts_format TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN link_preference TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN max_announce_days INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN rankings INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN pin INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN show_end INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN event_lead_days INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN weigh_in INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN weigh_in_message TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN embed_layout TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN fight_week_days INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN fight_week_message TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN event_failures INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN main_card_size INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN subscriber_role_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN card_update_mode TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN alert_channel_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN event_channel_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN result_method TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN result_emojis TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN results_reactions TEXT"); err != nil {
+		// ignore
+	}
+	for _, col := range []string{"alert_header", "alert_trailer", "alert_embed"} {
+		if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN " + col + " INTEGER"); err != nil {
+			// ignore
+		}
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN run_time_ref TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN flags INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN event_name_prefix TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN debug_ids INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN next_event_teaser INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN ping_role_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN notes INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN reminder_offsets TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN skip_until_ppv INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN notify_webhook_url TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN fallback_tz TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN help_visibility TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN message_template TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN no_channel_days INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN postpone_notice INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN section_order TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN date_format TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN broadcast_opt_out INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN live_soon_minutes INTEGER"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN pinned_channel_id TEXT"); err != nil {
+		// ignore
+	}
+	if _, err := db.Exec("ALTER TABLE guild_settings ADD COLUMN pinned_message_id TEXT"); err != nil {
+		// ignore
+	}
 	return nil
 }
 
+// Ping runs a trivial query and returns how long the round trip took.
+func (s *Store) Ping() (time.Duration, error) {
+	start := time.Now()
+	var one int
+	if err := s.db.QueryRowx("SELECT 1").Scan(&one); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
 // GuildIDs returns the set of guild IDs with settings persisted.
 func (s *Store) GuildIDs() []string {
 	var ids []string
@@ -101,6 +409,196 @@ func (s *Store) GuildIDs() []string {
 	return ids
 }
 
+// guildConfigRow is one row of the ListAllGuildConfigs join: a guild's settings
+// plus at most one last_posted entry.
+type guildConfigRow struct {
+	GuildID            string         `db:"guild_id"`
+	ChannelID          sql.NullString `db:"channel_id"`
+	Timezone           sql.NullString `db:"timezone"`
+	Enabled            sql.NullInt32  `db:"enabled"`
+	Org                sql.NullString `db:"org"`
+	RunHour            sql.NullInt32  `db:"run_hour"`
+	Announce           sql.NullInt32  `db:"announce"`
+	Events             sql.NullInt32  `db:"events"`
+	UFCIgnoreContender sql.NullInt32  `db:"ufc_ignore_contender"`
+	Preview            sql.NullInt32  `db:"preview"`
+	NoEventMessage     sql.NullString `db:"no_event_message"`
+	Headshots          sql.NullInt32  `db:"headshots"`
+	StartsFormat       sql.NullString `db:"starts_format"`
+	LinkPreference     sql.NullString `db:"link_preference"`
+	MaxAnnounceDays    sql.NullInt32  `db:"max_announce_days"`
+	Rankings           sql.NullInt32  `db:"rankings"`
+	Pin                sql.NullInt32  `db:"pin"`
+	PinnedChannelID    sql.NullString `db:"pinned_channel_id"`
+	PinnedMessageID    sql.NullString `db:"pinned_message_id"`
+	ShowEnd            sql.NullInt32  `db:"show_end"`
+	EventLeadDays      sql.NullInt32  `db:"event_lead_days"`
+	WeighIn            sql.NullInt32  `db:"weigh_in"`
+	WeighInMessage     sql.NullString `db:"weigh_in_message"`
+	EmbedLayout        sql.NullString `db:"embed_layout"`
+	FightWeekDays      sql.NullInt32  `db:"fight_week_days"`
+	FightWeekMessage   sql.NullString `db:"fight_week_message"`
+	EventFailures      sql.NullInt32  `db:"event_failures"`
+	MainCardSize       sql.NullInt32  `db:"main_card_size"`
+	SubscriberRoleID   sql.NullString `db:"subscriber_role_id"`
+	CardUpdateMode     sql.NullString `db:"card_update_mode"`
+	AlertChannelID     sql.NullString `db:"alert_channel_id"`
+	EventChannelID     sql.NullString `db:"event_channel_id"`
+	ResultMethod       sql.NullString `db:"result_method"`
+	ResultEmojis       sql.NullString `db:"result_emojis"`
+	ResultsReactions   sql.NullString `db:"results_reactions"`
+	AlertHeader        sql.NullInt32  `db:"alert_header"`
+	AlertTrailer       sql.NullInt32  `db:"alert_trailer"`
+	AlertEmbed         sql.NullInt32  `db:"alert_embed"`
+	RunTimeRef         sql.NullString `db:"run_time_ref"`
+	Flags              sql.NullInt32  `db:"flags"`
+	EventNamePrefix    sql.NullString `db:"event_name_prefix"`
+	DebugIDs           sql.NullInt32  `db:"debug_ids"`
+	NextEventTeaser    sql.NullInt32  `db:"next_event_teaser"`
+	PingRoleID         sql.NullString `db:"ping_role_id"`
+	Notes              sql.NullInt32  `db:"notes"`
+	ReminderOffsets    sql.NullString `db:"reminder_offsets"`
+	SkipUntilPPV       sql.NullInt32  `db:"skip_until_ppv"`
+	NotifyWebhookURL   sql.NullString `db:"notify_webhook_url"`
+	FallbackTZ         sql.NullString `db:"fallback_tz"`
+	HelpVisibility     sql.NullString `db:"help_visibility"`
+	MessageTemplate    sql.NullString `db:"message_template"`
+	PostponeNotice     sql.NullInt32  `db:"postpone_notice"`
+	SectionOrder       sql.NullString `db:"section_order"`
+	DateFormat         sql.NullString `db:"date_format"`
+	BroadcastOptOut    sql.NullInt32  `db:"broadcast_opt_out"`
+	LiveSoonMinutes    sql.NullInt32  `db:"live_soon_minutes"`
+	Excluded           sql.NullString `db:"excluded_orgs"`
+	Sport              sql.NullString `db:"sport"`
+	LastDate           sql.NullString `db:"last_date"`
+}
+
+func (r guildConfigRow) config(defaultAnnounce bool) GuildConfig {
+	on := func(v sql.NullInt32) bool { return v.Valid && v.Int32 != 0 }
+	c := GuildConfig{
+		GuildID:            r.GuildID,
+		ChannelID:          r.ChannelID.String,
+		Timezone:           r.Timezone.String,
+		LastPosted:         map[string]string{},
+		Org:                strings.ToLower(strings.TrimSpace(r.Org.String)),
+		NotifyEnabled:      on(r.Enabled),
+		RunHour:            -1,
+		Announce:           on(r.Announce) || (!r.Announce.Valid && defaultAnnounce),
+		Events:             on(r.Events),
+		EventLeadDays:      1,
+		MainCardSize:       DefaultMainCardSize,
+		UFCIgnoreContender: !r.UFCIgnoreContender.Valid || r.UFCIgnoreContender.Int32 != 0,
+		NoEventMessage:     r.NoEventMessage.String,
+		WeighIn:            on(r.WeighIn),
+		WeighInMessage:     r.WeighInMessage.String,
+		MaxAnnounceDays:    int(r.MaxAnnounceDays.Int32),
+		Pin:                on(r.Pin),
+		PinnedChannelID:    r.PinnedChannelID.String,
+		PinnedMessageID:    r.PinnedMessageID.String,
+		Preview:            on(r.Preview),
+		Headshots:          on(r.Headshots),
+		StartsFormat:       r.StartsFormat.String,
+		LinkPreference:     r.LinkPreference.String,
+		Rankings:           on(r.Rankings),
+		Flags:              on(r.Flags),
+		Notes:              on(r.Notes),
+		ShowEnd:            on(r.ShowEnd),
+		EmbedLayout:        r.EmbedLayout.String,
+		FightWeekMessage:   r.FightWeekMessage.String,
+		EventFailures:      int(r.EventFailures.Int32),
+		SubscriberRoleID:   r.SubscriberRoleID.String,
+		CardUpdateMode:     r.CardUpdateMode.String,
+		AlertChannelID:     r.AlertChannelID.String,
+		EventChannelID:     r.EventChannelID.String,
+		ResultMethod:       r.ResultMethod.String,
+		ResultEmojis:       r.ResultEmojis.String,
+		ResultsReactions:   r.ResultsReactions.String,
+		AlertSections:      alertSections(r.AlertHeader, r.AlertTrailer, r.AlertEmbed),
+		RunTimeRef:         r.RunTimeRef.String,
+		EventNamePrefix:    r.EventNamePrefix.String,
+		DebugIDs:           on(r.DebugIDs),
+		NextEventTeaser:    on(r.NextEventTeaser),
+		PingRoleID:         r.PingRoleID.String,
+		ReminderOffsets:    parseOffsets(r.ReminderOffsets.String),
+		SkipUntilPPV:       on(r.SkipUntilPPV),
+		NotifyWebhookURL:   r.NotifyWebhookURL.String,
+		FallbackTZ:         r.FallbackTZ.String,
+		HelpVisibility:     r.HelpVisibility.String,
+		MessageTemplate:    r.MessageTemplate.String,
+		PostponeNotice:     on(r.PostponeNotice),
+		SectionOrder:       r.SectionOrder.String,
+		DateFormat:         r.DateFormat.String,
+		BroadcastOptOut:    on(r.BroadcastOptOut),
+	}
+	if r.RunHour.Valid {
+		c.RunHour = int(r.RunHour.Int32)
+	}
+	if r.MainCardSize.Valid && r.MainCardSize.Int32 >= 1 {
+		c.MainCardSize = int(r.MainCardSize.Int32)
+	}
+	if r.FightWeekDays.Int32 > 0 {
+		c.FightWeekDays = int(r.FightWeekDays.Int32)
+	}
+	if r.LiveSoonMinutes.Int32 > 0 {
+		c.LiveSoonMinutes = int(r.LiveSoonMinutes.Int32)
+	}
+	if r.EventLeadDays.Valid && r.EventLeadDays.Int32 >= 1 {
+		c.EventLeadDays = int(r.EventLeadDays.Int32)
+	}
+	if r.Excluded.String != "" {
+		c.ExcludedEventOrgs = strings.Split(r.Excluded.String, ",")
+		sort.Strings(c.ExcludedEventOrgs)
+	}
+	return c
+}
+
+// ListAllGuildConfigs returns every guild's full settings, ordered by guild ID,
+// in one query (last_posted is joined in) to avoid per-guild reads when
+// building operator views or broadcasts.
+func (s *Store) ListAllGuildConfigs() ([]GuildConfig, error) {
+	rows, err := s.db.Queryx(`
+        SELECT g.guild_id, g.channel_id, g.timezone, g.enabled, g.org, g.run_hour,
+               g.announce, g.events, g.ufc_ignore_contender, g.preview, g.no_event_message,
+               g.headshots, g.starts_format, g.link_preference, g.max_announce_days,
+               g.rankings, g.pin, g.pinned_channel_id, g.pinned_message_id, g.show_end,
+               g.event_lead_days, g.weigh_in, g.weigh_in_message, g.embed_layout,
+               g.fight_week_days, g.fight_week_message, g.event_failures,
+               g.main_card_size, g.subscriber_role_id, g.card_update_mode,
+               g.alert_channel_id, g.event_channel_id, g.result_method, g.result_emojis, g.results_reactions,
+               g.alert_header, g.alert_trailer, g.alert_embed, g.run_time_ref, g.flags,
+               g.event_name_prefix, g.debug_ids, g.next_event_teaser, g.ping_role_id,
+               g.notes, g.reminder_offsets, g.skip_until_ppv,
+               g.notify_webhook_url, g.fallback_tz, g.help_visibility, g.message_template, g.postpone_notice, g.section_order, g.date_format, g.broadcast_opt_out, g.live_soon_minutes,
+               (SELECT GROUP_CONCAT(e.org) FROM org_event_exclusions e WHERE e.guild_id = g.guild_id) AS excluded_orgs,
+               lp.sport, lp.last_date
+        FROM guild_settings g
+        LEFT JOIN last_posted lp ON lp.guild_id = g.guild_id
+        ORDER BY g.guild_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list guild configs: %w", err)
+	}
+	defer rows.Close()
+
+	var out []GuildConfig
+	for rows.Next() {
+		var r guildConfigRow
+		if err := rows.StructScan(&r); err != nil {
+			return nil, fmt.Errorf("scan guild config: %w", err)
+		}
+		// Rows arrive grouped by guild; start a new config when the guild changes.
+		if len(out) == 0 || out[len(out)-1].GuildID != r.GuildID {
+			out = append(out, r.config(s.defaultAnnounce))
+		}
+		if r.Sport.Valid {
+			out[len(out)-1].LastPosted[r.Sport.String] = r.LastDate.String
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list guild configs: %w", err)
+	}
+	return out, nil
+}
+
 // GetGuildSettings returns channel, timezone, and last-posted map for the guild.
 func (s *Store) GetGuildSettings(guildID string) (channelID, tz string, lastPosted map[string]string) {
 	// settings
@@ -129,7 +627,8 @@ func (s *Store) UpdateGuildChannel(guildID, channelID string) {
 		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
 		return
 	}
-	if _, err := s.db.Exec("UPDATE guild_settings SET channel_id = ? WHERE guild_id = ?", channelID, guildID); err != nil {
+	// A new channel also restarts the missing-channel count (see RecordGuildNoChannelDay).
+	if _, err := s.db.Exec("UPDATE guild_settings SET channel_id = ?, no_channel_days = NULL WHERE guild_id = ?", channelID, guildID); err != nil {
 		logx.Error("state: update channel", "guild_id", guildID, "err", err)
 	}
 }
@@ -145,15 +644,365 @@ func (s *Store) UpdateGuildTZ(guildID, tz string) {
 	}
 }
 
-// MarkPosted records the most recent YYYY-MM-DD date a notification was posted for a sport.
-func (s *Store) MarkPosted(guildID, sport, yyyyMmDd string) {
+// UpdateGuildFallbackTZ sets the timezone used when the guild's primary
+// timezone fails to load (e.g. a renamed IANA zone); empty clears it.
+func (s *Store) UpdateGuildFallbackTZ(guildID, tz string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET fallback_tz = NULLIF(?, '') WHERE guild_id = ?", tz, guildID); err != nil {
+		logx.Error("state: update fallback_tz", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildFallbackTZ returns the fallback timezone, or "" when unset.
+func (s *Store) GetGuildFallbackTZ(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT fallback_tz FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// Post kinds deduped through last_posted. Each is stored under "<org>:<kind>"
+// (the bare org for fight-night alerts, as it predates the other kinds) with
+// the event date as last_date, so a full dedup key reads <org>:<kind>:<date>.
+const (
+	PostAlert     = ""
+	PostWeighIn   = "weighin"
+	PostFightWeek = "fightweek"
+	PostResults   = "results"
+	PostLiveSoon  = "livesoon" // keyed per event in posted_events only
+)
+
+// ReminderKind is the posted_events kind for the event reminder offsetHours
+// before the start, e.g. "reminder-24h". Reminders are keyed per event only.
+func ReminderKind(offsetHours int) string {
+	return fmt.Sprintf("reminder-%dh", offsetHours)
+}
+
+// DedupKey builds the dedup key for an org's post kind on a YYYY-MM-DD date,
+// e.g. "ufc:weighin:2024-04-12" (or "ufc:2024-04-13" for an alert).
+func DedupKey(org, kind, date string) string {
+	if kind == PostAlert {
+		return org + ":" + date
+	}
+	return org + ":" + kind + ":" + date
+}
+
+// SplitDedupKey splits a dedup key into its last_posted sport and date. Dates
+// never contain ':', so the date is everything after the last one.
+func SplitDedupKey(key string) (sport, date string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
+// HasPosted reports whether the post identified by a DedupKey was marked.
+func (s *Store) HasPosted(guildID, key string) bool {
+	sport, date := SplitDedupKey(key)
+	var n int
+	row := s.db.QueryRowx("SELECT COUNT(*) FROM last_posted WHERE guild_id = ? AND sport = ? AND last_date = ?", guildID, sport, date)
+	if err := row.Scan(&n); err != nil {
+		logx.Error("state: has posted", "guild_id", guildID, "key", key, "err", err)
+	}
+	return n > 0
+}
+
+// MarkPosted records the post identified by a DedupKey, replacing the previous
+// date for its org and kind (kept as prev_date for UnmarkPosted). Unlike the
+// settings setters it returns the error instead of logging it, so the notifier
+// can retry: a lost mark means the next tick re-posts.
+func (s *Store) MarkPosted(guildID, key string) error {
+	sport, date := SplitDedupKey(key)
 	if _, err := s.db.Exec(
 		"INSERT INTO last_posted (guild_id, sport, last_date) VALUES (?, ?, ?) "+
-			"ON CONFLICT(guild_id, sport) DO UPDATE SET last_date = excluded.last_date",
-		guildID, sport, yyyyMmDd,
+			"ON CONFLICT(guild_id, sport) DO UPDATE SET "+
+			"prev_date = CASE WHEN last_posted.last_date = excluded.last_date THEN last_posted.prev_date ELSE last_posted.last_date END, "+
+			"last_date = excluded.last_date",
+		guildID, sport, date,
+	); err != nil {
+		return fmt.Errorf("mark posted: %w", err)
+	}
+	return nil
+}
+
+// UnmarkPosted undoes a mark made ahead of a send that then failed, so the post
+// can be retried: the date marked before it is restored, or the row dropped
+// when there was none. A mark for a different date is left alone.
+func (s *Store) UnmarkPosted(guildID, key string) error {
+	sport, date := SplitDedupKey(key)
+	err := s.withTx(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec("DELETE FROM last_posted WHERE guild_id = ? AND sport = ? AND last_date = ? AND prev_date IS NULL", guildID, sport, date); err != nil {
+			return err
+		}
+		_, err := tx.Exec("UPDATE last_posted SET last_date = prev_date, prev_date = NULL WHERE guild_id = ? AND sport = ? AND last_date = ?", guildID, sport, date)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unmark posted: %w", err)
+	}
+	return nil
+}
+
+// postKindKey is how a post kind is stored in posted_events, where alerts
+// have no legacy reason to be bare.
+func postKindKey(kind string) string {
+	if kind == PostAlert {
+		return "alert"
+	}
+	return kind
+}
+
+// HasPostedKind reports whether a post of the kind was marked for the event.
+// Unlike HasPosted it tells apart two events on the same day.
+func (s *Store) HasPostedKind(guildID, org, eventID, kind string) bool {
+	var n int
+	row := s.db.QueryRowx("SELECT COUNT(*) FROM posted_events WHERE guild_id = ? AND org = ? AND event_id = ? AND kind = ?", guildID, org, eventID, postKindKey(kind))
+	if err := row.Scan(&n); err != nil {
+		logx.Error("state: has posted kind", "guild_id", guildID, "org", org, "event_id", eventID, "kind", kind, "err", err)
+	}
+	return n > 0
+}
+
+// HasPostedKindOn reports whether a post of the kind was marked for any event
+// on the YYYY-MM-DD date.
+func (s *Store) HasPostedKindOn(guildID, org, kind, yyyyMmDd string) bool {
+	var n int
+	row := s.db.QueryRowx("SELECT COUNT(*) FROM posted_events WHERE guild_id = ? AND org = ? AND kind = ? AND post_date = ?", guildID, org, postKindKey(kind), yyyyMmDd)
+	if err := row.Scan(&n); err != nil {
+		logx.Error("state: has posted kind on date", "guild_id", guildID, "org", org, "kind", kind, "date", yyyyMmDd, "err", err)
+	}
+	return n > 0
+}
+
+// MarkPostedKind records a post of the kind for the event on its YYYY-MM-DD
+// date. Like MarkPosted it returns the error so the caller can react.
+func (s *Store) MarkPostedKind(guildID, org, eventID, kind, yyyyMmDd string) error {
+	if _, err := s.db.Exec(
+		"INSERT INTO posted_events (guild_id, org, event_id, kind, post_date) VALUES (?, ?, ?, ?, ?) "+
+			"ON CONFLICT(guild_id, org, event_id, kind) DO UPDATE SET post_date = excluded.post_date",
+		guildID, org, eventID, postKindKey(kind), yyyyMmDd,
+	); err != nil {
+		return fmt.Errorf("mark posted kind: %w", err)
+	}
+	return nil
+}
+
+// UnmarkPostedKind drops an event's post mark so the post can be retried.
+func (s *Store) UnmarkPostedKind(guildID, org, eventID, kind string) error {
+	if _, err := s.db.Exec("DELETE FROM posted_events WHERE guild_id = ? AND org = ? AND event_id = ? AND kind = ?", guildID, org, eventID, postKindKey(kind)); err != nil {
+		return fmt.Errorf("unmark posted kind: %w", err)
+	}
+	return nil
+}
+
+// PostHistoryKeep is how many posts are retained per guild and org key; older
+// entries are pruned as new ones are recorded.
+const PostHistoryKeep = 25
+
+// PostRecord is one entry of a guild's posting history.
+type PostRecord struct {
+	Org       string // org key, optionally with a post kind suffix (e.g., "ufc:weighin")
+	Date      string // YYYY-MM-DD in guild TZ
+	MessageID string
+	PostedAt  time.Time
+}
+
+// RecordPost appends a successful post to the guild's history and prunes the
+// oldest entries beyond PostHistoryKeep. last_posted remains the dedup source;
+// this is for /history and auditing. Re-recording the same date updates it.
+func (s *Store) RecordPost(guildID, org, yyyyMmDd, messageID string) error {
+	err := s.withTx(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(
+			"INSERT INTO post_history (guild_id, sport, post_date, message_id, posted_at) VALUES (?, ?, ?, ?, ?) "+
+				"ON CONFLICT(guild_id, sport, post_date) DO UPDATE SET message_id = excluded.message_id, posted_at = excluded.posted_at",
+			guildID, org, yyyyMmDd, messageID, time.Now().UTC().Format(time.RFC3339),
+		); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			"DELETE FROM post_history WHERE guild_id = ? AND sport = ? AND post_date NOT IN "+
+				"(SELECT post_date FROM post_history WHERE guild_id = ? AND sport = ? ORDER BY post_date DESC LIMIT ?)",
+			guildID, org, guildID, org, PostHistoryKeep,
+		); err != nil {
+			return fmt.Errorf("prune: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("record post: %w", err)
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction, committing when it returns nil and rolling
+// back otherwise.
+func (s *Store) withTx(fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ErrGuildNotFound is returned when a guild has no stored settings.
+var ErrGuildNotFound = errors.New("guild has no stored settings")
+
+// copyGuildSkip lists guild_settings columns CopyGuild leaves untouched: IDs of
+// channels, roles, and messages that only exist in the source guild, and
+// per-guild runtime state.
+var copyGuildSkip = map[string]bool{
+	"guild_id":           true,
+	"channel_id":         true,
+	"alert_channel_id":   true,
+	"event_channel_id":   true,
+	"subscriber_role_id": true,
+	"ping_role_id":       true,
+	"pinned_channel_id":  true,
+	"pinned_message_id":  true,
+	"event_failures":     true,
+	"skip_until_ppv":     true,
+	"notify_webhook_url": true,
+	"no_channel_days":    true,
+}
+
+// CopyGuild copies src's settings and scheduled-event org exclusions onto dst
+// in one transaction, for moving a community to a new server. Posting state
+// (last_posted, posted_events, history, scheduled events) and guild-specific IDs (see
+// copyGuildSkip) are not copied. Returns ErrGuildNotFound when src has no
+// settings.
+func (s *Store) CopyGuild(src, dst string) error {
+	err := s.withTx(func(tx *sqlx.Tx) error {
+		var n int
+		if err := tx.Get(&n, "SELECT COUNT(*) FROM guild_settings WHERE guild_id = ?", src); err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrGuildNotFound
+		}
+		var cols []string
+		if err := tx.Select(&cols, "SELECT name FROM pragma_table_info('guild_settings')"); err != nil {
+			return err
+		}
+		sets := make([]string, 0, len(cols))
+		for _, c := range cols {
+			if !copyGuildSkip[c] {
+				sets = append(sets, fmt.Sprintf("%[1]s = (SELECT %[1]s FROM guild_settings WHERE guild_id = ?)", c))
+			}
+		}
+		args := make([]any, 0, len(sets)+1)
+		for range sets {
+			args = append(args, src)
+		}
+		args = append(args, dst)
+		if _, err := tx.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", dst); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE guild_settings SET "+strings.Join(sets, ", ")+" WHERE guild_id = ?", args...); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM org_event_exclusions WHERE guild_id = ?", dst); err != nil {
+			return err
+		}
+		_, err := tx.Exec("INSERT INTO org_event_exclusions (guild_id, org) SELECT ?, org FROM org_event_exclusions WHERE guild_id = ?", dst, src)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("copy guild: %w", err)
+	}
+	return nil
+}
+
+// ListPostHistory returns up to limit of the guild's recorded posts, newest
+// first (limit <= 0 returns everything retained).
+func (s *Store) ListPostHistory(guildID string, limit int) ([]PostRecord, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	rows, err := s.db.Queryx(
+		"SELECT sport, post_date, COALESCE(message_id, ''), COALESCE(posted_at, '') FROM post_history "+
+			"WHERE guild_id = ? ORDER BY post_date DESC, posted_at DESC, sport LIMIT ?",
+		guildID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list post history: %w", err)
+	}
+	defer rows.Close()
+	var out []PostRecord
+	for rows.Next() {
+		var r PostRecord
+		var ts string
+		if err := rows.Scan(&r.Org, &r.Date, &r.MessageID, &ts); err != nil {
+			return nil, fmt.Errorf("scan post history: %w", err)
+		}
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			r.PostedAt = t
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list post history: %w", err)
+	}
+	return out, nil
+}
+
+// EachPostRecord streams every guild's retained post history to fn, ordered by
+// guild, date, and key, stopping at the first error fn returns.
+func (s *Store) EachPostRecord(fn func(guildID string, r PostRecord) error) error {
+	rows, err := s.db.Queryx(
+		"SELECT guild_id, sport, post_date, COALESCE(message_id, ''), COALESCE(posted_at, '') FROM post_history " +
+			"ORDER BY guild_id, post_date, sport",
+	)
+	if err != nil {
+		return fmt.Errorf("list post history: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var guildID, ts string
+		var r PostRecord
+		if err := rows.Scan(&guildID, &r.Org, &r.Date, &r.MessageID, &ts); err != nil {
+			return fmt.Errorf("scan post history: %w", err)
+		}
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			r.PostedAt = t
+		}
+		if err := fn(guildID, r); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list post history: %w", err)
+	}
+	return nil
+}
+
+// SetPendingCard records the message that carries a posted alert whose card was
+// not yet available, so it can be updated once the card fills in. Empty IDs
+// clear it. The post must already be recorded with RecordPost.
+func (s *Store) SetPendingCard(guildID, org, yyyyMmDd, channelID, messageID string) error {
+	if _, err := s.db.Exec(
+		"UPDATE post_history SET card_channel_id = NULLIF(?, ''), card_message_id = NULLIF(?, '') WHERE guild_id = ? AND sport = ? AND post_date = ?",
+		channelID, messageID, guildID, org, yyyyMmDd,
 	); err != nil {
-		logx.Error("state: mark posted", "guild_id", guildID, "sport", sport, "err", err)
+		return fmt.Errorf("set pending card: %w", err)
 	}
+	return nil
+}
+
+// PendingCard returns the channel and message of a posted alert still waiting
+// on its card, or empty strings when there is none.
+func (s *Store) PendingCard(guildID, org, yyyyMmDd string) (channelID, messageID string) {
+	var ch, msg sql.NullString
+	row := s.db.QueryRowx("SELECT card_channel_id, card_message_id FROM post_history WHERE guild_id = ? AND sport = ? AND post_date = ?", guildID, org, yyyyMmDd)
+	_ = row.Scan(&ch, &msg)
+	return ch.String, msg.String
 }
 
 // UpdateGuildNotifyEnabled upserts the notify enabled flag for the guild.
@@ -205,11 +1054,22 @@ func (s *Store) GetGuildAnnounceEnabled(guildID string) bool {
 	var v sql.NullInt32
 	row := s.db.QueryRowx("SELECT announce FROM guild_settings WHERE guild_id = ?", guildID)
 	_ = row.Scan(&v)
-	return v.Valid && v.Int32 != 0
+	if !v.Valid {
+		return s.defaultAnnounce
+	}
+	return v.Int32 != 0
+}
+
+// SetDefaultAnnounce sets the delivery mode reported for guilds whose announce
+// setting is unset (from DEFAULT_DELIVERY). Call it before the store is shared.
+func (s *Store) SetDefaultAnnounce(enabled bool) {
+	s.defaultAnnounce = enabled
 }
 
-// UpdateGuildOrg upserts the org for the guild.
+// UpdateGuildOrg upserts the org for the guild. Org keys are stored lowercase so
+// provider lookups are stable regardless of input casing.
 func (s *Store) UpdateGuildOrg(guildID, org string) {
+	org = strings.ToLower(strings.TrimSpace(org))
 	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
 		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
 		return
@@ -219,15 +1079,16 @@ func (s *Store) UpdateGuildOrg(guildID, org string) {
 	}
 }
 
-// GetGuildOrg returns the selected org for the guild (default "ufc").
+// GetGuildOrg returns the selected org for the guild (default "ufc"), lowercased
+// so rows written before normalization still resolve.
 func (s *Store) GetGuildOrg(guildID string) string {
-	var org string
+	var org sql.NullString
 	row := s.db.QueryRowx("SELECT org FROM guild_settings WHERE guild_id = ?", guildID)
 	_ = row.Scan(&org)
-	if org == "" {
-		return "ufc"
+	if v := strings.ToLower(strings.TrimSpace(org.String)); v != "" {
+		return v
 	}
-	return org
+	return "ufc"
 }
 
 // HasGuildOrg returns true if an org has been explicitly set.
@@ -278,6 +1139,66 @@ func (s *Store) UpdateGuildEventsEnabled(guildID string, enabled bool) {
 	}
 }
 
+// RecordGuildEventFailure counts a failed scheduled event creation and returns
+// the number of consecutive failures (0 if the count could not be stored).
+func (s *Store) RecordGuildEventFailure(guildID string) int {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return 0
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET event_failures = COALESCE(event_failures, 0) + 1 WHERE guild_id = ?", guildID); err != nil {
+		logx.Error("state: update event_failures", "guild_id", guildID, "err", err)
+		return 0
+	}
+	return s.GetGuildEventFailures(guildID)
+}
+
+// ResetGuildEventFailures clears the consecutive scheduled event failure count.
+func (s *Store) ResetGuildEventFailures(guildID string) {
+	if _, err := s.db.Exec("UPDATE guild_settings SET event_failures = NULL WHERE guild_id = ?", guildID); err != nil {
+		logx.Error("state: reset event_failures", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildEventFailures returns the consecutive scheduled event failure count.
+func (s *Store) GetGuildEventFailures(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT event_failures FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return int(v.Int32)
+}
+
+// RecordGuildNoChannelDay counts a daily run where notifications were on but no
+// channel was set, and returns the consecutive count (0 if it could not be
+// stored). The count keeps growing past the prompt threshold, so reaching it
+// exactly once is what marks the owner prompt as sent.
+func (s *Store) RecordGuildNoChannelDay(guildID string) int {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return 0
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET no_channel_days = COALESCE(no_channel_days, 0) + 1 WHERE guild_id = ?", guildID); err != nil {
+		logx.Error("state: update no_channel_days", "guild_id", guildID, "err", err)
+		return 0
+	}
+	return s.GetGuildNoChannelDays(guildID)
+}
+
+// ResetGuildNoChannelDays clears the missing-channel count once a channel is set.
+func (s *Store) ResetGuildNoChannelDays(guildID string) {
+	if _, err := s.db.Exec("UPDATE guild_settings SET no_channel_days = NULL WHERE guild_id = ? AND no_channel_days IS NOT NULL", guildID); err != nil {
+		logx.Error("state: reset no_channel_days", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildNoChannelDays returns the consecutive missing-channel count.
+func (s *Store) GetGuildNoChannelDays(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT no_channel_days FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return int(v.Int32)
+}
+
 // GetGuildEventsEnabled returns true if scheduled event creation is enabled (default false).
 func (s *Store) GetGuildEventsEnabled(guildID string) bool {
 	var v sql.NullInt32
@@ -305,6 +1226,82 @@ func (s *Store) HasScheduledEvent(guildID, sport, yyyyMmDd string) bool {
 	return id != ""
 }
 
+// ScheduledEvent returns the tracked Discord event ID for the date and how many
+// times it has been recreated; ok is false when none is tracked.
+func (s *Store) ScheduledEvent(guildID, sport, yyyyMmDd string) (eventID string, recreated int, ok bool) {
+	var (
+		id sql.NullString
+		n  sql.NullInt32
+	)
+	row := s.db.QueryRowx("SELECT event_id, recreated FROM scheduled_events WHERE guild_id = ? AND sport = ? AND event_date = ?", guildID, sport, yyyyMmDd)
+	if err := row.Scan(&id, &n); err != nil || id.String == "" {
+		return "", 0, false
+	}
+	return id.String, int(n.Int32), true
+}
+
+// MarkScheduledEventRecreated tracks the replacement for a deleted scheduled
+// event and counts the recreation.
+func (s *Store) MarkScheduledEventRecreated(guildID, sport, yyyyMmDd, eventID string) {
+	if _, err := s.db.Exec(
+		"UPDATE scheduled_events SET event_id = ?, recreated = COALESCE(recreated, 0) + 1 WHERE guild_id = ? AND sport = ? AND event_date = ?",
+		eventID, guildID, sport, yyyyMmDd,
+	); err != nil {
+		logx.Error("state: mark scheduled event recreated", "guild_id", guildID, "sport", sport, "date", yyyyMmDd, "err", err)
+	}
+}
+
+// ScheduledEventDates returns the tracked scheduled events for the org dated on
+// or after fromDate (YYYY-MM-DD), mapping each date to its Discord event ID.
+func (s *Store) ScheduledEventDates(guildID, sport, fromDate string) map[string]string {
+	rows, err := s.db.Queryx("SELECT event_date, event_id FROM scheduled_events WHERE guild_id = ? AND sport = ? AND event_date >= ?", guildID, sport, fromDate)
+	if err != nil {
+		logx.Error("state: list scheduled events", "guild_id", guildID, "sport", sport, "err", err)
+		return nil
+	}
+	defer rows.Close()
+	out := map[string]string{}
+	for rows.Next() {
+		var date, id string
+		if err := rows.Scan(&date, &id); err != nil {
+			logx.Error("state: scan scheduled event", "guild_id", guildID, "sport", sport, "err", err)
+			return out
+		}
+		out[date] = id
+	}
+	return out
+}
+
+// ForgetScheduledEvent drops the tracked scheduled event for a date, e.g. once
+// the event it was created for is no longer listed.
+func (s *Store) ForgetScheduledEvent(guildID, sport, yyyyMmDd string) {
+	if _, err := s.db.Exec("DELETE FROM scheduled_events WHERE guild_id = ? AND sport = ? AND event_date = ?", guildID, sport, yyyyMmDd); err != nil {
+		logx.Error("state: forget scheduled event", "guild_id", guildID, "sport", sport, "date", yyyyMmDd, "err", err)
+	}
+}
+
+// UpdateGuildOrgEventsExcluded excludes (or re-includes) an org from scheduled event
+// creation for the guild. The guild-wide events toggle still applies to included orgs.
+func (s *Store) UpdateGuildOrgEventsExcluded(guildID, org string, excluded bool) {
+	org = strings.ToLower(strings.TrimSpace(org))
+	q := "DELETE FROM org_event_exclusions WHERE guild_id = ? AND org = ?"
+	if excluded {
+		q = "INSERT OR IGNORE INTO org_event_exclusions (guild_id, org) VALUES (?, ?)"
+	}
+	if _, err := s.db.Exec(q, guildID, org); err != nil {
+		logx.Error("state: update org event exclusion", "guild_id", guildID, "org", org, "err", err)
+	}
+}
+
+// GetGuildOrgEventsExcluded returns true if the org is excluded from scheduled event
+// creation for the guild (default false).
+func (s *Store) GetGuildOrgEventsExcluded(guildID, org string) bool {
+	var n int
+	row := s.db.QueryRowx("SELECT COUNT(1) FROM org_event_exclusions WHERE guild_id = ? AND org = ?", guildID, strings.ToLower(strings.TrimSpace(org)))
+	_ = row.Scan(&n)
+	return n > 0
+}
+
 // UpdateGuildUFCIgnoreContender toggles whether to ignore UFC Contender Series
 // when selecting next events. Default is true (ignored) when unset.
 func (s *Store) UpdateGuildUFCIgnoreContender(guildID string, ignore bool) {
@@ -332,3 +1329,951 @@ func (s *Store) GetGuildUFCIgnoreContender(guildID string) bool {
 	}
 	return v.Int32 != 0
 }
+
+// UpdateGuildPreviewEnabled toggles the editorial preview (link + headline) in event embeds.
+func (s *Store) UpdateGuildPreviewEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET preview = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update preview", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildPreviewEnabled returns true if embeds should include the event preview.
+// Default is false when unset to avoid extra upstream requests.
+func (s *Store) GetGuildPreviewEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT preview FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildNoEventMessage sets the custom reply used by /next-event when nothing
+// is scheduled. An empty message clears the override.
+func (s *Store) UpdateGuildNoEventMessage(guildID, msg string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET no_event_message = NULLIF(?, '') WHERE guild_id = ?", msg, guildID); err != nil {
+		logx.Error("state: update no_event_message", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildNoEventMessage returns the custom no-event reply, or "" when unset.
+func (s *Store) GetGuildNoEventMessage(guildID string) string {
+	var msg sql.NullString
+	row := s.db.QueryRowx("SELECT no_event_message FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&msg)
+	return msg.String
+}
+
+// UpdateGuildHeadshotsEnabled toggles the main-event fighter headshot thumbnail in embeds.
+func (s *Store) UpdateGuildHeadshotsEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET headshots = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update headshots", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildHeadshotsEnabled returns true if embeds should show a fighter headshot (default false).
+func (s *Store) GetGuildHeadshotsEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT headshots FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildStartsFormat sets the embed "Starts" line preset (e.g., long|short|relative).
+func (s *Store) UpdateGuildStartsFormat(guildID, format string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET starts_format = ? WHERE guild_id = ?", format, guildID); err != nil {
+		logx.Error("state: update starts_format", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildStartsFormat returns the embed "Starts" line preset, or "" when unset.
+func (s *Store) GetGuildStartsFormat(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT starts_format FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildLinkPreference sets which event link the embed title points to (e.g., espn|official|first).
+func (s *Store) UpdateGuildLinkPreference(guildID, pref string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET link_preference = NULLIF(?, '') WHERE guild_id = ?", pref, guildID); err != nil {
+		logx.Error("state: update link_preference", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildLinkPreference returns the embed title link preference, or "" when unset.
+func (s *Store) GetGuildLinkPreference(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT link_preference FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// DefaultMainCardSize is the number of bouts shown as the main card when a
+// guild hasn't chosen one (a typical UFC broadcast).
+const DefaultMainCardSize = 5
+
+// UpdateGuildMainCardSize sets how many bouts from the top of the card count as the main card.
+func (s *Store) UpdateGuildMainCardSize(guildID string, size int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET main_card_size = ? WHERE guild_id = ?", size, guildID); err != nil {
+		logx.Error("state: update main_card_size", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildMainCardSize returns the main card size (default DefaultMainCardSize).
+func (s *Store) GetGuildMainCardSize(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT main_card_size FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	if !v.Valid || v.Int32 < 1 {
+		return DefaultMainCardSize
+	}
+	return int(v.Int32)
+}
+
+// UpdateGuildEmbedLayout sets the card field layout (e.g., stacked|inline); empty resets it.
+func (s *Store) UpdateGuildEmbedLayout(guildID, layout string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET embed_layout = NULLIF(?, '') WHERE guild_id = ?", layout, guildID); err != nil {
+		logx.Error("state: update embed_layout", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildEmbedLayout returns the card field layout, or "" when unset.
+func (s *Store) GetGuildEmbedLayout(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT embed_layout FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildSectionOrder sets the card section order (e.g., headline|chrono); empty resets it.
+func (s *Store) UpdateGuildSectionOrder(guildID, order string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET section_order = NULLIF(?, '') WHERE guild_id = ?", order, guildID); err != nil {
+		logx.Error("state: update section_order", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildSectionOrder returns the card section order, or "" when unset.
+func (s *Store) GetGuildSectionOrder(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT section_order FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildDateFormat sets the date style (e.g., us|intl); empty resets it.
+func (s *Store) UpdateGuildDateFormat(guildID, format string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET date_format = NULLIF(?, '') WHERE guild_id = ?", format, guildID); err != nil {
+		logx.Error("state: update date_format", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildDateFormat returns the date style, or "" when unset.
+func (s *Store) GetGuildDateFormat(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT date_format FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildEventNamePrefix sets the prefix for scheduled event names; empty
+// restores the default "ORG:" prefix.
+func (s *Store) UpdateGuildEventNamePrefix(guildID, prefix string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET event_name_prefix = NULLIF(?, '') WHERE guild_id = ?", prefix, guildID); err != nil {
+		logx.Error("state: update event name prefix", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildEventNamePrefix returns the custom scheduled event name prefix, or ""
+// when unset.
+func (s *Store) GetGuildEventNamePrefix(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT event_name_prefix FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildRunTimeReference sets whether the run hour is read in the guild's
+// timezone or UTC (e.g., local|utc); empty resets it.
+func (s *Store) UpdateGuildRunTimeReference(guildID, ref string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET run_time_ref = NULLIF(?, '') WHERE guild_id = ?", ref, guildID); err != nil {
+		logx.Error("state: update run_time_ref", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildRunTimeReference returns the run hour reference, or "" when unset.
+func (s *Store) GetGuildRunTimeReference(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT run_time_ref FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildResultMethod sets how finish methods appear next to winners
+// (e.g., emoji|text|off); empty resets it.
+func (s *Store) UpdateGuildResultMethod(guildID, mode string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET result_method = NULLIF(?, '') WHERE guild_id = ?", mode, guildID); err != nil {
+		logx.Error("state: update result_method", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildResultMethod returns the finish method display mode, or "" when unset.
+func (s *Store) GetGuildResultMethod(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT result_method FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildResultEmojis stores the guild's finish method emoji set as
+// "ko,sub,dec"; empty resets it to the built-in set.
+func (s *Store) UpdateGuildResultEmojis(guildID, set string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET result_emojis = NULLIF(?, '') WHERE guild_id = ?", set, guildID); err != nil {
+		logx.Error("state: update result_emojis", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildResultEmojis returns the stored emoji set, or "" when unset.
+func (s *Store) GetGuildResultEmojis(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT result_emojis FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildResultsReactions sets the emoji the bot adds to its results posts;
+// nil or empty turns them off.
+func (s *Store) UpdateGuildResultsReactions(guildID string, emojis []string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET results_reactions = NULLIF(?, '') WHERE guild_id = ?", strings.Join(emojis, ","), guildID); err != nil {
+		logx.Error("state: update results_reactions", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildResultsReactions returns the results post reaction emoji, or nil
+// when off.
+func (s *Store) GetGuildResultsReactions(guildID string) []string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT results_reactions FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	var out []string
+	for _, p := range strings.Split(v.String, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// UpdateGuildMaxAnnounceDays sets how far ahead /next-event will show an event; 0 clears the limit.
+func (s *Store) UpdateGuildMaxAnnounceDays(guildID string, days int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET max_announce_days = NULLIF(?, 0) WHERE guild_id = ?", days, guildID); err != nil {
+		logx.Error("state: update max_announce_days", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildMaxAnnounceDays returns the /next-event window in days, or 0 when unlimited.
+func (s *Store) GetGuildMaxAnnounceDays(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT max_announce_days FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return int(v.Int32)
+}
+
+// UpdateGuildScheduledEventLeadDays sets how many days ahead of an event the
+// Discord scheduled event is created.
+func (s *Store) UpdateGuildScheduledEventLeadDays(guildID string, days int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET event_lead_days = ? WHERE guild_id = ?", days, guildID); err != nil {
+		logx.Error("state: update event_lead_days", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildScheduledEventLeadDays returns the scheduled event lead time in days
+// (default 1, i.e., the day before).
+func (s *Store) GetGuildScheduledEventLeadDays(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT event_lead_days FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	if !v.Valid || v.Int32 < 1 {
+		return 1
+	}
+	return int(v.Int32)
+}
+
+// UpdateGuildWeighInEnabled toggles the day-before weigh-in reminder.
+func (s *Store) UpdateGuildWeighInEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET weigh_in = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update weigh_in", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildWeighInEnabled returns true if the weigh-in reminder is enabled (default false).
+func (s *Store) GetGuildWeighInEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT weigh_in FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildPostponeNotice toggles the channel notice posted when a tracked
+// event is postponed or cancelled.
+func (s *Store) UpdateGuildPostponeNotice(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET postpone_notice = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update postpone_notice", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildPostponeNotice returns true if postponement notices are enabled (default false).
+func (s *Store) GetGuildPostponeNotice(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT postpone_notice FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildBroadcastOptOut sets whether the guild skips non-critical
+// operator broadcasts.
+func (s *Store) UpdateGuildBroadcastOptOut(guildID string, optOut bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if optOut {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET broadcast_opt_out = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update broadcast_opt_out", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildBroadcastOptOut returns true if the guild opted out of non-critical
+// operator broadcasts (default false).
+func (s *Store) GetGuildBroadcastOptOut(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT broadcast_opt_out FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildWeighInMessage sets the custom weigh-in reminder text; empty resets it.
+func (s *Store) UpdateGuildWeighInMessage(guildID, msg string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET weigh_in_message = NULLIF(?, '') WHERE guild_id = ?", msg, guildID); err != nil {
+		logx.Error("state: update weigh_in_message", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildWeighInMessage returns the custom weigh-in reminder text, or "" when unset.
+func (s *Store) GetGuildWeighInMessage(guildID string) string {
+	var msg sql.NullString
+	row := s.db.QueryRowx("SELECT weigh_in_message FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&msg)
+	return msg.String
+}
+
+// UpdateGuildMessageTemplate sets the fight-night alert text template; empty
+// resets it to the built-in format.
+func (s *Store) UpdateGuildMessageTemplate(guildID, tmpl string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET message_template = NULLIF(?, '') WHERE guild_id = ?", tmpl, guildID); err != nil {
+		logx.Error("state: update message_template", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildMessageTemplate returns the alert text template, or "" when unset.
+func (s *Store) GetGuildMessageTemplate(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT message_template FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildCardUpdateMode sets how a late card is delivered (e.g., edit|new); empty resets it.
+func (s *Store) UpdateGuildCardUpdateMode(guildID, mode string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET card_update_mode = NULLIF(?, '') WHERE guild_id = ?", mode, guildID); err != nil {
+		logx.Error("state: update card_update_mode", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildCardUpdateMode returns the card update mode, or "" when unset.
+func (s *Store) GetGuildCardUpdateMode(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT card_update_mode FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildHelpVisibility sets whether /help replies publicly or
+// ephemerally (public|ephemeral); empty resets it.
+func (s *Store) UpdateGuildHelpVisibility(guildID, visibility string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET help_visibility = NULLIF(?, '') WHERE guild_id = ?", visibility, guildID); err != nil {
+		logx.Error("state: update help_visibility", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildHelpVisibility returns the /help visibility, or "" when unset.
+func (s *Store) GetGuildHelpVisibility(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT help_visibility FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildSubscriberRole sets the opt-in role pinged by fight-night alerts; empty clears it.
+func (s *Store) UpdateGuildSubscriberRole(guildID, roleID string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET subscriber_role_id = NULLIF(?, '') WHERE guild_id = ?", roleID, guildID); err != nil {
+		logx.Error("state: update subscriber_role_id", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildSubscriberRole returns the subscriber role ID, or "" when unset.
+func (s *Store) GetGuildSubscriberRole(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT subscriber_role_id FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildPingRole sets the role every fight-night alert pings; empty clears it.
+func (s *Store) UpdateGuildPingRole(guildID, roleID string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET ping_role_id = NULLIF(?, '') WHERE guild_id = ?", roleID, guildID); err != nil {
+		logx.Error("state: update ping_role_id", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildPingRole returns the ping role ID, or "" when unset.
+func (s *Store) GetGuildPingRole(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT ping_role_id FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildNotifyWebhookURL sets the outbound webhook POSTed after each
+// fight-night alert; empty clears it.
+func (s *Store) UpdateGuildNotifyWebhookURL(guildID, url string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET notify_webhook_url = NULLIF(?, '') WHERE guild_id = ?", url, guildID); err != nil {
+		logx.Error("state: update notify_webhook_url", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildNotifyWebhookURL returns the outbound webhook URL, or "" when unset.
+func (s *Store) GetGuildNotifyWebhookURL(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT notify_webhook_url FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildReminderOffsets sets the hours before each event's start at which
+// reminders are posted; nil or empty turns reminders off.
+func (s *Store) UpdateGuildReminderOffsets(guildID string, hours []int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	parts := make([]string, 0, len(hours))
+	for _, h := range hours {
+		parts = append(parts, strconv.Itoa(h))
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET reminder_offsets = NULLIF(?, '') WHERE guild_id = ?", strings.Join(parts, ","), guildID); err != nil {
+		logx.Error("state: update reminder_offsets", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildReminderOffsets returns the reminder offsets in hours, or nil when
+// reminders are off.
+func (s *Store) GetGuildReminderOffsets(guildID string) []int {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT reminder_offsets FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return parseOffsets(v.String)
+}
+
+// parseOffsets reads a stored "24,1" offset list, skipping malformed entries.
+func parseOffsets(s string) []int {
+	var out []int
+	for _, p := range strings.Split(s, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(p)); err == nil && n > 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// UpdateGuildAlertChannel sets the channel fight-night alerts, weigh-in
+// reminders and fight-week promos go to; empty falls back to the main channel.
+func (s *Store) UpdateGuildAlertChannel(guildID, channelID string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET alert_channel_id = NULLIF(?, '') WHERE guild_id = ?", channelID, guildID); err != nil {
+		logx.Error("state: update alert_channel_id", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildAlertChannel returns the alert channel override, or "" when unset.
+func (s *Store) GetGuildAlertChannel(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT alert_channel_id FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildEventChannel sets the channel that scheduled-event links are
+// announced in; empty falls back to the main channel.
+func (s *Store) UpdateGuildEventChannel(guildID, channelID string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET event_channel_id = NULLIF(?, '') WHERE guild_id = ?", channelID, guildID); err != nil {
+		logx.Error("state: update event_channel_id", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildEventChannel returns the event-link channel override, or "" when unset.
+func (s *Store) GetGuildEventChannel(guildID string) string {
+	var v sql.NullString
+	row := s.db.QueryRowx("SELECT event_channel_id FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.String
+}
+
+// UpdateGuildFightWeekDays sets how many days before an event the fight-week
+// promo is posted; 0 disables it.
+func (s *Store) UpdateGuildFightWeekDays(guildID string, days int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET fight_week_days = ? WHERE guild_id = ?", days, guildID); err != nil {
+		logx.Error("state: update fight_week_days", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildFightWeekDays returns the fight-week promo lead in days, or 0 when disabled (default).
+func (s *Store) GetGuildFightWeekDays(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT fight_week_days FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	if v.Int32 < 0 {
+		return 0
+	}
+	return int(v.Int32)
+}
+
+// UpdateGuildLiveSoonMinutes sets how many minutes before an event starts the
+// live-soon reminder posts; 0 disables it.
+func (s *Store) UpdateGuildLiveSoonMinutes(guildID string, minutes int) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET live_soon_minutes = ? WHERE guild_id = ?", minutes, guildID); err != nil {
+		logx.Error("state: update live_soon_minutes", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildLiveSoonMinutes returns the live-soon reminder lead in minutes, or 0 when disabled (default).
+func (s *Store) GetGuildLiveSoonMinutes(guildID string) int {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT live_soon_minutes FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	if v.Int32 < 0 {
+		return 0
+	}
+	return int(v.Int32)
+}
+
+// UpdateGuildFightWeekMessage sets the custom fight-week promo text; empty resets it.
+func (s *Store) UpdateGuildFightWeekMessage(guildID, msg string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET fight_week_message = NULLIF(?, '') WHERE guild_id = ?", msg, guildID); err != nil {
+		logx.Error("state: update fight_week_message", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildFightWeekMessage returns the custom fight-week promo text, or "" when unset.
+func (s *Store) GetGuildFightWeekMessage(guildID string) string {
+	var msg sql.NullString
+	row := s.db.QueryRowx("SELECT fight_week_message FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&msg)
+	return msg.String
+}
+
+// UpdateGuildRankingsEnabled toggles division ranking/champion annotations in event embeds.
+func (s *Store) UpdateGuildRankingsEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET rankings = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update rankings", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildRankingsEnabled returns true if ranking annotations are enabled (default false).
+func (s *Store) GetGuildRankingsEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT rankings FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildFlagsEnabled toggles fighter country flags in event embeds.
+func (s *Store) UpdateGuildFlagsEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET flags = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update flags", "guild_id", guildID, "err", err)
+	}
+}
+
+// UpdateGuildNotesEnabled toggles bout notes (e.g., "Rematch") in event embeds.
+func (s *Store) UpdateGuildNotesEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET notes = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update notes", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildNotesEnabled returns true if bout notes are enabled (default false).
+func (s *Store) GetGuildNotesEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT notes FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// GetGuildFlagsEnabled returns true if fighter country flags are enabled (default false).
+func (s *Store) GetGuildFlagsEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT flags FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildDebugIDsEnabled toggles showing provider event IDs in /next-event
+// replies, for support requests.
+func (s *Store) UpdateGuildDebugIDsEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET debug_ids = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update debug_ids", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildDebugIDsEnabled returns true if event IDs are shown (default false).
+func (s *Store) GetGuildDebugIDsEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT debug_ids FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildNextEventTeaserEnabled toggles the "Then:" line naming the event
+// after the next one in /next-event replies.
+func (s *Store) UpdateGuildNextEventTeaserEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET next_event_teaser = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update next_event_teaser", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildNextEventTeaserEnabled returns true if the teaser line is shown (default false).
+func (s *Store) GetGuildNextEventTeaserEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT next_event_teaser FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildSkipUntilPPV sets or clears the one-shot mode that suppresses
+// notifications for non-PPV events; the notifier clears it once a PPV posts.
+func (s *Store) UpdateGuildSkipUntilPPV(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET skip_until_ppv = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update skip_until_ppv", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildSkipUntilPPV returns true while non-PPV notifications are suppressed (default false).
+func (s *Store) GetGuildSkipUntilPPV(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT skip_until_ppv FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildShowEndEnabled toggles the "Ends" line in event embeds.
+func (s *Store) UpdateGuildShowEndEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET show_end = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update show_end", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildShowEndEnabled returns true if the embed should show the end time (default false).
+func (s *Store) GetGuildShowEndEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT show_end FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// UpdateGuildPinEnabled toggles pinning the notifier's fight-night message.
+func (s *Store) UpdateGuildPinEnabled(guildID string, enabled bool) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	val := 0
+	if enabled {
+		val = 1
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET pin = ? WHERE guild_id = ?", val, guildID); err != nil {
+		logx.Error("state: update pin", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildPinEnabled returns true if notifier messages should be pinned (default false).
+func (s *Store) GetGuildPinEnabled(guildID string) bool {
+	var v sql.NullInt32
+	row := s.db.QueryRowx("SELECT pin FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&v)
+	return v.Valid && v.Int32 != 0
+}
+
+// AlertSections toggles the parts of a fight-night alert: the header line
+// ("UFC Fight Night Alert:"), the closing trailer line, and the card embed.
+// The event lines themselves are always sent.
+type AlertSections struct {
+	Header  bool
+	Trailer bool
+	Embed   bool
+}
+
+// DefaultAlertSections is what guilds that never changed /settings alert content get.
+var DefaultAlertSections = AlertSections{Header: true, Trailer: false, Embed: true}
+
+// alertSections applies DefaultAlertSections to unset columns.
+func alertSections(header, trailer, embed sql.NullInt32) AlertSections {
+	pick := func(v sql.NullInt32, def bool) bool {
+		if !v.Valid {
+			return def
+		}
+		return v.Int32 != 0
+	}
+	d := DefaultAlertSections
+	return AlertSections{Header: pick(header, d.Header), Trailer: pick(trailer, d.Trailer), Embed: pick(embed, d.Embed)}
+}
+
+// UpdateGuildAlertSections stores which parts of the fight-night alert are sent.
+func (s *Store) UpdateGuildAlertSections(guildID string, a AlertSections) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	if _, err := s.db.Exec("UPDATE guild_settings SET alert_header = ?, alert_trailer = ?, alert_embed = ? WHERE guild_id = ?",
+		b(a.Header), b(a.Trailer), b(a.Embed), guildID); err != nil {
+		logx.Error("state: update alert sections", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildAlertSections returns which parts of the fight-night alert are sent
+// (DefaultAlertSections for anything unset).
+func (s *Store) GetGuildAlertSections(guildID string) AlertSections {
+	var header, trailer, embed sql.NullInt32
+	row := s.db.QueryRowx("SELECT alert_header, alert_trailer, alert_embed FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&header, &trailer, &embed)
+	return alertSections(header, trailer, embed)
+}
+
+// UpdateGuildPinnedMessage records the message the bot last pinned so it can be
+// unpinned when the next event is posted. Empty IDs clear the record.
+func (s *Store) UpdateGuildPinnedMessage(guildID, channelID, messageID string) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)", guildID); err != nil {
+		logx.Error("state: ensure guild", "guild_id", guildID, "err", err)
+		return
+	}
+	if _, err := s.db.Exec(
+		"UPDATE guild_settings SET pinned_channel_id = NULLIF(?, ''), pinned_message_id = NULLIF(?, '') WHERE guild_id = ?",
+		channelID, messageID, guildID,
+	); err != nil {
+		logx.Error("state: update pinned message", "guild_id", guildID, "err", err)
+	}
+}
+
+// GetGuildPinnedMessage returns the channel and message ID the bot last pinned, if any.
+func (s *Store) GetGuildPinnedMessage(guildID string) (channelID, messageID string) {
+	row := s.db.QueryRowx("SELECT COALESCE(pinned_channel_id, ''), COALESCE(pinned_message_id, '') FROM guild_settings WHERE guild_id = ?", guildID)
+	_ = row.Scan(&channelID, &messageID)
+	return channelID, messageID
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -82,16 +84,392 @@ func TestMarkPosted_UpsertAndRead(t *testing.T) {
 	st := Load(":memory:")
 	st.UpdateGuildChannel("g1", "c1") // ensure row
 
-	st.MarkPosted("g1", "ufc", "2024-08-27")
+	st.MarkPosted("g1", DedupKey("ufc", PostAlert, "2024-08-27"))
 	_, _, last := st.GetGuildSettings("g1")
 	if got := last["ufc"]; got != "2024-08-27" {
 		t.Fatalf("last-posted after first mark: got %q", got)
 	}
 
 	// Update date for same sport
-	st.MarkPosted("g1", "ufc", "2024-09-01")
+	st.MarkPosted("g1", DedupKey("ufc", PostAlert, "2024-09-01"))
 	_, _, last2 := st.GetGuildSettings("g1")
 	if got := last2["ufc"]; got != "2024-09-01" {
 		t.Fatalf("last-posted after update: got %q", got)
 	}
 }
+
+func TestDedupKey_Scheme(t *testing.T) {
+	tests := []struct {
+		org, kind, date string
+		key, sport      string
+	}{
+		{"ufc", PostAlert, "2024-04-13", "ufc:2024-04-13", "ufc"},
+		{"ufc", PostWeighIn, "2024-04-12", "ufc:weighin:2024-04-12", "ufc:weighin"},
+		{"ufc", PostFightWeek, "2024-04-13", "ufc:fightweek:2024-04-13", "ufc:fightweek"},
+	}
+	for _, tc := range tests {
+		key := DedupKey(tc.org, tc.kind, tc.date)
+		if key != tc.key {
+			t.Fatalf("DedupKey(%q, %q, %q) = %q, want %q", tc.org, tc.kind, tc.date, key, tc.key)
+		}
+		if sport, date := SplitDedupKey(key); sport != tc.sport || date != tc.date {
+			t.Fatalf("SplitDedupKey(%q) = (%q, %q), want (%q, %q)", key, sport, date, tc.sport, tc.date)
+		}
+	}
+}
+
+func TestHasPosted_MarkAndUnmark(t *testing.T) {
+	st := Load(":memory:")
+	alert := DedupKey("ufc", PostAlert, "2024-04-13")
+	weighIn := DedupKey("ufc", PostWeighIn, "2024-04-12")
+	if st.HasPosted("g1", alert) {
+		t.Fatalf("expected nothing posted yet")
+	}
+	if err := st.MarkPosted("g1", alert); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+	if !st.HasPosted("g1", alert) || st.HasPosted("g1", weighIn) || st.HasPosted("g2", alert) {
+		t.Fatalf("expected only the alert marked for g1")
+	}
+	// A new date replaces the old one for the same kind.
+	next := DedupKey("ufc", PostAlert, "2024-04-20")
+	if err := st.MarkPosted("g1", next); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+	if st.HasPosted("g1", alert) || !st.HasPosted("g1", next) {
+		t.Fatalf("expected the later date to replace the earlier one")
+	}
+	// Unmarking a different date is a no-op; the matching date clears it.
+	if err := st.UnmarkPosted("g1", alert); err != nil || !st.HasPosted("g1", next) {
+		t.Fatalf("expected unmark of a stale date to keep the mark (err %v)", err)
+	}
+	// Releasing a mark restores the date it replaced.
+	if err := st.UnmarkPosted("g1", next); err != nil || st.HasPosted("g1", next) || !st.HasPosted("g1", alert) {
+		t.Fatalf("expected unmark to restore the previous date (err %v)", err)
+	}
+	// With nothing before it, the mark is cleared.
+	if err := st.UnmarkPosted("g1", alert); err != nil || st.HasPosted("g1", alert) {
+		t.Fatalf("expected unmark to clear the mark (err %v)", err)
+	}
+	// Re-marking the same date keeps the earlier date to fall back to.
+	_ = st.MarkPosted("g1", alert)
+	_ = st.MarkPosted("g1", next)
+	_ = st.MarkPosted("g1", next)
+	if err := st.UnmarkPosted("g1", next); err != nil || !st.HasPosted("g1", alert) {
+		t.Fatalf("expected a repeated mark to keep the prior date (err %v)", err)
+	}
+}
+
+func TestHasPostedKind_KeysOnEvent(t *testing.T) {
+	st := Load(":memory:")
+	if st.HasPostedKind("g1", "ufc", "600041", PostAlert) || st.HasPostedKindOn("g1", "ufc", PostAlert, "2024-04-13") {
+		t.Fatalf("expected nothing posted yet")
+	}
+	if err := st.MarkPostedKind("g1", "ufc", "600041", PostAlert, "2024-04-13"); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+	// A second event on the same day is tracked separately.
+	if err := st.MarkPostedKind("g1", "ufc", "600042", PostAlert, "2024-04-13"); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+	for _, id := range []string{"600041", "600042"} {
+		if !st.HasPostedKind("g1", "ufc", id, PostAlert) {
+			t.Fatalf("expected alert for %s marked", id)
+		}
+	}
+	switch {
+	case st.HasPostedKind("g1", "ufc", "600043", PostAlert):
+		t.Fatalf("unmarked event reported as posted")
+	case st.HasPostedKind("g1", "ufc", "600041", PostWeighIn):
+		t.Fatalf("alert mark leaked to weigh-in kind")
+	case st.HasPostedKind("g2", "ufc", "600041", PostAlert), st.HasPostedKind("g1", "pfl", "600041", PostAlert):
+		t.Fatalf("mark leaked to another guild or org")
+	case !st.HasPostedKindOn("g1", "ufc", PostAlert, "2024-04-13") || st.HasPostedKindOn("g1", "ufc", PostAlert, "2024-04-14"):
+		t.Fatalf("expected only 2024-04-13 to have alert marks")
+	}
+	if err := st.UnmarkPostedKind("g1", "ufc", "600041", PostAlert); err != nil {
+		t.Fatalf("unmark: %v", err)
+	}
+	if st.HasPostedKind("g1", "ufc", "600041", PostAlert) || !st.HasPostedKind("g1", "ufc", "600042", PostAlert) {
+		t.Fatalf("expected unmark to clear only 600041")
+	}
+}
+
+func TestGuildOrg_NormalizedToLowercase(t *testing.T) {
+	st := Load(":memory:")
+
+	for _, in := range []string{"UFC", " Ufc ", "ufc"} {
+		st.UpdateGuildOrg("g1", in)
+		if got := st.GetGuildOrg("g1"); got != "ufc" {
+			t.Fatalf("UpdateGuildOrg(%q): got %q want ufc", in, got)
+		}
+	}
+
+	// Rows written before normalization still read back lowercase
+	if _, err := st.db.Exec("INSERT INTO guild_settings (guild_id, org) VALUES (?, ?)", "g2", "PFL"); err != nil {
+		t.Fatalf("seed legacy org: %v", err)
+	}
+	if got := st.GetGuildOrg("g2"); got != "pfl" {
+		t.Fatalf("legacy mixed-case org: got %q want pfl", got)
+	}
+}
+
+func TestGuildOrgEventsExcluded_PerOrg(t *testing.T) {
+	st := Load(":memory:")
+
+	if st.GetGuildOrgEventsExcluded("g1", "ufc") {
+		t.Fatalf("expected orgs included by default")
+	}
+	st.UpdateGuildOrgEventsExcluded("g1", "UFC", true)
+	st.UpdateGuildOrgEventsExcluded("g1", "UFC", true) // idempotent
+	if !st.GetGuildOrgEventsExcluded("g1", "ufc") {
+		t.Fatalf("expected ufc excluded")
+	}
+	if st.GetGuildOrgEventsExcluded("g1", "pfl") || st.GetGuildOrgEventsExcluded("g2", "ufc") {
+		t.Fatalf("exclusion leaked to another org or guild")
+	}
+	st.UpdateGuildOrgEventsExcluded("g1", "ufc", false)
+	if st.GetGuildOrgEventsExcluded("g1", "ufc") {
+		t.Fatalf("expected ufc included again")
+	}
+}
+
+func TestListAllGuildConfigs_BatchRead(t *testing.T) {
+	st := Load(":memory:")
+
+	st.UpdateGuildChannel("g1", "c1")
+	st.UpdateGuildTZ("g1", "UTC")
+	st.UpdateGuildOrg("g1", "UFC")
+	st.UpdateGuildNotifyEnabled("g1", true)
+	st.UpdateGuildRunHour("g1", 18)
+	st.UpdateGuildEventsEnabled("g1", true)
+	st.UpdateGuildScheduledEventLeadDays("g1", 14)
+	st.UpdateGuildOrgEventsExcluded("g1", "ufc", true)
+	st.UpdateGuildRankingsEnabled("g1", true)
+	st.UpdateGuildWeighInMessage("g1", "Weigh-ins!")
+	if err := st.MarkPosted("g1", DedupKey("ufc", PostAlert, "2024-04-13")); err != nil {
+		t.Fatalf("mark posted: %v", err)
+	}
+	if err := st.MarkPosted("g1", DedupKey("ufc", PostWeighIn, "2024-04-13")); err != nil {
+		t.Fatalf("mark posted: %v", err)
+	}
+
+	st.UpdateGuildChannel("g2", "c2")
+	st.UpdateGuildUFCIgnoreContender("g2", false)
+
+	st.UpdateGuildMaxAnnounceDays("g3", 30)
+
+	got, err := st.ListAllGuildConfigs()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 guilds, got %d: %+v", len(got), got)
+	}
+
+	g1 := got[0]
+	want1 := GuildConfig{
+		GuildID:            "g1",
+		ChannelID:          "c1",
+		Timezone:           "UTC",
+		LastPosted:         map[string]string{"ufc": "2024-04-13", "ufc:weighin": "2024-04-13"},
+		Org:                "ufc",
+		NotifyEnabled:      true,
+		RunHour:            18,
+		Events:             true,
+		EventLeadDays:      14,
+		ExcludedEventOrgs:  []string{"ufc"},
+		UFCIgnoreContender: true,
+		WeighInMessage:     "Weigh-ins!",
+		Rankings:           true,
+		MainCardSize:       DefaultMainCardSize,
+		AlertSections:      DefaultAlertSections,
+	}
+	if !reflect.DeepEqual(g1, want1) {
+		t.Fatalf("g1 config:\n got %+v\nwant %+v", g1, want1)
+	}
+
+	// Unset fields use the getters' defaults.
+	g2 := got[1]
+	if g2.GuildID != "g2" || g2.ChannelID != "c2" || g2.Org != "" || g2.RunHour != -1 || g2.EventLeadDays != 1 || g2.MainCardSize != DefaultMainCardSize ||
+		g2.UFCIgnoreContender || len(g2.LastPosted) != 0 || g2.ExcludedEventOrgs != nil {
+		t.Fatalf("g2 config: %+v", g2)
+	}
+	if g3 := got[2]; g3.GuildID != "g3" || g3.MaxAnnounceDays != 30 || !g3.UFCIgnoreContender {
+		t.Fatalf("g3 config: %+v", g3)
+	}
+
+	// Matches the per-guild getters.
+	for _, c := range got {
+		if c.NotifyEnabled != st.GetGuildNotifyEnabled(c.GuildID) || c.RunHour != st.GetGuildRunHour(c.GuildID) ||
+			c.EventLeadDays != st.GetGuildScheduledEventLeadDays(c.GuildID) {
+			t.Fatalf("batch read disagrees with getters for %s: %+v", c.GuildID, c)
+		}
+	}
+}
+
+func TestGuildAnnounceEnabled_ConfiguredDefault(t *testing.T) {
+	st := Load(":memory:")
+	st.SetDefaultAnnounce(true)
+
+	if !st.GetGuildAnnounceEnabled("new") {
+		t.Fatalf("expected new guild to default to announcement delivery")
+	}
+	st.UpdateGuildAnnounceEnabled("g1", false)
+	if st.GetGuildAnnounceEnabled("g1") {
+		t.Fatalf("expected explicit message delivery to override the default")
+	}
+	st.UpdateGuildChannel("g2", "c2") // row exists, announce still unset
+	cfgs, err := st.ListAllGuildConfigs()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, c := range cfgs {
+		if want := c.GuildID == "g2"; c.Announce != want {
+			t.Fatalf("%s: announce=%v want %v", c.GuildID, c.Announce, want)
+		}
+	}
+}
+
+func TestPostHistory_RecordListAndPrune(t *testing.T) {
+	st := Load(":memory:")
+	for i := 1; i <= PostHistoryKeep+3; i++ {
+		date := fmt.Sprintf("2024-%02d-%02d", 1+i/28, 1+i%28)
+		if err := st.RecordPost("g1", "ufc", date, fmt.Sprintf("m%d", i)); err != nil {
+			t.Fatalf("record post: %v", err)
+		}
+	}
+	// Re-recording the same date updates rather than duplicates.
+	if err := st.RecordPost("g1", "ufc:weighin", "2024-04-12", "w1"); err != nil {
+		t.Fatalf("record post: %v", err)
+	}
+	if err := st.RecordPost("g1", "ufc:weighin", "2024-04-12", "w2"); err != nil {
+		t.Fatalf("record post: %v", err)
+	}
+	_ = st.RecordPost("g2", "ufc", "2024-04-13", "other")
+
+	all, err := st.ListPostHistory("g1", 0)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var ufc []string
+	for _, r := range all {
+		if r.Org == "ufc" {
+			ufc = append(ufc, r.Date)
+		}
+		if r.PostedAt.IsZero() {
+			t.Fatalf("expected posted_at recorded: %+v", r)
+		}
+	}
+	if len(ufc) != PostHistoryKeep {
+		t.Fatalf("expected %d retained ufc posts, got %d", PostHistoryKeep, len(ufc))
+	}
+	if !sort.IsSorted(sort.Reverse(sort.StringSlice(ufc))) {
+		t.Fatalf("expected newest first, got %v", ufc)
+	}
+	if ufc[len(ufc)-1] != "2024-01-05" {
+		t.Fatalf("expected oldest entries pruned, oldest kept %q", ufc[len(ufc)-1])
+	}
+
+	recent, err := st.ListPostHistory("g1", 3)
+	if err != nil || len(recent) != 3 {
+		t.Fatalf("expected 3 records, got %d (err %v)", len(recent), err)
+	}
+	if recent[0].Org != "ufc:weighin" || recent[1].Org != "ufc" || recent[1].Date != "2024-02-01" || recent[1].MessageID != "m28" {
+		t.Fatalf("unexpected newest records: %+v", recent)
+	}
+	for _, r := range all {
+		if r.Org == "ufc:weighin" && r.MessageID != "w2" {
+			t.Fatalf("expected re-record to update message id, got %+v", r)
+		}
+	}
+	if got, _ := st.ListPostHistory("none", 0); len(got) != 0 {
+		t.Fatalf("expected empty history for unknown guild")
+	}
+}
+
+func TestCopyGuild_CopiesSettingsToFreshGuild(t *testing.T) {
+	st := Load(":memory:")
+
+	st.UpdateGuildChannel("src", "c1")
+	st.UpdateGuildTZ("src", "America/New_York")
+	st.UpdateGuildOrg("src", "ufc")
+	st.UpdateGuildNotifyEnabled("src", true)
+	st.UpdateGuildRunHour("src", 18)
+	st.UpdateGuildEventsEnabled("src", true)
+	st.UpdateGuildOrgEventsExcluded("src", "ufc", true)
+	st.UpdateGuildWeighInMessage("src", "Weigh-ins!")
+	st.UpdateGuildSubscriberRole("src", "r1")
+	st.UpdateGuildAlertSections("src", AlertSections{Header: false, Trailer: true, Embed: true})
+	if err := st.MarkPosted("src", DedupKey("ufc", PostAlert, "2024-04-13")); err != nil {
+		t.Fatalf("mark posted: %v", err)
+	}
+
+	if err := st.CopyGuild("src", "dst"); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+
+	all, err := st.ListAllGuildConfigs()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	byID := map[string]GuildConfig{}
+	for _, c := range all {
+		byID[c.GuildID] = c
+	}
+	want := byID["src"]
+	want.GuildID = "dst"
+	want.ChannelID = ""
+	want.SubscriberRoleID = ""
+	want.LastPosted = map[string]string{}
+	if got := byID["dst"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("copied config mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+	if st.HasPosted("dst", DedupKey("ufc", PostAlert, "2024-04-13")) {
+		t.Fatalf("expected last_posted not to be copied")
+	}
+}
+
+func TestGuildPingRole_SetGetUnset(t *testing.T) {
+	st := Load(":memory:")
+	if got := st.GetGuildPingRole("g1"); got != "" {
+		t.Fatalf("expected no ping role by default, got %q", got)
+	}
+	st.UpdateGuildPingRole("g1", "r42")
+	if got := st.GetGuildPingRole("g1"); got != "r42" {
+		t.Fatalf("expected r42, got %q", got)
+	}
+	st.UpdateGuildPingRole("g1", "")
+	if got := st.GetGuildPingRole("g1"); got != "" {
+		t.Fatalf("expected ping role cleared, got %q", got)
+	}
+}
+
+func TestReminderOffsetsAndMarks(t *testing.T) {
+	st := Load(":memory:")
+	if got := st.GetGuildReminderOffsets("g1"); got != nil {
+		t.Fatalf("expected reminders off by default, got %v", got)
+	}
+	st.UpdateGuildReminderOffsets("g1", []int{24, 1})
+	if got := st.GetGuildReminderOffsets("g1"); !reflect.DeepEqual(got, []int{24, 1}) {
+		t.Fatalf("expected [24 1], got %v", got)
+	}
+	st.UpdateGuildReminderOffsets("g1", nil)
+	if got := st.GetGuildReminderOffsets("g1"); got != nil {
+		t.Fatalf("expected reminders cleared, got %v", got)
+	}
+
+	if got := ReminderKind(24); got != "reminder-24h" {
+		t.Fatalf("ReminderKind(24) = %q", got)
+	}
+}
+
+func TestCopyGuild_MissingSource(t *testing.T) {
+	st := Load(":memory:")
+	if err := st.CopyGuild("nope", "dst"); !errors.Is(err, ErrGuildNotFound) {
+		t.Fatalf("expected ErrGuildNotFound, got %v", err)
+	}
+	if ids := st.GuildIDs(); len(ids) != 0 {
+		t.Fatalf("expected no guild rows created, got %v", ids)
+	}
+}